@@ -9,9 +9,14 @@ import (
 
 	"github.com/urfave/cli/v2"
 
+	"github.com/tentacle-scylla/scql/pkg/analyze"
+	"github.com/tentacle-scylla/scql/pkg/ast"
 	"github.com/tentacle-scylla/scql/pkg/format"
 	"github.com/tentacle-scylla/scql/pkg/lint"
+	"github.com/tentacle-scylla/scql/pkg/lsp"
 	"github.com/tentacle-scylla/scql/pkg/parse"
+	"github.com/tentacle-scylla/scql/pkg/report"
+	"github.com/tentacle-scylla/scql/pkg/schema"
 )
 
 func main() {
@@ -23,6 +28,8 @@ func main() {
 			lintCmd(),
 			formatCmd(),
 			parseCmd(),
+			analyzeCmd(),
+			lspCmd(),
 		},
 	}
 
@@ -48,38 +55,43 @@ func lintCmd() *cli.Command {
 				Aliases: []string{"q"},
 				Usage:   "Only output errors, no success message",
 			},
+			outputFlag(),
 		},
 		Action: func(c *cli.Context) error {
+			output, err := report.ParseFormat(c.String("output"))
+			if err != nil {
+				return err
+			}
 			input, err := getInput(c)
 			if err != nil {
 				return err
 			}
 
 			results := lint.AnalyzeMultiple(input)
-			hasErrors := false
-			totalStatements := 0
+			var diags []report.Diagnostic
 			validStatements := 0
-
 			for _, r := range results {
-				totalStatements++
 				if r.IsValid {
 					validStatements++
-				} else {
-					hasErrors = true
-					for _, e := range r.Errors {
-						fmt.Fprintf(os.Stderr, "%s\n", e.Error())
-						if e.Suggestion != "" {
-							fmt.Fprintf(os.Stderr, "  suggestion: %s\n", e.Suggestion)
-						}
-					}
 				}
+				diags = append(diags, report.FromErrors(c.String("file"), r.Errors)...)
 			}
+			hasErrors := validStatements < len(results)
 
-			if !c.Bool("quiet") {
-				if hasErrors {
-					fmt.Fprintf(os.Stderr, "\n%d/%d statements valid\n", validStatements, totalStatements)
-				} else {
-					fmt.Printf("OK: %d statements valid\n", totalStatements)
+			if output != report.Text {
+				if err := report.Write(os.Stdout, output, diags); err != nil {
+					return err
+				}
+			} else {
+				if err := report.Write(os.Stderr, output, diags); err != nil {
+					return err
+				}
+				if !c.Bool("quiet") {
+					if hasErrors {
+						fmt.Fprintf(os.Stderr, "\n%d/%d statements valid\n", validStatements, len(results))
+					} else {
+						fmt.Printf("OK: %d statements valid\n", len(results))
+					}
 				}
 			}
 
@@ -91,6 +103,16 @@ func lintCmd() *cli.Command {
 	}
 }
 
+// outputFlag selects the format of findings.
+func outputFlag() cli.Flag {
+	return &cli.StringFlag{
+		Name:    "output",
+		Aliases: []string{"o"},
+		Value:   string(report.Text),
+		Usage:   "Output format: text, json, sarif, checkstyle or github",
+	}
+}
+
 func formatCmd() *cli.Command {
 	return &cli.Command{
 		Name:    "format",
@@ -187,7 +209,7 @@ func parseCmd() *cli.Command {
 			results := lint.AnalyzeMultiple(input)
 
 			for i, r := range results {
-				fmt.Printf("Statement %d:\n", i+1)
+				fmt.Printf("Statement %d (line %d):\n", i+1, r.Line)
 				fmt.Printf("  Type:  %s\n", r.Type)
 				fmt.Printf("  Valid: %v\n", r.IsValid)
 				if r.Errors.HasErrors() {
@@ -206,6 +228,146 @@ func parseCmd() *cli.Command {
 	}
 }
 
+func analyzeCmd() *cli.Command {
+	return &cli.Command{
+		Name:    "analyze",
+		Aliases: []string{"a"},
+		Usage:   "Validate CQL statements against a schema",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:    "file",
+				Aliases: []string{"f"},
+				Usage:   "Read CQL from file",
+			},
+			&cli.StringFlag{
+				Name:    "schema",
+				Aliases: []string{"s"},
+				Usage:   "Schema file: .json, CQL DDL, or a directory of system_schema dumps",
+			},
+			&cli.StringFlag{
+				Name:    "keyspace",
+				Aliases: []string{"k"},
+				Usage:   "Default keyspace for statements without one",
+			},
+			&cli.StringSliceFlag{
+				Name:  "warn",
+				Usage: "Enable optional warnings: select-star, no-limit, lwt",
+			},
+			&cli.IntFlag{
+				Name:  "large-limit",
+				Usage: "Warn when LIMIT exceeds this value (0 disables)",
+			},
+			&cli.StringFlag{
+				Name:  "fail-on",
+				Value: string(analyze.SeverityError),
+				Usage: "Exit with status 1 on findings of this severity or worse: error, warning, info or none",
+			},
+			outputFlag(),
+		},
+		Action: func(c *cli.Context) error {
+			output, err := report.ParseFormat(c.String("output"))
+			if err != nil {
+				return err
+			}
+			failOn := analyze.Severity(c.String("fail-on"))
+			switch failOn {
+			case analyze.SeverityError, analyze.SeverityWarning, analyze.SeverityInfo, "none":
+			default:
+				return fmt.Errorf("invalid --fail-on %q (want error, warning, info or none)", failOn)
+			}
+
+			opts := analyze.DefaultOptions()
+			opts.DefaultKeyspace = c.String("keyspace")
+			opts.LargeLimitThreshold = c.Int("large-limit")
+			for _, w := range c.StringSlice("warn") {
+				switch strings.TrimSpace(w) {
+				case "select-star":
+					opts.WarnOnSelectStar = true
+				case "no-limit":
+					opts.WarnOnNoLimit = true
+				case "lwt":
+					opts.WarnOnLightweightTransaction = true
+				default:
+					return fmt.Errorf("unknown warning %q (want select-star, no-limit or lwt)", w)
+				}
+			}
+			if path := c.String("schema"); path != "" {
+				s, err := schema.Load(path)
+				if s == nil {
+					return fmt.Errorf("loading schema: %w", err)
+				}
+				if err != nil {
+					// A DDL script with errors still yields a partial schema
+					fmt.Fprintf(os.Stderr, "warning: schema %s: %v\n", path, err)
+				}
+				opts.Schema = s
+			}
+
+			input, err := getInput(c)
+			if err != nil {
+				return err
+			}
+
+			// Statements are analyzed one by one; USE changes the default keyspace
+			var diags []report.Diagnostic
+			analyzed := 0
+			for _, r := range parse.Multiple(input) {
+				if use, ok := r.AST().(*ast.UseStmt); ok {
+					opts.DefaultKeyspace = use.Keyspace
+					continue
+				}
+				analyzed++
+				result := analyze.Analyze(r.Input, opts)
+				diags = append(diags, report.FromAnalyze(c.String("file"), r.Line, r.Column, result)...)
+			}
+
+			if output != report.Text {
+				if err := report.Write(os.Stdout, output, diags); err != nil {
+					return err
+				}
+			} else {
+				if err := report.Write(os.Stderr, output, diags); err != nil {
+					return err
+				}
+				if len(diags) == 0 {
+					fmt.Printf("OK: %d statements analyzed\n", analyzed)
+				} else {
+					counts := map[analyze.Severity]int{}
+					for _, d := range diags {
+						counts[d.Severity]++
+					}
+					fmt.Fprintf(os.Stderr, "\n%d statements analyzed: %d errors, %d warnings, %d info\n",
+						analyzed, counts[analyze.SeverityError], counts[analyze.SeverityWarning], counts[analyze.SeverityInfo])
+				}
+			}
+
+			if failOn != "none" && report.AnyAtLeast(diags, failOn) {
+				os.Exit(1)
+			}
+			return nil
+		},
+	}
+}
+
+func lspCmd() *cli.Command {
+	return &cli.Command{
+		Name:  "lsp",
+		Usage: "Run the language server over stdio",
+		Description: "Speaks the Language Server Protocol on stdin/stdout. The schema is\n" +
+			"configured with the \"schema\" and \"keyspace\" settings, in the\n" +
+			"initialization options or under \"scql\" in the workspace settings.",
+		Flags: []cli.Flag{
+			&cli.BoolFlag{
+				Name:  "stdio",
+				Usage: "Use stdio (the default; accepted for editor compatibility)",
+			},
+		},
+		Action: func(c *cli.Context) error {
+			return lsp.NewServer(os.Stdin, os.Stdout).Run()
+		},
+	}
+}
+
 func getInput(c *cli.Context) (string, error) {
 	// Check for file flag
 	if file := c.String("file"); file != "" {
@@ -323,6 +323,7 @@ alterTableOperation
     | alterTableDropCompactStorage
     | alterTableRename
     | alterTableWith
+    | kwAlter column kwType dataType    // ALTER column TYPE type
     ;
 
 alterTableWith
@@ -503,8 +504,9 @@ compoundKey
     : partitionKey (syntaxComma clusteringKeyList)
     ;
 
+// Clustering columns are optional after a composite partition key: PRIMARY KEY ((a, b))
 compositeKey
-    : syntaxBracketLr partitionKeyList syntaxBracketRr (syntaxComma clusteringKeyList)
+    : syntaxBracketLr partitionKeyList syntaxBracketRr (syntaxComma clusteringKeyList)?
     ;
 
 partitionKeyList
@@ -652,9 +654,10 @@ deleteColumnList
     : (deleteColumnItem) (syntaxComma deleteColumnItem)*
     ;
 
+// Map keys and list indexes may be bind markers: DELETE m[?] FROM t
 deleteColumnItem
     : OBJECT_NAME
-    | OBJECT_NAME LS_BRACKET (stringLiteral | decimalLiteral) RS_BRACKET
+    | OBJECT_NAME LS_BRACKET (stringLiteral | decimalLiteral | QMARK | namedMarker) RS_BRACKET
     ;
 
 update
@@ -710,14 +713,17 @@ assignmentElement
     | columnRef OPERATOR_EQ columnRef (PLUS | MINUS) assignmentList
     | columnRef OPERATOR_EQ assignmentList (PLUS | MINUS) columnRef
     | columnRef syntaxBracketLs assignmentIndexKey syntaxBracketRs OPERATOR_EQ constant
+    | columnRef '.' OBJECT_NAME OPERATOR_EQ (constant | assignmentMap | assignmentSet | assignmentList)    // UDT field
     ;
 
-// Index keys for map/list access - supports int, string, boolean, null
+// Index keys for map/list access - supports int, string, boolean, null and bind markers
 assignmentIndexKey
     : decimalLiteral
     | stringLiteral
     | booleanLiteral
     | kwNull
+    | QMARK
+    | namedMarker
     ;
 
 assignmentSet
@@ -735,7 +741,7 @@ assignmentMap
     : syntaxBracketLc (assignmentMapEntry (syntaxComma assignmentMapEntry)*)? syntaxBracketRc
     ;
 
-// Map entries with flexible key/value types
+// Map entries with flexible key/value types; a field name as key makes a UDT literal
 assignmentMapEntry
     : assignmentMapKey syntaxColon assignmentMapValue
     ;
@@ -744,6 +750,7 @@ assignmentMapKey
     : constant
     | assignmentList
     | assignmentSet
+    | OBJECT_NAME    // UDT field
     ;
 
 assignmentMapValue
@@ -785,12 +792,14 @@ usingTtlTimestamp
     | kwUsing kwTimeout constant kwAnd timestamp
     ;
 
+// Bind markers are allowed for USING TIMESTAMP
 timestamp
-    : kwTimestamp decimalLiteral
+    : kwTimestamp (decimalLiteral | QMARK | namedMarker)
     ;
 
+// Bind markers are allowed for USING TTL
 ttl
-    : kwTtl decimalLiteral
+    : kwTtl (decimalLiteral | QMARK | namedMarker)
     ;
 
 usingTimestampSpec
@@ -860,7 +869,7 @@ bypassCacheSpec
 
 // ScyllaDB: PER PARTITION LIMIT
 perPartitionLimitSpec
-    : kwPer kwPartition kwLimit decimalLiteral
+    : kwPer kwPartition kwLimit (decimalLiteral | QMARK | namedMarker)
     ;
 
 // ScyllaDB: USING TIMEOUT
@@ -967,8 +976,9 @@ pruneUsingSpec
     | kwUsing kwTimeout constant kwAnd kwConcurrency decimalLiteral
     ;
 
+// Bind markers are allowed for LIMIT
 limitSpec
-    : kwLimit decimalLiteral
+    : kwLimit (decimalLiteral | QMARK | namedMarker)
     ;
 
 fromSpec
@@ -1016,6 +1026,7 @@ selectElement
     | functionCall (kwAs OBJECT_NAME)?
     | castCall (kwAs OBJECT_NAME)?
     | qualifiedFunctionCall (kwAs OBJECT_NAME)?
+    | columnRef '.' OBJECT_NAME (kwAs OBJECT_NAME)?    // UDT field
     ;
 
 relationElements
@@ -1029,13 +1040,14 @@ relationElement
     | functionCall (OPERATOR_EQ | OPERATOR_LT | OPERATOR_GT | OPERATOR_LTE | OPERATOR_GTE) constant
     | functionCall (OPERATOR_EQ | OPERATOR_LT | OPERATOR_GT | OPERATOR_LTE | OPERATOR_GTE) functionCall
     | columnRef kwIn '(' functionArgs? ')'
-    | '(' columnRef (syntaxComma columnRef)* ')' kwIn '(' assignmentTuple (syntaxComma assignmentTuple)* ')'
+    | '(' columnRef (syntaxComma columnRef)* ')' kwIn ('(' assignmentTuple (syntaxComma assignmentTuple)* ')' | QMARK | namedMarker)
     | '(' columnRef (syntaxComma columnRef)* ')' (OPERATOR_EQ | OPERATOR_LT | OPERATOR_GT | OPERATOR_LTE | OPERATOR_GTE) (assignmentTuple (syntaxComma assignmentTuple)*)
     | '(' columnRef (syntaxComma columnRef)* ')' (OPERATOR_EQ | OPERATOR_LT | OPERATOR_GT | OPERATOR_LTE | OPERATOR_GTE) scyllaClusteringBound
     | scyllaClusteringBound (OPERATOR_EQ | OPERATOR_LT | OPERATOR_GT | OPERATOR_LTE | OPERATOR_GTE) '(' functionArgs ')'
     | relalationContainsKey
     | relalationContains
     | columnRef kwLike constant
+    | columnRef kwIn (QMARK | namedMarker)    // IN with a single bind marker for the whole list
     ;
 
 relalationContains
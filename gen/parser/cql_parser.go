@@ -184,7 +184,7 @@ func cqlparserParserInit() {
 	}
 	staticData.PredictionContextCache = antlr.NewPredictionContextCache()
 	staticData.serializedATN = []int32{
-		4, 1, 216, 3350, 2, 0, 7, 0, 2, 1, 7, 1, 2, 2, 7, 2, 2, 3, 7, 3, 2, 4,
+		4, 1, 216, 3404, 2, 0, 7, 0, 2, 1, 7, 1, 2, 2, 7, 2, 2, 3, 7, 3, 2, 4,
 		7, 4, 2, 5, 7, 5, 2, 6, 7, 6, 2, 7, 7, 7, 2, 8, 7, 8, 2, 9, 7, 9, 2, 10,
 		7, 10, 2, 11, 7, 11, 2, 12, 7, 12, 2, 13, 7, 13, 2, 14, 7, 14, 2, 15, 7,
 		15, 2, 16, 7, 16, 2, 17, 7, 17, 2, 18, 7, 18, 2, 19, 7, 19, 2, 20, 7, 20,
@@ -560,265 +560,272 @@ func cqlparserParserInit() {
 		358, 1, 359, 1, 359, 1, 360, 1, 360, 1, 361, 1, 361, 1, 362, 1, 362, 1,
 		363, 1, 363, 1, 364, 1, 364, 1, 365, 1, 365, 1, 366, 1, 366, 1, 367, 1,
 		367, 1, 368, 1, 368, 1, 369, 1, 369, 1, 370, 1, 370, 1, 371, 1, 371, 1,
-		372, 1, 372, 1, 373, 1, 373, 1, 374, 1, 374, 1, 374, 0, 0, 375, 0, 2, 4,
-		6, 8, 10, 12, 14, 16, 18, 20, 22, 24, 26, 28, 30, 32, 34, 36, 38, 40, 42,
-		44, 46, 48, 50, 52, 54, 56, 58, 60, 62, 64, 66, 68, 70, 72, 74, 76, 78,
-		80, 82, 84, 86, 88, 90, 92, 94, 96, 98, 100, 102, 104, 106, 108, 110, 112,
-		114, 116, 118, 120, 122, 124, 126, 128, 130, 132, 134, 136, 138, 140, 142,
-		144, 146, 148, 150, 152, 154, 156, 158, 160, 162, 164, 166, 168, 170, 172,
-		174, 176, 178, 180, 182, 184, 186, 188, 190, 192, 194, 196, 198, 200, 202,
-		204, 206, 208, 210, 212, 214, 216, 218, 220, 222, 224, 226, 228, 230, 232,
-		234, 236, 238, 240, 242, 244, 246, 248, 250, 252, 254, 256, 258, 260, 262,
-		264, 266, 268, 270, 272, 274, 276, 278, 280, 282, 284, 286, 288, 290, 292,
-		294, 296, 298, 300, 302, 304, 306, 308, 310, 312, 314, 316, 318, 320, 322,
-		324, 326, 328, 330, 332, 334, 336, 338, 340, 342, 344, 346, 348, 350, 352,
-		354, 356, 358, 360, 362, 364, 366, 368, 370, 372, 374, 376, 378, 380, 382,
-		384, 386, 388, 390, 392, 394, 396, 398, 400, 402, 404, 406, 408, 410, 412,
-		414, 416, 418, 420, 422, 424, 426, 428, 430, 432, 434, 436, 438, 440, 442,
-		444, 446, 448, 450, 452, 454, 456, 458, 460, 462, 464, 466, 468, 470, 472,
-		474, 476, 478, 480, 482, 484, 486, 488, 490, 492, 494, 496, 498, 500, 502,
-		504, 506, 508, 510, 512, 514, 516, 518, 520, 522, 524, 526, 528, 530, 532,
-		534, 536, 538, 540, 542, 544, 546, 548, 550, 552, 554, 556, 558, 560, 562,
-		564, 566, 568, 570, 572, 574, 576, 578, 580, 582, 584, 586, 588, 590, 592,
-		594, 596, 598, 600, 602, 604, 606, 608, 610, 612, 614, 616, 618, 620, 622,
-		624, 626, 628, 630, 632, 634, 636, 638, 640, 642, 644, 646, 648, 650, 652,
-		654, 656, 658, 660, 662, 664, 666, 668, 670, 672, 674, 676, 678, 680, 682,
-		684, 686, 688, 690, 692, 694, 696, 698, 700, 702, 704, 706, 708, 710, 712,
-		714, 716, 718, 720, 722, 724, 726, 728, 730, 732, 734, 736, 738, 740, 742,
-		744, 746, 748, 0, 12, 1, 0, 19, 23, 1, 0, 199, 200, 2, 0, 19, 23, 206,
-		206, 2, 0, 19, 19, 206, 206, 2, 0, 14, 14, 16, 16, 1, 0, 201, 202, 2, 0,
-		56, 56, 126, 126, 11, 0, 74, 74, 99, 99, 110, 110, 116, 116, 122, 122,
-		129, 130, 134, 134, 136, 137, 157, 157, 159, 159, 173, 173, 5, 0, 62, 62,
-		77, 77, 182, 184, 194, 194, 214, 216, 9, 0, 94, 94, 121, 121, 129, 129,
-		143, 143, 145, 145, 149, 150, 153, 153, 157, 157, 162, 162, 6, 0, 114,
-		114, 122, 122, 136, 136, 142, 163, 169, 170, 208, 208, 2, 0, 69, 69, 208,
-		208, 3427, 0, 751, 1, 0, 0, 0, 2, 767, 1, 0, 0, 0, 4, 780, 1, 0, 0, 0,
-		6, 782, 1, 0, 0, 0, 8, 831, 1, 0, 0, 0, 10, 833, 1, 0, 0, 0, 12, 840, 1,
-		0, 0, 0, 14, 853, 1, 0, 0, 0, 16, 856, 1, 0, 0, 0, 18, 868, 1, 0, 0, 0,
-		20, 888, 1, 0, 0, 0, 22, 928, 1, 0, 0, 0, 24, 930, 1, 0, 0, 0, 26, 943,
-		1, 0, 0, 0, 28, 952, 1, 0, 0, 0, 30, 967, 1, 0, 0, 0, 32, 978, 1, 0, 0,
-		0, 34, 992, 1, 0, 0, 0, 36, 1017, 1, 0, 0, 0, 38, 1036, 1, 0, 0, 0, 40,
-		1038, 1, 0, 0, 0, 42, 1045, 1, 0, 0, 0, 44, 1054, 1, 0, 0, 0, 46, 1065,
-		1, 0, 0, 0, 48, 1067, 1, 0, 0, 0, 50, 1089, 1, 0, 0, 0, 52, 1116, 1, 0,
-		0, 0, 54, 1118, 1, 0, 0, 0, 56, 1131, 1, 0, 0, 0, 58, 1137, 1, 0, 0, 0,
-		60, 1177, 1, 0, 0, 0, 62, 1179, 1, 0, 0, 0, 64, 1191, 1, 0, 0, 0, 66, 1195,
-		1, 0, 0, 0, 68, 1208, 1, 0, 0, 0, 70, 1220, 1, 0, 0, 0, 72, 1223, 1, 0,
-		0, 0, 74, 1231, 1, 0, 0, 0, 76, 1236, 1, 0, 0, 0, 78, 1238, 1, 0, 0, 0,
-		80, 1251, 1, 0, 0, 0, 82, 1253, 1, 0, 0, 0, 84, 1256, 1, 0, 0, 0, 86, 1265,
-		1, 0, 0, 0, 88, 1269, 1, 0, 0, 0, 90, 1281, 1, 0, 0, 0, 92, 1286, 1, 0,
-		0, 0, 94, 1301, 1, 0, 0, 0, 96, 1303, 1, 0, 0, 0, 98, 1306, 1, 0, 0, 0,
-		100, 1311, 1, 0, 0, 0, 102, 1315, 1, 0, 0, 0, 104, 1318, 1, 0, 0, 0, 106,
-		1346, 1, 0, 0, 0, 108, 1348, 1, 0, 0, 0, 110, 1359, 1, 0, 0, 0, 112, 1365,
-		1, 0, 0, 0, 114, 1400, 1, 0, 0, 0, 116, 1402, 1, 0, 0, 0, 118, 1416, 1,
-		0, 0, 0, 120, 1423, 1, 0, 0, 0, 122, 1435, 1, 0, 0, 0, 124, 1448, 1, 0,
-		0, 0, 126, 1465, 1, 0, 0, 0, 128, 1477, 1, 0, 0, 0, 130, 1491, 1, 0, 0,
-		0, 132, 1498, 1, 0, 0, 0, 134, 1510, 1, 0, 0, 0, 136, 1517, 1, 0, 0, 0,
-		138, 1529, 1, 0, 0, 0, 140, 1546, 1, 0, 0, 0, 142, 1571, 1, 0, 0, 0, 144,
-		1573, 1, 0, 0, 0, 146, 1601, 1, 0, 0, 0, 148, 1603, 1, 0, 0, 0, 150, 1607,
-		1, 0, 0, 0, 152, 1609, 1, 0, 0, 0, 154, 1621, 1, 0, 0, 0, 156, 1625, 1,
-		0, 0, 0, 158, 1631, 1, 0, 0, 0, 160, 1633, 1, 0, 0, 0, 162, 1647, 1, 0,
-		0, 0, 164, 1655, 1, 0, 0, 0, 166, 1658, 1, 0, 0, 0, 168, 1660, 1, 0, 0,
-		0, 170, 1669, 1, 0, 0, 0, 172, 1671, 1, 0, 0, 0, 174, 1673, 1, 0, 0, 0,
-		176, 1677, 1, 0, 0, 0, 178, 1683, 1, 0, 0, 0, 180, 1692, 1, 0, 0, 0, 182,
-		1701, 1, 0, 0, 0, 184, 1703, 1, 0, 0, 0, 186, 1705, 1, 0, 0, 0, 188, 1708,
-		1, 0, 0, 0, 190, 1723, 1, 0, 0, 0, 192, 1730, 1, 0, 0, 0, 194, 1732, 1,
-		0, 0, 0, 196, 1750, 1, 0, 0, 0, 198, 1767, 1, 0, 0, 0, 200, 1780, 1, 0,
-		0, 0, 202, 1791, 1, 0, 0, 0, 204, 1793, 1, 0, 0, 0, 206, 1807, 1, 0, 0,
-		0, 208, 1822, 1, 0, 0, 0, 210, 1824, 1, 0, 0, 0, 212, 1828, 1, 0, 0, 0,
-		214, 1834, 1, 0, 0, 0, 216, 1843, 1, 0, 0, 0, 218, 1850, 1, 0, 0, 0, 220,
-		1853, 1, 0, 0, 0, 222, 1864, 1, 0, 0, 0, 224, 1888, 1, 0, 0, 0, 226, 1893,
-		1, 0, 0, 0, 228, 1900, 1, 0, 0, 0, 230, 1906, 1, 0, 0, 0, 232, 1908, 1,
-		0, 0, 0, 234, 1913, 1, 0, 0, 0, 236, 1918, 1, 0, 0, 0, 238, 1924, 1, 0,
-		0, 0, 240, 1939, 1, 0, 0, 0, 242, 1957, 1, 0, 0, 0, 244, 1960, 1, 0, 0,
-		0, 246, 1986, 1, 0, 0, 0, 248, 1988, 1, 0, 0, 0, 250, 2062, 1, 0, 0, 0,
-		252, 2070, 1, 0, 0, 0, 254, 2072, 1, 0, 0, 0, 256, 2139, 1, 0, 0, 0, 258,
-		2145, 1, 0, 0, 0, 260, 2147, 1, 0, 0, 0, 262, 2164, 1, 0, 0, 0, 264, 2166,
-		1, 0, 0, 0, 266, 2180, 1, 0, 0, 0, 268, 2187, 1, 0, 0, 0, 270, 2193, 1,
-		0, 0, 0, 272, 2195, 1, 0, 0, 0, 274, 2213, 1, 0, 0, 0, 276, 2215, 1, 0,
-		0, 0, 278, 2228, 1, 0, 0, 0, 280, 2292, 1, 0, 0, 0, 282, 2294, 1, 0, 0,
-		0, 284, 2297, 1, 0, 0, 0, 286, 2319, 1, 0, 0, 0, 288, 2321, 1, 0, 0, 0,
-		290, 2325, 1, 0, 0, 0, 292, 2338, 1, 0, 0, 0, 294, 2346, 1, 0, 0, 0, 296,
-		2348, 1, 0, 0, 0, 298, 2352, 1, 0, 0, 0, 300, 2361, 1, 0, 0, 0, 302, 2376,
-		1, 0, 0, 0, 304, 2378, 1, 0, 0, 0, 306, 2411, 1, 0, 0, 0, 308, 2414, 1,
-		0, 0, 0, 310, 2418, 1, 0, 0, 0, 312, 2421, 1, 0, 0, 0, 314, 2426, 1, 0,
-		0, 0, 316, 2430, 1, 0, 0, 0, 318, 2447, 1, 0, 0, 0, 320, 2528, 1, 0, 0,
-		0, 322, 2530, 1, 0, 0, 0, 324, 2539, 1, 0, 0, 0, 326, 2541, 1, 0, 0, 0,
-		328, 2544, 1, 0, 0, 0, 330, 2547, 1, 0, 0, 0, 332, 2558, 1, 0, 0, 0, 334,
-		2564, 1, 0, 0, 0, 336, 2571, 1, 0, 0, 0, 338, 2577, 1, 0, 0, 0, 340, 2602,
-		1, 0, 0, 0, 342, 2604, 1, 0, 0, 0, 344, 2613, 1, 0, 0, 0, 346, 2620, 1,
-		0, 0, 0, 348, 2624, 1, 0, 0, 0, 350, 2648, 1, 0, 0, 0, 352, 2650, 1, 0,
-		0, 0, 354, 2653, 1, 0, 0, 0, 356, 2668, 1, 0, 0, 0, 358, 2670, 1, 0, 0,
-		0, 360, 2684, 1, 0, 0, 0, 362, 2686, 1, 0, 0, 0, 364, 2703, 1, 0, 0, 0,
-		366, 2706, 1, 0, 0, 0, 368, 2710, 1, 0, 0, 0, 370, 2747, 1, 0, 0, 0, 372,
-		2749, 1, 0, 0, 0, 374, 2859, 1, 0, 0, 0, 376, 2861, 1, 0, 0, 0, 378, 2865,
-		1, 0, 0, 0, 380, 2878, 1, 0, 0, 0, 382, 2908, 1, 0, 0, 0, 384, 2910, 1,
-		0, 0, 0, 386, 2917, 1, 0, 0, 0, 388, 2930, 1, 0, 0, 0, 390, 2956, 1, 0,
-		0, 0, 392, 2958, 1, 0, 0, 0, 394, 2961, 1, 0, 0, 0, 396, 2963, 1, 0, 0,
-		0, 398, 2965, 1, 0, 0, 0, 400, 2967, 1, 0, 0, 0, 402, 2969, 1, 0, 0, 0,
-		404, 2971, 1, 0, 0, 0, 406, 2977, 1, 0, 0, 0, 408, 2982, 1, 0, 0, 0, 410,
-		2989, 1, 0, 0, 0, 412, 2991, 1, 0, 0, 0, 414, 2995, 1, 0, 0, 0, 416, 2997,
-		1, 0, 0, 0, 418, 2999, 1, 0, 0, 0, 420, 3001, 1, 0, 0, 0, 422, 3005, 1,
-		0, 0, 0, 424, 3007, 1, 0, 0, 0, 426, 3021, 1, 0, 0, 0, 428, 3025, 1, 0,
-		0, 0, 430, 3027, 1, 0, 0, 0, 432, 3029, 1, 0, 0, 0, 434, 3031, 1, 0, 0,
-		0, 436, 3033, 1, 0, 0, 0, 438, 3035, 1, 0, 0, 0, 440, 3037, 1, 0, 0, 0,
-		442, 3039, 1, 0, 0, 0, 444, 3041, 1, 0, 0, 0, 446, 3043, 1, 0, 0, 0, 448,
-		3045, 1, 0, 0, 0, 450, 3047, 1, 0, 0, 0, 452, 3049, 1, 0, 0, 0, 454, 3052,
-		1, 0, 0, 0, 456, 3054, 1, 0, 0, 0, 458, 3056, 1, 0, 0, 0, 460, 3058, 1,
-		0, 0, 0, 462, 3060, 1, 0, 0, 0, 464, 3063, 1, 0, 0, 0, 466, 3065, 1, 0,
-		0, 0, 468, 3067, 1, 0, 0, 0, 470, 3069, 1, 0, 0, 0, 472, 3071, 1, 0, 0,
-		0, 474, 3073, 1, 0, 0, 0, 476, 3075, 1, 0, 0, 0, 478, 3077, 1, 0, 0, 0,
-		480, 3079, 1, 0, 0, 0, 482, 3081, 1, 0, 0, 0, 484, 3083, 1, 0, 0, 0, 486,
-		3085, 1, 0, 0, 0, 488, 3087, 1, 0, 0, 0, 490, 3089, 1, 0, 0, 0, 492, 3091,
-		1, 0, 0, 0, 494, 3093, 1, 0, 0, 0, 496, 3095, 1, 0, 0, 0, 498, 3097, 1,
-		0, 0, 0, 500, 3099, 1, 0, 0, 0, 502, 3101, 1, 0, 0, 0, 504, 3103, 1, 0,
-		0, 0, 506, 3105, 1, 0, 0, 0, 508, 3107, 1, 0, 0, 0, 510, 3109, 1, 0, 0,
-		0, 512, 3111, 1, 0, 0, 0, 514, 3113, 1, 0, 0, 0, 516, 3115, 1, 0, 0, 0,
-		518, 3117, 1, 0, 0, 0, 520, 3119, 1, 0, 0, 0, 522, 3121, 1, 0, 0, 0, 524,
-		3123, 1, 0, 0, 0, 526, 3125, 1, 0, 0, 0, 528, 3127, 1, 0, 0, 0, 530, 3129,
-		1, 0, 0, 0, 532, 3131, 1, 0, 0, 0, 534, 3133, 1, 0, 0, 0, 536, 3135, 1,
-		0, 0, 0, 538, 3137, 1, 0, 0, 0, 540, 3139, 1, 0, 0, 0, 542, 3141, 1, 0,
-		0, 0, 544, 3143, 1, 0, 0, 0, 546, 3145, 1, 0, 0, 0, 548, 3147, 1, 0, 0,
-		0, 550, 3149, 1, 0, 0, 0, 552, 3151, 1, 0, 0, 0, 554, 3153, 1, 0, 0, 0,
-		556, 3155, 1, 0, 0, 0, 558, 3157, 1, 0, 0, 0, 560, 3159, 1, 0, 0, 0, 562,
-		3161, 1, 0, 0, 0, 564, 3163, 1, 0, 0, 0, 566, 3165, 1, 0, 0, 0, 568, 3167,
-		1, 0, 0, 0, 570, 3169, 1, 0, 0, 0, 572, 3171, 1, 0, 0, 0, 574, 3173, 1,
-		0, 0, 0, 576, 3175, 1, 0, 0, 0, 578, 3177, 1, 0, 0, 0, 580, 3179, 1, 0,
-		0, 0, 582, 3181, 1, 0, 0, 0, 584, 3183, 1, 0, 0, 0, 586, 3185, 1, 0, 0,
-		0, 588, 3187, 1, 0, 0, 0, 590, 3189, 1, 0, 0, 0, 592, 3191, 1, 0, 0, 0,
-		594, 3193, 1, 0, 0, 0, 596, 3195, 1, 0, 0, 0, 598, 3197, 1, 0, 0, 0, 600,
-		3199, 1, 0, 0, 0, 602, 3201, 1, 0, 0, 0, 604, 3203, 1, 0, 0, 0, 606, 3205,
-		1, 0, 0, 0, 608, 3207, 1, 0, 0, 0, 610, 3209, 1, 0, 0, 0, 612, 3211, 1,
-		0, 0, 0, 614, 3213, 1, 0, 0, 0, 616, 3215, 1, 0, 0, 0, 618, 3217, 1, 0,
-		0, 0, 620, 3219, 1, 0, 0, 0, 622, 3221, 1, 0, 0, 0, 624, 3223, 1, 0, 0,
-		0, 626, 3225, 1, 0, 0, 0, 628, 3227, 1, 0, 0, 0, 630, 3229, 1, 0, 0, 0,
-		632, 3231, 1, 0, 0, 0, 634, 3233, 1, 0, 0, 0, 636, 3235, 1, 0, 0, 0, 638,
-		3237, 1, 0, 0, 0, 640, 3239, 1, 0, 0, 0, 642, 3241, 1, 0, 0, 0, 644, 3243,
-		1, 0, 0, 0, 646, 3245, 1, 0, 0, 0, 648, 3247, 1, 0, 0, 0, 650, 3249, 1,
-		0, 0, 0, 652, 3251, 1, 0, 0, 0, 654, 3253, 1, 0, 0, 0, 656, 3255, 1, 0,
-		0, 0, 658, 3257, 1, 0, 0, 0, 660, 3259, 1, 0, 0, 0, 662, 3261, 1, 0, 0,
-		0, 664, 3263, 1, 0, 0, 0, 666, 3265, 1, 0, 0, 0, 668, 3267, 1, 0, 0, 0,
-		670, 3269, 1, 0, 0, 0, 672, 3271, 1, 0, 0, 0, 674, 3273, 1, 0, 0, 0, 676,
-		3275, 1, 0, 0, 0, 678, 3277, 1, 0, 0, 0, 680, 3279, 1, 0, 0, 0, 682, 3281,
-		1, 0, 0, 0, 684, 3283, 1, 0, 0, 0, 686, 3285, 1, 0, 0, 0, 688, 3287, 1,
-		0, 0, 0, 690, 3289, 1, 0, 0, 0, 692, 3291, 1, 0, 0, 0, 694, 3293, 1, 0,
-		0, 0, 696, 3295, 1, 0, 0, 0, 698, 3297, 1, 0, 0, 0, 700, 3299, 1, 0, 0,
-		0, 702, 3301, 1, 0, 0, 0, 704, 3303, 1, 0, 0, 0, 706, 3305, 1, 0, 0, 0,
-		708, 3307, 1, 0, 0, 0, 710, 3309, 1, 0, 0, 0, 712, 3311, 1, 0, 0, 0, 714,
-		3313, 1, 0, 0, 0, 716, 3315, 1, 0, 0, 0, 718, 3317, 1, 0, 0, 0, 720, 3319,
-		1, 0, 0, 0, 722, 3321, 1, 0, 0, 0, 724, 3323, 1, 0, 0, 0, 726, 3325, 1,
-		0, 0, 0, 728, 3327, 1, 0, 0, 0, 730, 3329, 1, 0, 0, 0, 732, 3331, 1, 0,
-		0, 0, 734, 3333, 1, 0, 0, 0, 736, 3335, 1, 0, 0, 0, 738, 3337, 1, 0, 0,
-		0, 740, 3339, 1, 0, 0, 0, 742, 3341, 1, 0, 0, 0, 744, 3343, 1, 0, 0, 0,
-		746, 3345, 1, 0, 0, 0, 748, 3347, 1, 0, 0, 0, 750, 752, 3, 2, 1, 0, 751,
-		750, 1, 0, 0, 0, 751, 752, 1, 0, 0, 0, 752, 754, 1, 0, 0, 0, 753, 755,
-		5, 15, 0, 0, 754, 753, 1, 0, 0, 0, 754, 755, 1, 0, 0, 0, 755, 756, 1, 0,
-		0, 0, 756, 757, 5, 0, 0, 1, 757, 1, 1, 0, 0, 0, 758, 760, 3, 8, 4, 0, 759,
-		761, 5, 15, 0, 0, 760, 759, 1, 0, 0, 0, 760, 761, 1, 0, 0, 0, 761, 762,
-		1, 0, 0, 0, 762, 763, 3, 4, 2, 0, 763, 766, 1, 0, 0, 0, 764, 766, 3, 6,
-		3, 0, 765, 758, 1, 0, 0, 0, 765, 764, 1, 0, 0, 0, 766, 769, 1, 0, 0, 0,
-		767, 765, 1, 0, 0, 0, 767, 768, 1, 0, 0, 0, 768, 778, 1, 0, 0, 0, 769,
-		767, 1, 0, 0, 0, 770, 775, 3, 8, 4, 0, 771, 773, 5, 15, 0, 0, 772, 771,
-		1, 0, 0, 0, 772, 773, 1, 0, 0, 0, 773, 774, 1, 0, 0, 0, 774, 776, 3, 4,
-		2, 0, 775, 772, 1, 0, 0, 0, 775, 776, 1, 0, 0, 0, 776, 779, 1, 0, 0, 0,
-		777, 779, 3, 6, 3, 0, 778, 770, 1, 0, 0, 0, 778, 777, 1, 0, 0, 0, 779,
-		3, 1, 0, 0, 0, 780, 781, 5, 8, 0, 0, 781, 5, 1, 0, 0, 0, 782, 783, 3, 4,
-		2, 0, 783, 7, 1, 0, 0, 0, 784, 832, 3, 204, 102, 0, 785, 832, 3, 116, 58,
-		0, 786, 832, 3, 110, 55, 0, 787, 832, 3, 92, 46, 0, 788, 832, 3, 78, 39,
-		0, 789, 832, 3, 72, 36, 0, 790, 832, 3, 186, 93, 0, 791, 832, 3, 58, 29,
-		0, 792, 832, 3, 50, 25, 0, 793, 832, 3, 222, 111, 0, 794, 832, 3, 48, 24,
-		0, 795, 832, 3, 34, 17, 0, 796, 832, 3, 26, 13, 0, 797, 832, 3, 138, 69,
-		0, 798, 832, 3, 32, 16, 0, 799, 832, 3, 28, 14, 0, 800, 832, 3, 24, 12,
-		0, 801, 832, 3, 238, 119, 0, 802, 832, 3, 124, 62, 0, 803, 832, 3, 126,
-		63, 0, 804, 832, 3, 136, 68, 0, 805, 832, 3, 134, 67, 0, 806, 832, 3, 122,
-		61, 0, 807, 832, 3, 130, 65, 0, 808, 832, 3, 132, 66, 0, 809, 832, 3, 128,
-		64, 0, 810, 832, 3, 120, 60, 0, 811, 832, 3, 118, 59, 0, 812, 832, 3, 18,
-		9, 0, 813, 832, 3, 278, 139, 0, 814, 832, 3, 16, 8, 0, 815, 832, 3, 12,
-		6, 0, 816, 832, 3, 10, 5, 0, 817, 832, 3, 304, 152, 0, 818, 832, 3, 220,
-		110, 0, 819, 832, 3, 244, 122, 0, 820, 832, 3, 218, 109, 0, 821, 832, 3,
-		316, 158, 0, 822, 832, 3, 188, 94, 0, 823, 832, 3, 318, 159, 0, 824, 832,
-		3, 330, 165, 0, 825, 832, 3, 332, 166, 0, 826, 832, 3, 334, 167, 0, 827,
-		832, 3, 336, 168, 0, 828, 832, 3, 338, 169, 0, 829, 832, 3, 340, 170, 0,
-		830, 832, 3, 14, 7, 0, 831, 784, 1, 0, 0, 0, 831, 785, 1, 0, 0, 0, 831,
-		786, 1, 0, 0, 0, 831, 787, 1, 0, 0, 0, 831, 788, 1, 0, 0, 0, 831, 789,
-		1, 0, 0, 0, 831, 790, 1, 0, 0, 0, 831, 791, 1, 0, 0, 0, 831, 792, 1, 0,
-		0, 0, 831, 793, 1, 0, 0, 0, 831, 794, 1, 0, 0, 0, 831, 795, 1, 0, 0, 0,
-		831, 796, 1, 0, 0, 0, 831, 797, 1, 0, 0, 0, 831, 798, 1, 0, 0, 0, 831,
-		799, 1, 0, 0, 0, 831, 800, 1, 0, 0, 0, 831, 801, 1, 0, 0, 0, 831, 802,
-		1, 0, 0, 0, 831, 803, 1, 0, 0, 0, 831, 804, 1, 0, 0, 0, 831, 805, 1, 0,
-		0, 0, 831, 806, 1, 0, 0, 0, 831, 807, 1, 0, 0, 0, 831, 808, 1, 0, 0, 0,
-		831, 809, 1, 0, 0, 0, 831, 810, 1, 0, 0, 0, 831, 811, 1, 0, 0, 0, 831,
-		812, 1, 0, 0, 0, 831, 813, 1, 0, 0, 0, 831, 814, 1, 0, 0, 0, 831, 815,
-		1, 0, 0, 0, 831, 816, 1, 0, 0, 0, 831, 817, 1, 0, 0, 0, 831, 818, 1, 0,
-		0, 0, 831, 819, 1, 0, 0, 0, 831, 820, 1, 0, 0, 0, 831, 821, 1, 0, 0, 0,
-		831, 822, 1, 0, 0, 0, 831, 823, 1, 0, 0, 0, 831, 824, 1, 0, 0, 0, 831,
-		825, 1, 0, 0, 0, 831, 826, 1, 0, 0, 0, 831, 827, 1, 0, 0, 0, 831, 828,
-		1, 0, 0, 0, 831, 829, 1, 0, 0, 0, 831, 830, 1, 0, 0, 0, 832, 9, 1, 0, 0,
-		0, 833, 834, 3, 644, 322, 0, 834, 835, 3, 20, 10, 0, 835, 836, 3, 576,
-		288, 0, 836, 837, 3, 22, 11, 0, 837, 838, 3, 516, 258, 0, 838, 839, 3,
-		430, 215, 0, 839, 11, 1, 0, 0, 0, 840, 842, 3, 556, 278, 0, 841, 843, 3,
-		460, 230, 0, 842, 841, 1, 0, 0, 0, 842, 843, 1, 0, 0, 0, 843, 844, 1, 0,
-		0, 0, 844, 848, 3, 598, 299, 0, 845, 846, 3, 574, 287, 0, 846, 847, 3,
-		430, 215, 0, 847, 849, 1, 0, 0, 0, 848, 845, 1, 0, 0, 0, 848, 849, 1, 0,
-		0, 0, 849, 851, 1, 0, 0, 0, 850, 852, 3, 568, 284, 0, 851, 850, 1, 0, 0,
-		0, 851, 852, 1, 0, 0, 0, 852, 13, 1, 0, 0, 0, 853, 854, 3, 556, 278, 0,
-		854, 855, 3, 740, 370, 0, 855, 15, 1, 0, 0, 0, 856, 857, 3, 556, 278, 0,
-		857, 861, 3, 20, 10, 0, 858, 859, 3, 576, 288, 0, 859, 860, 3, 22, 11,
-		0, 860, 862, 1, 0, 0, 0, 861, 858, 1, 0, 0, 0, 861, 862, 1, 0, 0, 0, 862,
-		866, 1, 0, 0, 0, 863, 864, 3, 574, 287, 0, 864, 865, 3, 430, 215, 0, 865,
-		867, 1, 0, 0, 0, 866, 863, 1, 0, 0, 0, 866, 867, 1, 0, 0, 0, 867, 17, 1,
-		0, 0, 0, 868, 869, 3, 524, 262, 0, 869, 870, 3, 20, 10, 0, 870, 871, 3,
-		576, 288, 0, 871, 872, 3, 22, 11, 0, 872, 873, 3, 616, 308, 0, 873, 874,
-		3, 430, 215, 0, 874, 19, 1, 0, 0, 0, 875, 878, 3, 460, 230, 0, 876, 878,
-		3, 462, 231, 0, 877, 875, 1, 0, 0, 0, 877, 876, 1, 0, 0, 0, 878, 889, 1,
-		0, 0, 0, 879, 889, 3, 466, 233, 0, 880, 889, 3, 476, 238, 0, 881, 889,
-		3, 498, 249, 0, 882, 889, 3, 508, 254, 0, 883, 889, 3, 492, 246, 0, 884,
-		889, 3, 502, 251, 0, 885, 889, 3, 564, 282, 0, 886, 889, 3, 600, 300, 0,
-		887, 889, 3, 748, 374, 0, 888, 877, 1, 0, 0, 0, 888, 879, 1, 0, 0, 0, 888,
-		880, 1, 0, 0, 0, 888, 881, 1, 0, 0, 0, 888, 882, 1, 0, 0, 0, 888, 883,
-		1, 0, 0, 0, 888, 884, 1, 0, 0, 0, 888, 885, 1, 0, 0, 0, 888, 886, 1, 0,
-		0, 0, 888, 887, 1, 0, 0, 0, 889, 21, 1, 0, 0, 0, 890, 891, 3, 460, 230,
-		0, 891, 892, 3, 522, 261, 0, 892, 929, 1, 0, 0, 0, 893, 894, 3, 460, 230,
-		0, 894, 895, 3, 522, 261, 0, 895, 896, 3, 528, 264, 0, 896, 897, 3, 548,
-		274, 0, 897, 898, 3, 406, 203, 0, 898, 929, 1, 0, 0, 0, 899, 903, 3, 520,
-		260, 0, 900, 901, 3, 406, 203, 0, 901, 902, 5, 10, 0, 0, 902, 904, 1, 0,
-		0, 0, 903, 900, 1, 0, 0, 0, 903, 904, 1, 0, 0, 0, 904, 905, 1, 0, 0, 0,
-		905, 906, 3, 442, 221, 0, 906, 929, 1, 0, 0, 0, 907, 908, 3, 460, 230,
-		0, 908, 909, 3, 550, 275, 0, 909, 929, 1, 0, 0, 0, 910, 911, 3, 548, 274,
-		0, 911, 912, 3, 406, 203, 0, 912, 929, 1, 0, 0, 0, 913, 915, 3, 612, 306,
-		0, 914, 913, 1, 0, 0, 0, 914, 915, 1, 0, 0, 0, 915, 919, 1, 0, 0, 0, 916,
-		917, 3, 406, 203, 0, 917, 918, 5, 10, 0, 0, 918, 920, 1, 0, 0, 0, 919,
-		916, 1, 0, 0, 0, 919, 920, 1, 0, 0, 0, 920, 921, 1, 0, 0, 0, 921, 929,
-		3, 408, 204, 0, 922, 923, 3, 460, 230, 0, 923, 924, 3, 598, 299, 0, 924,
-		929, 1, 0, 0, 0, 925, 926, 3, 596, 298, 0, 926, 927, 3, 430, 215, 0, 927,
-		929, 1, 0, 0, 0, 928, 890, 1, 0, 0, 0, 928, 893, 1, 0, 0, 0, 928, 899,
-		1, 0, 0, 0, 928, 907, 1, 0, 0, 0, 928, 910, 1, 0, 0, 0, 928, 914, 1, 0,
-		0, 0, 928, 922, 1, 0, 0, 0, 928, 925, 1, 0, 0, 0, 929, 23, 1, 0, 0, 0,
-		930, 931, 3, 492, 246, 0, 931, 933, 3, 632, 316, 0, 932, 934, 3, 288, 144,
-		0, 933, 932, 1, 0, 0, 0, 933, 934, 1, 0, 0, 0, 934, 935, 1, 0, 0, 0, 935,
-		936, 3, 446, 223, 0, 936, 937, 3, 642, 321, 0, 937, 938, 3, 584, 292, 0,
-		938, 941, 3, 400, 200, 0, 939, 942, 3, 610, 305, 0, 940, 942, 3, 566, 283,
-		0, 941, 939, 1, 0, 0, 0, 941, 940, 1, 0, 0, 0, 941, 942, 1, 0, 0, 0, 942,
-		25, 1, 0, 0, 0, 943, 944, 3, 492, 246, 0, 944, 946, 3, 596, 298, 0, 945,
-		947, 3, 288, 144, 0, 946, 945, 1, 0, 0, 0, 946, 947, 1, 0, 0, 0, 947, 948,
-		1, 0, 0, 0, 948, 950, 3, 430, 215, 0, 949, 951, 3, 112, 56, 0, 950, 949,
-		1, 0, 0, 0, 950, 951, 1, 0, 0, 0, 951, 27, 1, 0, 0, 0, 952, 953, 3, 492,
-		246, 0, 953, 955, 3, 624, 312, 0, 954, 956, 3, 288, 144, 0, 955, 954, 1,
-		0, 0, 0, 955, 956, 1, 0, 0, 0, 956, 960, 1, 0, 0, 0, 957, 958, 3, 406,
-		203, 0, 958, 959, 5, 10, 0, 0, 959, 961, 1, 0, 0, 0, 960, 957, 1, 0, 0,
-		0, 960, 961, 1, 0, 0, 0, 961, 962, 1, 0, 0, 0, 962, 963, 3, 438, 219, 0,
-		963, 964, 3, 646, 323, 0, 964, 965, 3, 30, 15, 0, 965, 966, 3, 648, 324,
-		0, 966, 29, 1, 0, 0, 0, 967, 968, 3, 410, 205, 0, 968, 975, 3, 420, 210,
-		0, 969, 970, 3, 662, 331, 0, 970, 971, 3, 410, 205, 0, 971, 972, 3, 420,
-		210, 0, 972, 974, 1, 0, 0, 0, 973, 969, 1, 0, 0, 0, 974, 977, 1, 0, 0,
-		0, 975, 973, 1, 0, 0, 0, 975, 976, 1, 0, 0, 0, 976, 31, 1, 0, 0, 0, 977,
-		975, 1, 0, 0, 0, 978, 979, 3, 492, 246, 0, 979, 981, 3, 618, 309, 0, 980,
-		982, 3, 288, 144, 0, 981, 980, 1, 0, 0, 0, 981, 982, 1, 0, 0, 0, 982, 986,
-		1, 0, 0, 0, 983, 984, 3, 406, 203, 0, 984, 985, 5, 10, 0, 0, 985, 987,
-		1, 0, 0, 0, 986, 983, 1, 0, 0, 0, 986, 987, 1, 0, 0, 0, 987, 988, 1, 0,
-		0, 0, 988, 989, 3, 432, 216, 0, 989, 990, 3, 634, 317, 0, 990, 991, 3,
-		434, 217, 0, 991, 33, 1, 0, 0, 0, 992, 993, 3, 492, 246, 0, 993, 994, 3,
-		562, 281, 0, 994, 996, 3, 638, 319, 0, 995, 997, 3, 288, 144, 0, 996, 995,
-		1, 0, 0, 0, 996, 997, 1, 0, 0, 0, 997, 1001, 1, 0, 0, 0, 998, 999, 3, 406,
+		372, 1, 372, 1, 373, 1, 373, 1, 374, 1, 374, 1, 374, 1, 142, 1, 142, 3,
+		142, 3353, 8, 142, 1, 141, 1, 141, 3, 141, 3357, 8, 141, 1, 176, 1, 176,
+		3, 176, 3361, 8, 176, 1, 156, 1, 156, 3, 156, 3365, 8, 156, 1, 187, 1,
+		187, 1, 187, 1, 187, 3, 187, 3371, 8, 187, 1, 134, 1, 185, 1, 185, 1, 185,
+		1, 185, 1, 185, 3, 185, 3379, 8, 185, 1, 128, 1, 128, 1, 128, 1, 128, 1,
+		128, 1, 128, 1, 128, 1, 128, 3, 128, 3389, 8, 128, 3, 88, 3391, 8, 88,
+		1, 47, 1, 47, 1, 47, 1, 47, 1, 121, 1, 121, 1, 129, 1, 129, 1, 187, 1,
+		187, 3, 187, 3403, 8, 187, 0, 0, 375, 0, 2, 4, 6, 8, 10, 12, 14, 16, 18,
+		20, 22, 24, 26, 28, 30, 32, 34, 36, 38, 40, 42, 44, 46, 48, 50, 52, 54,
+		56, 58, 60, 62, 64, 66, 68, 70, 72, 74, 76, 78, 80, 82, 84, 86, 88, 90,
+		92, 94, 96, 98, 100, 102, 104, 106, 108, 110, 112, 114, 116, 118, 120,
+		122, 124, 126, 128, 130, 132, 134, 136, 138, 140, 142, 144, 146, 148, 150,
+		152, 154, 156, 158, 160, 162, 164, 166, 168, 170, 172, 174, 176, 178, 180,
+		182, 184, 186, 188, 190, 192, 194, 196, 198, 200, 202, 204, 206, 208, 210,
+		212, 214, 216, 218, 220, 222, 224, 226, 228, 230, 232, 234, 236, 238, 240,
+		242, 244, 246, 248, 250, 252, 254, 256, 258, 260, 262, 264, 266, 268, 270,
+		272, 274, 276, 278, 280, 282, 284, 286, 288, 290, 292, 294, 296, 298, 300,
+		302, 304, 306, 308, 310, 312, 314, 316, 318, 320, 322, 324, 326, 328, 330,
+		332, 334, 336, 338, 340, 342, 344, 346, 348, 350, 352, 354, 356, 358, 360,
+		362, 364, 366, 368, 370, 372, 374, 376, 378, 380, 382, 384, 386, 388, 390,
+		392, 394, 396, 398, 400, 402, 404, 406, 408, 410, 412, 414, 416, 418, 420,
+		422, 424, 426, 428, 430, 432, 434, 436, 438, 440, 442, 444, 446, 448, 450,
+		452, 454, 456, 458, 460, 462, 464, 466, 468, 470, 472, 474, 476, 478, 480,
+		482, 484, 486, 488, 490, 492, 494, 496, 498, 500, 502, 504, 506, 508, 510,
+		512, 514, 516, 518, 520, 522, 524, 526, 528, 530, 532, 534, 536, 538, 540,
+		542, 544, 546, 548, 550, 552, 554, 556, 558, 560, 562, 564, 566, 568, 570,
+		572, 574, 576, 578, 580, 582, 584, 586, 588, 590, 592, 594, 596, 598, 600,
+		602, 604, 606, 608, 610, 612, 614, 616, 618, 620, 622, 624, 626, 628, 630,
+		632, 634, 636, 638, 640, 642, 644, 646, 648, 650, 652, 654, 656, 658, 660,
+		662, 664, 666, 668, 670, 672, 674, 676, 678, 680, 682, 684, 686, 688, 690,
+		692, 694, 696, 698, 700, 702, 704, 706, 708, 710, 712, 714, 716, 718, 720,
+		722, 724, 726, 728, 730, 732, 734, 736, 738, 740, 742, 744, 746, 748, 0,
+		12, 1, 0, 19, 23, 1, 0, 199, 200, 2, 0, 19, 23, 206, 206, 2, 0, 19, 19,
+		206, 206, 2, 0, 14, 14, 16, 16, 1, 0, 201, 202, 2, 0, 56, 56, 126, 126,
+		11, 0, 74, 74, 99, 99, 110, 110, 116, 116, 122, 122, 129, 130, 134, 134,
+		136, 137, 157, 157, 159, 159, 173, 173, 5, 0, 62, 62, 77, 77, 182, 184,
+		194, 194, 214, 216, 9, 0, 94, 94, 121, 121, 129, 129, 143, 143, 145, 145,
+		149, 150, 153, 153, 157, 157, 162, 162, 6, 0, 114, 114, 122, 122, 136,
+		136, 142, 163, 169, 170, 208, 208, 2, 0, 69, 69, 208, 208, 3506, 0, 751,
+		1, 0, 0, 0, 2, 767, 1, 0, 0, 0, 4, 780, 1, 0, 0, 0, 6, 782, 1, 0, 0, 0,
+		8, 831, 1, 0, 0, 0, 10, 833, 1, 0, 0, 0, 12, 840, 1, 0, 0, 0, 14, 853,
+		1, 0, 0, 0, 16, 856, 1, 0, 0, 0, 18, 868, 1, 0, 0, 0, 20, 888, 1, 0, 0,
+		0, 22, 928, 1, 0, 0, 0, 24, 930, 1, 0, 0, 0, 26, 943, 1, 0, 0, 0, 28, 952,
+		1, 0, 0, 0, 30, 967, 1, 0, 0, 0, 32, 978, 1, 0, 0, 0, 34, 992, 1, 0, 0,
+		0, 36, 1017, 1, 0, 0, 0, 38, 1036, 1, 0, 0, 0, 40, 1038, 1, 0, 0, 0, 42,
+		1045, 1, 0, 0, 0, 44, 1054, 1, 0, 0, 0, 46, 1065, 1, 0, 0, 0, 48, 1067,
+		1, 0, 0, 0, 50, 1089, 1, 0, 0, 0, 52, 1116, 1, 0, 0, 0, 54, 1118, 1, 0,
+		0, 0, 56, 1131, 1, 0, 0, 0, 58, 1137, 1, 0, 0, 0, 60, 1177, 1, 0, 0, 0,
+		62, 1179, 1, 0, 0, 0, 64, 1191, 1, 0, 0, 0, 66, 1195, 1, 0, 0, 0, 68, 1208,
+		1, 0, 0, 0, 70, 1220, 1, 0, 0, 0, 72, 1223, 1, 0, 0, 0, 74, 1231, 1, 0,
+		0, 0, 76, 1236, 1, 0, 0, 0, 78, 1238, 1, 0, 0, 0, 80, 1251, 1, 0, 0, 0,
+		82, 1253, 1, 0, 0, 0, 84, 1256, 1, 0, 0, 0, 86, 1265, 1, 0, 0, 0, 88, 1269,
+		1, 0, 0, 0, 90, 1281, 1, 0, 0, 0, 92, 1286, 1, 0, 0, 0, 94, 1301, 1, 0,
+		0, 0, 96, 1303, 1, 0, 0, 0, 98, 1306, 1, 0, 0, 0, 100, 1311, 1, 0, 0, 0,
+		102, 1315, 1, 0, 0, 0, 104, 1318, 1, 0, 0, 0, 106, 1346, 1, 0, 0, 0, 108,
+		1348, 1, 0, 0, 0, 110, 1359, 1, 0, 0, 0, 112, 1365, 1, 0, 0, 0, 114, 1400,
+		1, 0, 0, 0, 116, 1402, 1, 0, 0, 0, 118, 1416, 1, 0, 0, 0, 120, 1423, 1,
+		0, 0, 0, 122, 1435, 1, 0, 0, 0, 124, 1448, 1, 0, 0, 0, 126, 1465, 1, 0,
+		0, 0, 128, 1477, 1, 0, 0, 0, 130, 1491, 1, 0, 0, 0, 132, 1498, 1, 0, 0,
+		0, 134, 1510, 1, 0, 0, 0, 136, 1517, 1, 0, 0, 0, 138, 1529, 1, 0, 0, 0,
+		140, 1546, 1, 0, 0, 0, 142, 1571, 1, 0, 0, 0, 144, 1573, 1, 0, 0, 0, 146,
+		1601, 1, 0, 0, 0, 148, 1603, 1, 0, 0, 0, 150, 1607, 1, 0, 0, 0, 152, 1609,
+		1, 0, 0, 0, 154, 1621, 1, 0, 0, 0, 156, 1625, 1, 0, 0, 0, 158, 1631, 1,
+		0, 0, 0, 160, 1633, 1, 0, 0, 0, 162, 1647, 1, 0, 0, 0, 164, 1655, 1, 0,
+		0, 0, 166, 1658, 1, 0, 0, 0, 168, 1660, 1, 0, 0, 0, 170, 1669, 1, 0, 0,
+		0, 172, 1671, 1, 0, 0, 0, 174, 1673, 1, 0, 0, 0, 176, 1677, 1, 0, 0, 0,
+		178, 1683, 1, 0, 0, 0, 180, 1692, 1, 0, 0, 0, 182, 1701, 1, 0, 0, 0, 184,
+		1703, 1, 0, 0, 0, 186, 1705, 1, 0, 0, 0, 188, 1708, 1, 0, 0, 0, 190, 1723,
+		1, 0, 0, 0, 192, 1730, 1, 0, 0, 0, 194, 1732, 1, 0, 0, 0, 196, 1750, 1,
+		0, 0, 0, 198, 1767, 1, 0, 0, 0, 200, 1780, 1, 0, 0, 0, 202, 1791, 1, 0,
+		0, 0, 204, 1793, 1, 0, 0, 0, 206, 1807, 1, 0, 0, 0, 208, 1822, 1, 0, 0,
+		0, 210, 1824, 1, 0, 0, 0, 212, 1828, 1, 0, 0, 0, 214, 1834, 1, 0, 0, 0,
+		216, 1843, 1, 0, 0, 0, 218, 1850, 1, 0, 0, 0, 220, 1853, 1, 0, 0, 0, 222,
+		1864, 1, 0, 0, 0, 224, 1888, 1, 0, 0, 0, 226, 1893, 1, 0, 0, 0, 228, 1900,
+		1, 0, 0, 0, 230, 1906, 1, 0, 0, 0, 232, 1908, 1, 0, 0, 0, 234, 1913, 1,
+		0, 0, 0, 236, 1918, 1, 0, 0, 0, 238, 1924, 1, 0, 0, 0, 240, 1939, 1, 0,
+		0, 0, 242, 1957, 1, 0, 0, 0, 244, 1960, 1, 0, 0, 0, 246, 1986, 1, 0, 0,
+		0, 248, 1988, 1, 0, 0, 0, 250, 2062, 1, 0, 0, 0, 252, 2070, 1, 0, 0, 0,
+		254, 2072, 1, 0, 0, 0, 256, 2139, 1, 0, 0, 0, 258, 2145, 1, 0, 0, 0, 260,
+		2147, 1, 0, 0, 0, 262, 2164, 1, 0, 0, 0, 264, 2166, 1, 0, 0, 0, 266, 2180,
+		1, 0, 0, 0, 268, 2187, 1, 0, 0, 0, 270, 2193, 1, 0, 0, 0, 272, 2195, 1,
+		0, 0, 0, 274, 2213, 1, 0, 0, 0, 276, 2215, 1, 0, 0, 0, 278, 2228, 1, 0,
+		0, 0, 280, 2292, 1, 0, 0, 0, 282, 2294, 1, 0, 0, 0, 284, 2297, 1, 0, 0,
+		0, 286, 2319, 1, 0, 0, 0, 288, 2321, 1, 0, 0, 0, 290, 2325, 1, 0, 0, 0,
+		292, 2338, 1, 0, 0, 0, 294, 2346, 1, 0, 0, 0, 296, 2348, 1, 0, 0, 0, 298,
+		2352, 1, 0, 0, 0, 300, 2361, 1, 0, 0, 0, 302, 2376, 1, 0, 0, 0, 304, 2378,
+		1, 0, 0, 0, 306, 2411, 1, 0, 0, 0, 308, 2414, 1, 0, 0, 0, 310, 2418, 1,
+		0, 0, 0, 312, 2421, 1, 0, 0, 0, 314, 2426, 1, 0, 0, 0, 316, 2430, 1, 0,
+		0, 0, 318, 2447, 1, 0, 0, 0, 320, 2528, 1, 0, 0, 0, 322, 2530, 1, 0, 0,
+		0, 324, 2539, 1, 0, 0, 0, 326, 2541, 1, 0, 0, 0, 328, 2544, 1, 0, 0, 0,
+		330, 2547, 1, 0, 0, 0, 332, 2558, 1, 0, 0, 0, 334, 2564, 1, 0, 0, 0, 336,
+		2571, 1, 0, 0, 0, 338, 2577, 1, 0, 0, 0, 340, 2602, 1, 0, 0, 0, 342, 2604,
+		1, 0, 0, 0, 344, 2613, 1, 0, 0, 0, 346, 2620, 1, 0, 0, 0, 348, 2624, 1,
+		0, 0, 0, 350, 2648, 1, 0, 0, 0, 352, 2650, 1, 0, 0, 0, 354, 2653, 1, 0,
+		0, 0, 356, 2668, 1, 0, 0, 0, 358, 2670, 1, 0, 0, 0, 360, 2684, 1, 0, 0,
+		0, 362, 2686, 1, 0, 0, 0, 364, 2703, 1, 0, 0, 0, 366, 2706, 1, 0, 0, 0,
+		368, 2710, 1, 0, 0, 0, 370, 2747, 1, 0, 0, 0, 372, 2749, 1, 0, 0, 0, 374,
+		2859, 1, 0, 0, 0, 376, 2861, 1, 0, 0, 0, 378, 2865, 1, 0, 0, 0, 380, 2878,
+		1, 0, 0, 0, 382, 2908, 1, 0, 0, 0, 384, 2910, 1, 0, 0, 0, 386, 2917, 1,
+		0, 0, 0, 388, 2930, 1, 0, 0, 0, 390, 2956, 1, 0, 0, 0, 392, 2958, 1, 0,
+		0, 0, 394, 2961, 1, 0, 0, 0, 396, 2963, 1, 0, 0, 0, 398, 2965, 1, 0, 0,
+		0, 400, 2967, 1, 0, 0, 0, 402, 2969, 1, 0, 0, 0, 404, 2971, 1, 0, 0, 0,
+		406, 2977, 1, 0, 0, 0, 408, 2982, 1, 0, 0, 0, 410, 2989, 1, 0, 0, 0, 412,
+		2991, 1, 0, 0, 0, 414, 2995, 1, 0, 0, 0, 416, 2997, 1, 0, 0, 0, 418, 2999,
+		1, 0, 0, 0, 420, 3001, 1, 0, 0, 0, 422, 3005, 1, 0, 0, 0, 424, 3007, 1,
+		0, 0, 0, 426, 3021, 1, 0, 0, 0, 428, 3025, 1, 0, 0, 0, 430, 3027, 1, 0,
+		0, 0, 432, 3029, 1, 0, 0, 0, 434, 3031, 1, 0, 0, 0, 436, 3033, 1, 0, 0,
+		0, 438, 3035, 1, 0, 0, 0, 440, 3037, 1, 0, 0, 0, 442, 3039, 1, 0, 0, 0,
+		444, 3041, 1, 0, 0, 0, 446, 3043, 1, 0, 0, 0, 448, 3045, 1, 0, 0, 0, 450,
+		3047, 1, 0, 0, 0, 452, 3049, 1, 0, 0, 0, 454, 3052, 1, 0, 0, 0, 456, 3054,
+		1, 0, 0, 0, 458, 3056, 1, 0, 0, 0, 460, 3058, 1, 0, 0, 0, 462, 3060, 1,
+		0, 0, 0, 464, 3063, 1, 0, 0, 0, 466, 3065, 1, 0, 0, 0, 468, 3067, 1, 0,
+		0, 0, 470, 3069, 1, 0, 0, 0, 472, 3071, 1, 0, 0, 0, 474, 3073, 1, 0, 0,
+		0, 476, 3075, 1, 0, 0, 0, 478, 3077, 1, 0, 0, 0, 480, 3079, 1, 0, 0, 0,
+		482, 3081, 1, 0, 0, 0, 484, 3083, 1, 0, 0, 0, 486, 3085, 1, 0, 0, 0, 488,
+		3087, 1, 0, 0, 0, 490, 3089, 1, 0, 0, 0, 492, 3091, 1, 0, 0, 0, 494, 3093,
+		1, 0, 0, 0, 496, 3095, 1, 0, 0, 0, 498, 3097, 1, 0, 0, 0, 500, 3099, 1,
+		0, 0, 0, 502, 3101, 1, 0, 0, 0, 504, 3103, 1, 0, 0, 0, 506, 3105, 1, 0,
+		0, 0, 508, 3107, 1, 0, 0, 0, 510, 3109, 1, 0, 0, 0, 512, 3111, 1, 0, 0,
+		0, 514, 3113, 1, 0, 0, 0, 516, 3115, 1, 0, 0, 0, 518, 3117, 1, 0, 0, 0,
+		520, 3119, 1, 0, 0, 0, 522, 3121, 1, 0, 0, 0, 524, 3123, 1, 0, 0, 0, 526,
+		3125, 1, 0, 0, 0, 528, 3127, 1, 0, 0, 0, 530, 3129, 1, 0, 0, 0, 532, 3131,
+		1, 0, 0, 0, 534, 3133, 1, 0, 0, 0, 536, 3135, 1, 0, 0, 0, 538, 3137, 1,
+		0, 0, 0, 540, 3139, 1, 0, 0, 0, 542, 3141, 1, 0, 0, 0, 544, 3143, 1, 0,
+		0, 0, 546, 3145, 1, 0, 0, 0, 548, 3147, 1, 0, 0, 0, 550, 3149, 1, 0, 0,
+		0, 552, 3151, 1, 0, 0, 0, 554, 3153, 1, 0, 0, 0, 556, 3155, 1, 0, 0, 0,
+		558, 3157, 1, 0, 0, 0, 560, 3159, 1, 0, 0, 0, 562, 3161, 1, 0, 0, 0, 564,
+		3163, 1, 0, 0, 0, 566, 3165, 1, 0, 0, 0, 568, 3167, 1, 0, 0, 0, 570, 3169,
+		1, 0, 0, 0, 572, 3171, 1, 0, 0, 0, 574, 3173, 1, 0, 0, 0, 576, 3175, 1,
+		0, 0, 0, 578, 3177, 1, 0, 0, 0, 580, 3179, 1, 0, 0, 0, 582, 3181, 1, 0,
+		0, 0, 584, 3183, 1, 0, 0, 0, 586, 3185, 1, 0, 0, 0, 588, 3187, 1, 0, 0,
+		0, 590, 3189, 1, 0, 0, 0, 592, 3191, 1, 0, 0, 0, 594, 3193, 1, 0, 0, 0,
+		596, 3195, 1, 0, 0, 0, 598, 3197, 1, 0, 0, 0, 600, 3199, 1, 0, 0, 0, 602,
+		3201, 1, 0, 0, 0, 604, 3203, 1, 0, 0, 0, 606, 3205, 1, 0, 0, 0, 608, 3207,
+		1, 0, 0, 0, 610, 3209, 1, 0, 0, 0, 612, 3211, 1, 0, 0, 0, 614, 3213, 1,
+		0, 0, 0, 616, 3215, 1, 0, 0, 0, 618, 3217, 1, 0, 0, 0, 620, 3219, 1, 0,
+		0, 0, 622, 3221, 1, 0, 0, 0, 624, 3223, 1, 0, 0, 0, 626, 3225, 1, 0, 0,
+		0, 628, 3227, 1, 0, 0, 0, 630, 3229, 1, 0, 0, 0, 632, 3231, 1, 0, 0, 0,
+		634, 3233, 1, 0, 0, 0, 636, 3235, 1, 0, 0, 0, 638, 3237, 1, 0, 0, 0, 640,
+		3239, 1, 0, 0, 0, 642, 3241, 1, 0, 0, 0, 644, 3243, 1, 0, 0, 0, 646, 3245,
+		1, 0, 0, 0, 648, 3247, 1, 0, 0, 0, 650, 3249, 1, 0, 0, 0, 652, 3251, 1,
+		0, 0, 0, 654, 3253, 1, 0, 0, 0, 656, 3255, 1, 0, 0, 0, 658, 3257, 1, 0,
+		0, 0, 660, 3259, 1, 0, 0, 0, 662, 3261, 1, 0, 0, 0, 664, 3263, 1, 0, 0,
+		0, 666, 3265, 1, 0, 0, 0, 668, 3267, 1, 0, 0, 0, 670, 3269, 1, 0, 0, 0,
+		672, 3271, 1, 0, 0, 0, 674, 3273, 1, 0, 0, 0, 676, 3275, 1, 0, 0, 0, 678,
+		3277, 1, 0, 0, 0, 680, 3279, 1, 0, 0, 0, 682, 3281, 1, 0, 0, 0, 684, 3283,
+		1, 0, 0, 0, 686, 3285, 1, 0, 0, 0, 688, 3287, 1, 0, 0, 0, 690, 3289, 1,
+		0, 0, 0, 692, 3291, 1, 0, 0, 0, 694, 3293, 1, 0, 0, 0, 696, 3295, 1, 0,
+		0, 0, 698, 3297, 1, 0, 0, 0, 700, 3299, 1, 0, 0, 0, 702, 3301, 1, 0, 0,
+		0, 704, 3303, 1, 0, 0, 0, 706, 3305, 1, 0, 0, 0, 708, 3307, 1, 0, 0, 0,
+		710, 3309, 1, 0, 0, 0, 712, 3311, 1, 0, 0, 0, 714, 3313, 1, 0, 0, 0, 716,
+		3315, 1, 0, 0, 0, 718, 3317, 1, 0, 0, 0, 720, 3319, 1, 0, 0, 0, 722, 3321,
+		1, 0, 0, 0, 724, 3323, 1, 0, 0, 0, 726, 3325, 1, 0, 0, 0, 728, 3327, 1,
+		0, 0, 0, 730, 3329, 1, 0, 0, 0, 732, 3331, 1, 0, 0, 0, 734, 3333, 1, 0,
+		0, 0, 736, 3335, 1, 0, 0, 0, 738, 3337, 1, 0, 0, 0, 740, 3339, 1, 0, 0,
+		0, 742, 3341, 1, 0, 0, 0, 744, 3343, 1, 0, 0, 0, 746, 3345, 1, 0, 0, 0,
+		748, 3347, 1, 0, 0, 0, 750, 752, 3, 2, 1, 0, 751, 750, 1, 0, 0, 0, 751,
+		752, 1, 0, 0, 0, 752, 754, 1, 0, 0, 0, 753, 755, 5, 15, 0, 0, 754, 753,
+		1, 0, 0, 0, 754, 755, 1, 0, 0, 0, 755, 756, 1, 0, 0, 0, 756, 757, 5, 0,
+		0, 1, 757, 1, 1, 0, 0, 0, 758, 760, 3, 8, 4, 0, 759, 761, 5, 15, 0, 0,
+		760, 759, 1, 0, 0, 0, 760, 761, 1, 0, 0, 0, 761, 762, 1, 0, 0, 0, 762,
+		763, 3, 4, 2, 0, 763, 766, 1, 0, 0, 0, 764, 766, 3, 6, 3, 0, 765, 758,
+		1, 0, 0, 0, 765, 764, 1, 0, 0, 0, 766, 769, 1, 0, 0, 0, 767, 765, 1, 0,
+		0, 0, 767, 768, 1, 0, 0, 0, 768, 778, 1, 0, 0, 0, 769, 767, 1, 0, 0, 0,
+		770, 775, 3, 8, 4, 0, 771, 773, 5, 15, 0, 0, 772, 771, 1, 0, 0, 0, 772,
+		773, 1, 0, 0, 0, 773, 774, 1, 0, 0, 0, 774, 776, 3, 4, 2, 0, 775, 772,
+		1, 0, 0, 0, 775, 776, 1, 0, 0, 0, 776, 779, 1, 0, 0, 0, 777, 779, 3, 6,
+		3, 0, 778, 770, 1, 0, 0, 0, 778, 777, 1, 0, 0, 0, 779, 3, 1, 0, 0, 0, 780,
+		781, 5, 8, 0, 0, 781, 5, 1, 0, 0, 0, 782, 783, 3, 4, 2, 0, 783, 7, 1, 0,
+		0, 0, 784, 832, 3, 204, 102, 0, 785, 832, 3, 116, 58, 0, 786, 832, 3, 110,
+		55, 0, 787, 832, 3, 92, 46, 0, 788, 832, 3, 78, 39, 0, 789, 832, 3, 72,
+		36, 0, 790, 832, 3, 186, 93, 0, 791, 832, 3, 58, 29, 0, 792, 832, 3, 50,
+		25, 0, 793, 832, 3, 222, 111, 0, 794, 832, 3, 48, 24, 0, 795, 832, 3, 34,
+		17, 0, 796, 832, 3, 26, 13, 0, 797, 832, 3, 138, 69, 0, 798, 832, 3, 32,
+		16, 0, 799, 832, 3, 28, 14, 0, 800, 832, 3, 24, 12, 0, 801, 832, 3, 238,
+		119, 0, 802, 832, 3, 124, 62, 0, 803, 832, 3, 126, 63, 0, 804, 832, 3,
+		136, 68, 0, 805, 832, 3, 134, 67, 0, 806, 832, 3, 122, 61, 0, 807, 832,
+		3, 130, 65, 0, 808, 832, 3, 132, 66, 0, 809, 832, 3, 128, 64, 0, 810, 832,
+		3, 120, 60, 0, 811, 832, 3, 118, 59, 0, 812, 832, 3, 18, 9, 0, 813, 832,
+		3, 278, 139, 0, 814, 832, 3, 16, 8, 0, 815, 832, 3, 12, 6, 0, 816, 832,
+		3, 10, 5, 0, 817, 832, 3, 304, 152, 0, 818, 832, 3, 220, 110, 0, 819, 832,
+		3, 244, 122, 0, 820, 832, 3, 218, 109, 0, 821, 832, 3, 316, 158, 0, 822,
+		832, 3, 188, 94, 0, 823, 832, 3, 318, 159, 0, 824, 832, 3, 330, 165, 0,
+		825, 832, 3, 332, 166, 0, 826, 832, 3, 334, 167, 0, 827, 832, 3, 336, 168,
+		0, 828, 832, 3, 338, 169, 0, 829, 832, 3, 340, 170, 0, 830, 832, 3, 14,
+		7, 0, 831, 784, 1, 0, 0, 0, 831, 785, 1, 0, 0, 0, 831, 786, 1, 0, 0, 0,
+		831, 787, 1, 0, 0, 0, 831, 788, 1, 0, 0, 0, 831, 789, 1, 0, 0, 0, 831,
+		790, 1, 0, 0, 0, 831, 791, 1, 0, 0, 0, 831, 792, 1, 0, 0, 0, 831, 793,
+		1, 0, 0, 0, 831, 794, 1, 0, 0, 0, 831, 795, 1, 0, 0, 0, 831, 796, 1, 0,
+		0, 0, 831, 797, 1, 0, 0, 0, 831, 798, 1, 0, 0, 0, 831, 799, 1, 0, 0, 0,
+		831, 800, 1, 0, 0, 0, 831, 801, 1, 0, 0, 0, 831, 802, 1, 0, 0, 0, 831,
+		803, 1, 0, 0, 0, 831, 804, 1, 0, 0, 0, 831, 805, 1, 0, 0, 0, 831, 806,
+		1, 0, 0, 0, 831, 807, 1, 0, 0, 0, 831, 808, 1, 0, 0, 0, 831, 809, 1, 0,
+		0, 0, 831, 810, 1, 0, 0, 0, 831, 811, 1, 0, 0, 0, 831, 812, 1, 0, 0, 0,
+		831, 813, 1, 0, 0, 0, 831, 814, 1, 0, 0, 0, 831, 815, 1, 0, 0, 0, 831,
+		816, 1, 0, 0, 0, 831, 817, 1, 0, 0, 0, 831, 818, 1, 0, 0, 0, 831, 819,
+		1, 0, 0, 0, 831, 820, 1, 0, 0, 0, 831, 821, 1, 0, 0, 0, 831, 822, 1, 0,
+		0, 0, 831, 823, 1, 0, 0, 0, 831, 824, 1, 0, 0, 0, 831, 825, 1, 0, 0, 0,
+		831, 826, 1, 0, 0, 0, 831, 827, 1, 0, 0, 0, 831, 828, 1, 0, 0, 0, 831,
+		829, 1, 0, 0, 0, 831, 830, 1, 0, 0, 0, 832, 9, 1, 0, 0, 0, 833, 834, 3,
+		644, 322, 0, 834, 835, 3, 20, 10, 0, 835, 836, 3, 576, 288, 0, 836, 837,
+		3, 22, 11, 0, 837, 838, 3, 516, 258, 0, 838, 839, 3, 430, 215, 0, 839,
+		11, 1, 0, 0, 0, 840, 842, 3, 556, 278, 0, 841, 843, 3, 460, 230, 0, 842,
+		841, 1, 0, 0, 0, 842, 843, 1, 0, 0, 0, 843, 844, 1, 0, 0, 0, 844, 848,
+		3, 598, 299, 0, 845, 846, 3, 574, 287, 0, 846, 847, 3, 430, 215, 0, 847,
+		849, 1, 0, 0, 0, 848, 845, 1, 0, 0, 0, 848, 849, 1, 0, 0, 0, 849, 851,
+		1, 0, 0, 0, 850, 852, 3, 568, 284, 0, 851, 850, 1, 0, 0, 0, 851, 852, 1,
+		0, 0, 0, 852, 13, 1, 0, 0, 0, 853, 854, 3, 556, 278, 0, 854, 855, 3, 740,
+		370, 0, 855, 15, 1, 0, 0, 0, 856, 857, 3, 556, 278, 0, 857, 861, 3, 20,
+		10, 0, 858, 859, 3, 576, 288, 0, 859, 860, 3, 22, 11, 0, 860, 862, 1, 0,
+		0, 0, 861, 858, 1, 0, 0, 0, 861, 862, 1, 0, 0, 0, 862, 866, 1, 0, 0, 0,
+		863, 864, 3, 574, 287, 0, 864, 865, 3, 430, 215, 0, 865, 867, 1, 0, 0,
+		0, 866, 863, 1, 0, 0, 0, 866, 867, 1, 0, 0, 0, 867, 17, 1, 0, 0, 0, 868,
+		869, 3, 524, 262, 0, 869, 870, 3, 20, 10, 0, 870, 871, 3, 576, 288, 0,
+		871, 872, 3, 22, 11, 0, 872, 873, 3, 616, 308, 0, 873, 874, 3, 430, 215,
+		0, 874, 19, 1, 0, 0, 0, 875, 878, 3, 460, 230, 0, 876, 878, 3, 462, 231,
+		0, 877, 875, 1, 0, 0, 0, 877, 876, 1, 0, 0, 0, 878, 889, 1, 0, 0, 0, 879,
+		889, 3, 466, 233, 0, 880, 889, 3, 476, 238, 0, 881, 889, 3, 498, 249, 0,
+		882, 889, 3, 508, 254, 0, 883, 889, 3, 492, 246, 0, 884, 889, 3, 502, 251,
+		0, 885, 889, 3, 564, 282, 0, 886, 889, 3, 600, 300, 0, 887, 889, 3, 748,
+		374, 0, 888, 877, 1, 0, 0, 0, 888, 879, 1, 0, 0, 0, 888, 880, 1, 0, 0,
+		0, 888, 881, 1, 0, 0, 0, 888, 882, 1, 0, 0, 0, 888, 883, 1, 0, 0, 0, 888,
+		884, 1, 0, 0, 0, 888, 885, 1, 0, 0, 0, 888, 886, 1, 0, 0, 0, 888, 887,
+		1, 0, 0, 0, 889, 21, 1, 0, 0, 0, 890, 891, 3, 460, 230, 0, 891, 892, 3,
+		522, 261, 0, 892, 929, 1, 0, 0, 0, 893, 894, 3, 460, 230, 0, 894, 895,
+		3, 522, 261, 0, 895, 896, 3, 528, 264, 0, 896, 897, 3, 548, 274, 0, 897,
+		898, 3, 406, 203, 0, 898, 929, 1, 0, 0, 0, 899, 903, 3, 520, 260, 0, 900,
+		901, 3, 406, 203, 0, 901, 902, 5, 10, 0, 0, 902, 904, 1, 0, 0, 0, 903,
+		900, 1, 0, 0, 0, 903, 904, 1, 0, 0, 0, 904, 905, 1, 0, 0, 0, 905, 906,
+		3, 442, 221, 0, 906, 929, 1, 0, 0, 0, 907, 908, 3, 460, 230, 0, 908, 909,
+		3, 550, 275, 0, 909, 929, 1, 0, 0, 0, 910, 911, 3, 548, 274, 0, 911, 912,
+		3, 406, 203, 0, 912, 929, 1, 0, 0, 0, 913, 915, 3, 612, 306, 0, 914, 913,
+		1, 0, 0, 0, 914, 915, 1, 0, 0, 0, 915, 919, 1, 0, 0, 0, 916, 917, 3, 406,
+		203, 0, 917, 918, 5, 10, 0, 0, 918, 920, 1, 0, 0, 0, 919, 916, 1, 0, 0,
+		0, 919, 920, 1, 0, 0, 0, 920, 921, 1, 0, 0, 0, 921, 929, 3, 408, 204, 0,
+		922, 923, 3, 460, 230, 0, 923, 924, 3, 598, 299, 0, 924, 929, 1, 0, 0,
+		0, 925, 926, 3, 596, 298, 0, 926, 927, 3, 430, 215, 0, 927, 929, 1, 0,
+		0, 0, 928, 890, 1, 0, 0, 0, 928, 893, 1, 0, 0, 0, 928, 899, 1, 0, 0, 0,
+		928, 907, 1, 0, 0, 0, 928, 910, 1, 0, 0, 0, 928, 914, 1, 0, 0, 0, 928,
+		922, 1, 0, 0, 0, 928, 925, 1, 0, 0, 0, 929, 23, 1, 0, 0, 0, 930, 931, 3,
+		492, 246, 0, 931, 933, 3, 632, 316, 0, 932, 934, 3, 288, 144, 0, 933, 932,
+		1, 0, 0, 0, 933, 934, 1, 0, 0, 0, 934, 935, 1, 0, 0, 0, 935, 936, 3, 446,
+		223, 0, 936, 937, 3, 642, 321, 0, 937, 938, 3, 584, 292, 0, 938, 941, 3,
+		400, 200, 0, 939, 942, 3, 610, 305, 0, 940, 942, 3, 566, 283, 0, 941, 939,
+		1, 0, 0, 0, 941, 940, 1, 0, 0, 0, 941, 942, 1, 0, 0, 0, 942, 25, 1, 0,
+		0, 0, 943, 944, 3, 492, 246, 0, 944, 946, 3, 596, 298, 0, 945, 947, 3,
+		288, 144, 0, 946, 945, 1, 0, 0, 0, 946, 947, 1, 0, 0, 0, 947, 948, 1, 0,
+		0, 0, 948, 950, 3, 430, 215, 0, 949, 951, 3, 112, 56, 0, 950, 949, 1, 0,
+		0, 0, 950, 951, 1, 0, 0, 0, 951, 27, 1, 0, 0, 0, 952, 953, 3, 492, 246,
+		0, 953, 955, 3, 624, 312, 0, 954, 956, 3, 288, 144, 0, 955, 954, 1, 0,
+		0, 0, 955, 956, 1, 0, 0, 0, 956, 960, 1, 0, 0, 0, 957, 958, 3, 406, 203,
+		0, 958, 959, 5, 10, 0, 0, 959, 961, 1, 0, 0, 0, 960, 957, 1, 0, 0, 0, 960,
+		961, 1, 0, 0, 0, 961, 962, 1, 0, 0, 0, 962, 963, 3, 438, 219, 0, 963, 964,
+		3, 646, 323, 0, 964, 965, 3, 30, 15, 0, 965, 966, 3, 648, 324, 0, 966,
+		29, 1, 0, 0, 0, 967, 968, 3, 410, 205, 0, 968, 975, 3, 420, 210, 0, 969,
+		970, 3, 662, 331, 0, 970, 971, 3, 410, 205, 0, 971, 972, 3, 420, 210, 0,
+		972, 974, 1, 0, 0, 0, 973, 969, 1, 0, 0, 0, 974, 977, 1, 0, 0, 0, 975,
+		973, 1, 0, 0, 0, 975, 976, 1, 0, 0, 0, 976, 31, 1, 0, 0, 0, 977, 975, 1,
+		0, 0, 0, 978, 979, 3, 492, 246, 0, 979, 981, 3, 618, 309, 0, 980, 982,
+		3, 288, 144, 0, 981, 980, 1, 0, 0, 0, 981, 982, 1, 0, 0, 0, 982, 986, 1,
+		0, 0, 0, 983, 984, 3, 406, 203, 0, 984, 985, 5, 10, 0, 0, 985, 987, 1,
+		0, 0, 0, 986, 983, 1, 0, 0, 0, 986, 987, 1, 0, 0, 0, 987, 988, 1, 0, 0,
+		0, 988, 989, 3, 432, 216, 0, 989, 990, 3, 634, 317, 0, 990, 991, 3, 434,
+		217, 0, 991, 33, 1, 0, 0, 0, 992, 993, 3, 492, 246, 0, 993, 994, 3, 562,
+		281, 0, 994, 996, 3, 638, 319, 0, 995, 997, 3, 288, 144, 0, 996, 995, 1,
+		0, 0, 0, 996, 997, 1, 0, 0, 0, 997, 1001, 1, 0, 0, 0, 998, 999, 3, 406,
 		203, 0, 999, 1000, 5, 10, 0, 0, 1000, 1002, 1, 0, 0, 0, 1001, 998, 1, 0,
 		0, 0, 1001, 1002, 1, 0, 0, 0, 1002, 1003, 1, 0, 0, 0, 1003, 1004, 3, 436,
 		218, 0, 1004, 1005, 3, 472, 236, 0, 1005, 1006, 3, 600, 300, 0, 1006, 1007,
@@ -1091,8 +1098,8 @@ func cqlparserParserInit() {
 		1, 0, 0, 0, 1669, 1668, 1, 0, 0, 0, 1670, 171, 1, 0, 0, 0, 1671, 1672,
 		3, 410, 205, 0, 1672, 173, 1, 0, 0, 0, 1673, 1674, 3, 182, 91, 0, 1674,
 		1675, 3, 662, 331, 0, 1675, 1676, 3, 180, 90, 0, 1676, 175, 1, 0, 0, 0,
-		1677, 1678, 3, 646, 323, 0, 1678, 1679, 3, 178, 89, 0, 1679, 1680, 3, 648,
-		324, 0, 1680, 1681, 3, 662, 331, 0, 1681, 1682, 3, 180, 90, 0, 1682, 177,
+		1677, 1678, 3, 646, 323, 0, 1678, 1679, 3, 178, 89, 0, 1679, 3390, 3, 648,
+		324, 0, 1680, 1681, 3, 662, 331, 0, 1681, 1682, 3, 180, 90, 0, 1682, 3391,
 		1, 0, 0, 0, 1683, 1689, 3, 182, 91, 0, 1684, 1685, 3, 662, 331, 0, 1685,
 		1686, 3, 182, 91, 0, 1686, 1688, 1, 0, 0, 0, 1687, 1684, 1, 0, 0, 0, 1688,
 		1691, 1, 0, 0, 0, 1689, 1687, 1, 0, 0, 0, 1689, 1690, 1, 0, 0, 0, 1690,
@@ -1350,9 +1357,9 @@ func cqlparserParserInit() {
 		1, 0, 0, 0, 2292, 2248, 1, 0, 0, 0, 2292, 2251, 1, 0, 0, 0, 2292, 2256,
 		1, 0, 0, 0, 2292, 2259, 1, 0, 0, 0, 2292, 2264, 1, 0, 0, 0, 2292, 2268,
 		1, 0, 0, 0, 2292, 2274, 1, 0, 0, 0, 2292, 2280, 1, 0, 0, 0, 2292, 2286,
-		1, 0, 0, 0, 2293, 281, 1, 0, 0, 0, 2294, 2295, 3, 614, 307, 0, 2295, 2296,
-		3, 396, 198, 0, 2296, 283, 1, 0, 0, 0, 2297, 2298, 3, 622, 311, 0, 2298,
-		2299, 3, 396, 198, 0, 2299, 285, 1, 0, 0, 0, 2300, 2301, 3, 634, 317, 0,
+		1, 0, 0, 0, 2293, 281, 1, 0, 0, 0, 2294, 3356, 3, 614, 307, 0, 2295, 3357,
+		3, 396, 198, 0, 2296, 283, 1, 0, 0, 0, 2297, 3352, 3, 622, 311, 0, 2298,
+		3353, 3, 396, 198, 0, 2299, 285, 1, 0, 0, 0, 2300, 2301, 3, 634, 317, 0,
 		2301, 2302, 3, 282, 141, 0, 2302, 2320, 1, 0, 0, 0, 2303, 2304, 3, 634,
 		317, 0, 2304, 2305, 3, 670, 335, 0, 2305, 2306, 3, 390, 195, 0, 2306, 2320,
 		1, 0, 0, 0, 2307, 2308, 3, 634, 317, 0, 2308, 2309, 3, 282, 141, 0, 2309,
@@ -1403,8 +1410,8 @@ func cqlparserParserInit() {
 		2413, 307, 1, 0, 0, 0, 2414, 2415, 3, 678, 339, 0, 2415, 2416, 3, 482,
 		241, 0, 2416, 2417, 3, 298, 149, 0, 2417, 309, 1, 0, 0, 0, 2418, 2419,
 		3, 666, 333, 0, 2419, 2420, 3, 668, 334, 0, 2420, 311, 1, 0, 0, 0, 2421,
-		2422, 3, 674, 337, 0, 2422, 2423, 3, 676, 338, 0, 2423, 2424, 3, 554, 277,
-		0, 2424, 2425, 3, 396, 198, 0, 2425, 313, 1, 0, 0, 0, 2426, 2427, 3, 634,
+		2422, 3, 674, 337, 0, 2422, 2423, 3, 676, 338, 0, 2423, 3364, 3, 554, 277,
+		0, 2424, 3365, 3, 396, 198, 0, 2425, 313, 1, 0, 0, 0, 2426, 2427, 3, 634,
 		317, 0, 2427, 2428, 3, 670, 335, 0, 2428, 2429, 3, 390, 195, 0, 2429, 315,
 		1, 0, 0, 0, 2430, 2431, 3, 672, 336, 0, 2431, 2432, 3, 562, 281, 0, 2432,
 		2436, 3, 638, 319, 0, 2433, 2434, 3, 406, 203, 0, 2434, 2435, 5, 10, 0,
@@ -1499,8 +1506,8 @@ func cqlparserParserInit() {
 		335, 0, 2643, 2644, 3, 390, 195, 0, 2644, 2645, 3, 468, 234, 0, 2645, 2646,
 		3, 746, 373, 0, 2646, 2647, 3, 396, 198, 0, 2647, 2649, 1, 0, 0, 0, 2648,
 		2626, 1, 0, 0, 0, 2648, 2630, 1, 0, 0, 0, 2648, 2634, 1, 0, 0, 0, 2648,
-		2641, 1, 0, 0, 0, 2649, 351, 1, 0, 0, 0, 2650, 2651, 3, 554, 277, 0, 2651,
-		2652, 3, 396, 198, 0, 2652, 353, 1, 0, 0, 0, 2653, 2654, 3, 516, 258, 0,
+		2641, 1, 0, 0, 0, 2649, 351, 1, 0, 0, 0, 2650, 3360, 3, 554, 277, 0, 2651,
+		3361, 3, 396, 198, 0, 2652, 353, 1, 0, 0, 0, 2653, 2654, 3, 516, 258, 0,
 		2654, 2655, 3, 356, 178, 0, 2655, 355, 1, 0, 0, 0, 2656, 2669, 5, 208,
 		0, 0, 2657, 2669, 3, 416, 208, 0, 2658, 2669, 3, 418, 209, 0, 2659, 2660,
 		5, 208, 0, 0, 2660, 2661, 5, 10, 0, 0, 2661, 2669, 5, 208, 0, 0, 2662,
@@ -1560,11 +1567,11 @@ func cqlparserParserInit() {
 		0, 2791, 2792, 3, 414, 207, 0, 2792, 2794, 1, 0, 0, 0, 2793, 2790, 1, 0,
 		0, 0, 2794, 2797, 1, 0, 0, 0, 2795, 2793, 1, 0, 0, 0, 2795, 2796, 1, 0,
 		0, 0, 2796, 2798, 1, 0, 0, 0, 2797, 2795, 1, 0, 0, 0, 2798, 2799, 5, 2,
-		0, 0, 2799, 2800, 3, 528, 264, 0, 2800, 2801, 5, 1, 0, 0, 2801, 2807, 3,
+		0, 0, 2799, 3402, 3, 528, 264, 0, 2800, 2801, 5, 1, 0, 0, 2801, 2807, 3,
 		276, 138, 0, 2802, 2803, 3, 662, 331, 0, 2803, 2804, 3, 276, 138, 0, 2804,
 		2806, 1, 0, 0, 0, 2805, 2802, 1, 0, 0, 0, 2806, 2809, 1, 0, 0, 0, 2807,
 		2805, 1, 0, 0, 0, 2807, 2808, 1, 0, 0, 0, 2808, 2810, 1, 0, 0, 0, 2809,
-		2807, 1, 0, 0, 0, 2810, 2811, 5, 2, 0, 0, 2811, 2860, 1, 0, 0, 0, 2812,
+		2807, 1, 0, 0, 0, 2810, 2811, 5, 2, 0, 0, 2811, 3403, 1, 0, 0, 0, 2812,
 		2813, 5, 1, 0, 0, 2813, 2819, 3, 414, 207, 0, 2814, 2815, 3, 662, 331,
 		0, 2815, 2816, 3, 414, 207, 0, 2816, 2818, 1, 0, 0, 0, 2817, 2814, 1, 0,
 		0, 0, 2818, 2821, 1, 0, 0, 0, 2819, 2817, 1, 0, 0, 0, 2819, 2820, 1, 0,
@@ -1764,29 +1771,57 @@ func cqlparserParserInit() {
 		0, 0, 3338, 739, 1, 0, 0, 0, 3339, 3340, 5, 194, 0, 0, 3340, 741, 1, 0,
 		0, 0, 3341, 3342, 5, 195, 0, 0, 3342, 743, 1, 0, 0, 0, 3343, 3344, 5, 196,
 		0, 0, 3344, 745, 1, 0, 0, 0, 3345, 3346, 5, 197, 0, 0, 3346, 747, 1, 0,
-		0, 0, 3347, 3348, 5, 198, 0, 0, 3348, 749, 1, 0, 0, 0, 269, 751, 754, 760,
-		765, 767, 772, 775, 778, 831, 842, 848, 851, 861, 866, 877, 888, 903, 914,
-		919, 928, 933, 941, 946, 950, 955, 960, 975, 981, 986, 996, 1001, 1009,
-		1015, 1024, 1036, 1043, 1051, 1065, 1070, 1082, 1087, 1091, 1095, 1100,
-		1105, 1124, 1131, 1139, 1143, 1148, 1161, 1166, 1171, 1177, 1186, 1201,
-		1203, 1215, 1229, 1236, 1243, 1251, 1262, 1278, 1291, 1301, 1324, 1331,
-		1341, 1346, 1356, 1363, 1372, 1400, 1408, 1414, 1419, 1426, 1431, 1439,
-		1444, 1451, 1456, 1463, 1468, 1473, 1480, 1487, 1494, 1501, 1506, 1513,
-		1520, 1525, 1532, 1537, 1544, 1554, 1560, 1568, 1571, 1579, 1584, 1588,
-		1601, 1607, 1616, 1631, 1639, 1645, 1650, 1653, 1669, 1689, 1698, 1710,
-		1714, 1717, 1725, 1730, 1737, 1741, 1745, 1748, 1754, 1758, 1765, 1769,
-		1773, 1778, 1782, 1786, 1791, 1805, 1813, 1822, 1840, 1848, 1855, 1860,
-		1866, 1870, 1873, 1879, 1886, 1891, 1900, 1906, 1924, 1928, 1932, 1937,
-		1945, 1953, 1957, 1960, 1966, 1970, 1977, 1986, 1994, 2002, 2022, 2025,
-		2062, 2070, 2078, 2088, 2139, 2145, 2154, 2157, 2164, 2173, 2176, 2187,
-		2193, 2202, 2205, 2213, 2222, 2228, 2235, 2239, 2243, 2246, 2292, 2319,
-		2336, 2338, 2346, 2358, 2367, 2376, 2380, 2383, 2388, 2391, 2394, 2397,
-		2400, 2403, 2406, 2409, 2436, 2440, 2443, 2447, 2451, 2455, 2460, 2464,
-		2468, 2473, 2480, 2488, 2497, 2506, 2515, 2524, 2528, 2535, 2539, 2550,
-		2556, 2567, 2602, 2610, 2620, 2624, 2648, 2668, 2677, 2684, 2689, 2694,
-		2698, 2710, 2717, 2727, 2733, 2739, 2745, 2747, 2755, 2784, 2795, 2807,
-		2819, 2830, 2840, 2859, 2874, 2878, 2887, 2908, 2922, 2930, 2937, 2941,
-		2956, 2977, 2982, 2989, 2995, 3003, 3014, 3021, 3025,
+		0, 0, 3347, 3348, 5, 198, 0, 0, 3348, 749, 1, 0, 0, 0, 3352, 2298, 1, 0,
+		0, 0, 3352, 3350, 1, 0, 0, 0, 3350, 3353, 5, 205, 0, 0, 3352, 3351, 1,
+		0, 0, 0, 3351, 3353, 3, 392, 196, 0, 3353, 2299, 1, 0, 0, 0, 3356, 2295,
+		1, 0, 0, 0, 3356, 3354, 1, 0, 0, 0, 3354, 3357, 5, 205, 0, 0, 3356, 3355,
+		1, 0, 0, 0, 3355, 3357, 3, 392, 196, 0, 3357, 2296, 1, 0, 0, 0, 3360, 2651,
+		1, 0, 0, 0, 3360, 3358, 1, 0, 0, 0, 3358, 3361, 5, 205, 0, 0, 3360, 3359,
+		1, 0, 0, 0, 3359, 3361, 3, 392, 196, 0, 3361, 2652, 1, 0, 0, 0, 3364, 2424,
+		1, 0, 0, 0, 3364, 3362, 1, 0, 0, 0, 3362, 3365, 5, 205, 0, 0, 3364, 3363,
+		1, 0, 0, 0, 3363, 3365, 3, 392, 196, 0, 3365, 2425, 1, 0, 0, 0, 2859, 3366,
+		1, 0, 0, 0, 3366, 3367, 3, 414, 207, 0, 3367, 3370, 3, 528, 264, 0, 3370,
+		3368, 1, 0, 0, 0, 3368, 3371, 5, 205, 0, 0, 3370, 3369, 1, 0, 0, 0, 3369,
+		3371, 3, 392, 196, 0, 3371, 2860, 1, 0, 0, 0, 2187, 3372, 1, 0, 0, 0, 3372,
+		2188, 5, 208, 0, 0, 2747, 3373, 1, 0, 0, 0, 3373, 3374, 3, 414, 207, 0,
+		3374, 3375, 5, 10, 0, 0, 3375, 3378, 5, 208, 0, 0, 3378, 3376, 1, 0, 0,
+		0, 3376, 3377, 3, 472, 236, 0, 3377, 3379, 5, 208, 0, 0, 3378, 3379, 1,
+		0, 0, 0, 3379, 2748, 1, 0, 0, 0, 2139, 3380, 1, 0, 0, 0, 3380, 3381, 3,
+		414, 207, 0, 3381, 3382, 5, 10, 0, 0, 3382, 3383, 5, 208, 0, 0, 3383, 3388,
+		5, 19, 0, 0, 3388, 3384, 1, 0, 0, 0, 3384, 3389, 3, 390, 195, 0, 3388,
+		3385, 1, 0, 0, 0, 3385, 3389, 3, 264, 132, 0, 3388, 3386, 1, 0, 0, 0, 3386,
+		3389, 3, 260, 130, 0, 3388, 3387, 1, 0, 0, 0, 3387, 3389, 3, 272, 136,
+		0, 3389, 2140, 1, 0, 0, 0, 3390, 1680, 1, 0, 0, 0, 3390, 3391, 1, 0, 0,
+		0, 3391, 177, 1, 0, 0, 0, 1301, 3392, 1, 0, 0, 0, 3392, 3393, 3, 466, 233,
+		0, 3393, 3394, 3, 410, 205, 0, 3394, 3395, 3, 624, 312, 0, 3395, 1302,
+		3, 420, 210, 0, 1953, 3396, 1, 0, 0, 0, 3396, 1954, 5, 205, 0, 0, 1953,
+		3397, 1, 0, 0, 0, 3397, 1954, 3, 392, 196, 0, 2145, 3398, 1, 0, 0, 0, 3398,
+		2146, 5, 205, 0, 0, 2145, 3399, 1, 0, 0, 0, 3399, 2146, 3, 392, 196, 0,
+		3402, 2800, 1, 0, 0, 0, 3402, 3400, 1, 0, 0, 0, 3400, 3403, 5, 205, 0,
+		0, 3402, 3401, 1, 0, 0, 0, 3401, 3403, 3, 392, 196, 0, 3403, 2860, 1, 0,
+		0, 0, 278, 751, 754, 760, 765, 767, 772, 775, 778, 831, 842, 848, 851,
+		861, 866, 877, 888, 903, 914, 919, 928, 933, 941, 946, 950, 955, 960, 975,
+		981, 986, 996, 1001, 1009, 1015, 1024, 1036, 1043, 1051, 1065, 1070, 1082,
+		1087, 1091, 1095, 1100, 1105, 1124, 1131, 1139, 1143, 1148, 1161, 1166,
+		1171, 1177, 1186, 1201, 1203, 1215, 1229, 1236, 1243, 1251, 1262, 1278,
+		1291, 1301, 1324, 1331, 1341, 1346, 1356, 1363, 1372, 1400, 1408, 1414,
+		1419, 1426, 1431, 1439, 1444, 1451, 1456, 1463, 1468, 1473, 1480, 1487,
+		1494, 1501, 1506, 1513, 1520, 1525, 1532, 1537, 1544, 1554, 1560, 1568,
+		1571, 1579, 1584, 1588, 1601, 1607, 1616, 1631, 1639, 1645, 1650, 1653,
+		1669, 1689, 1698, 1710, 1714, 1717, 1725, 1730, 1737, 1741, 1745, 1748,
+		1754, 1758, 1765, 1769, 1773, 1778, 1782, 1786, 1791, 1805, 1813, 1822,
+		1840, 1848, 1855, 1860, 1866, 1870, 1873, 1879, 1886, 1891, 1900, 1906,
+		1924, 1928, 1932, 1937, 1945, 1953, 1957, 1960, 1966, 1970, 1977, 1986,
+		1994, 2002, 2022, 2025, 2062, 2070, 2078, 2088, 2139, 2145, 2154, 2157,
+		2164, 2173, 2176, 2187, 2193, 2202, 2205, 2213, 2222, 2228, 2235, 2239,
+		2243, 2246, 2292, 2319, 2336, 2338, 2346, 2358, 2367, 2376, 2380, 2383,
+		2388, 2391, 2394, 2397, 2400, 2403, 2406, 2409, 2436, 2440, 2443, 2447,
+		2451, 2455, 2460, 2464, 2468, 2473, 2480, 2488, 2497, 2506, 2515, 2524,
+		2528, 2535, 2539, 2550, 2556, 2567, 2602, 2610, 2620, 2624, 2648, 2668,
+		2677, 2684, 2689, 2694, 2698, 2710, 2717, 2727, 2733, 2739, 2745, 2747,
+		2755, 2784, 2795, 2807, 2819, 2830, 2840, 2859, 2874, 2878, 2887, 2908,
+		2922, 2930, 2937, 2941, 2956, 2977, 2982, 2989, 2995, 3003, 3014, 3021,
+		3025, 3352, 3356, 3360, 3364, 3370, 3378, 3388, 3390, 3402,
 	}
 	deserializer := antlr.NewATNDeserializer(nil)
 	staticData.atn = deserializer.Deserialize(staticData.serializedATN)
@@ -14328,6 +14363,10 @@ type IAlterTableOperationContext interface {
 	AlterTableDropCompactStorage() IAlterTableDropCompactStorageContext
 	AlterTableRename() IAlterTableRenameContext
 	AlterTableWith() IAlterTableWithContext
+	KwAlter() IKwAlterContext
+	Column() IColumnContext
+	KwType() IKwTypeContext
+	DataType() IDataTypeContext
 
 	// IsAlterTableOperationContext differentiates from other interfaces.
 	IsAlterTableOperationContext()
@@ -14445,6 +14484,70 @@ func (s *AlterTableOperationContext) AlterTableWith() IAlterTableWithContext {
 	return t.(IAlterTableWithContext)
 }
 
+func (s *AlterTableOperationContext) KwAlter() IKwAlterContext {
+	var t antlr.RuleContext
+	for _, ctx := range s.GetChildren() {
+		if _, ok := ctx.(IKwAlterContext); ok {
+			t = ctx.(antlr.RuleContext)
+			break
+		}
+	}
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IKwAlterContext)
+}
+
+func (s *AlterTableOperationContext) Column() IColumnContext {
+	var t antlr.RuleContext
+	for _, ctx := range s.GetChildren() {
+		if _, ok := ctx.(IColumnContext); ok {
+			t = ctx.(antlr.RuleContext)
+			break
+		}
+	}
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IColumnContext)
+}
+
+func (s *AlterTableOperationContext) KwType() IKwTypeContext {
+	var t antlr.RuleContext
+	for _, ctx := range s.GetChildren() {
+		if _, ok := ctx.(IKwTypeContext); ok {
+			t = ctx.(antlr.RuleContext)
+			break
+		}
+	}
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IKwTypeContext)
+}
+
+func (s *AlterTableOperationContext) DataType() IDataTypeContext {
+	var t antlr.RuleContext
+	for _, ctx := range s.GetChildren() {
+		if _, ok := ctx.(IDataTypeContext); ok {
+			t = ctx.(antlr.RuleContext)
+			break
+		}
+	}
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IDataTypeContext)
+}
+
 func (s *AlterTableOperationContext) GetRuleContext() antlr.RuleContext {
 	return s
 }
@@ -14510,6 +14613,25 @@ func (p *CqlParser) AlterTableOperation() (localctx IAlterTableOperationContext)
 			p.AlterTableWith()
 		}
 
+	case 6:
+		p.EnterOuterAlt(localctx, 6)
+		{
+			p.SetState(3392)
+			p.KwAlter()
+		}
+		{
+			p.SetState(3393)
+			p.Column()
+		}
+		{
+			p.SetState(3394)
+			p.KwType()
+		}
+		{
+			p.SetState(3395)
+			p.DataType()
+		}
+
 	case antlr.ATNInvalidAltNumber:
 		goto errorExit
 	}
@@ -22820,6 +22942,8 @@ func (s *CompositeKeyContext) ExitRule(listener antlr.ParseTreeListener) {
 func (p *CqlParser) CompositeKey() (localctx ICompositeKeyContext) {
 	localctx = NewCompositeKeyContext(p, p.GetParserRuleContext(), p.GetState())
 	p.EnterRule(localctx, 176, CqlParserRULE_compositeKey)
+	var _la int
+
 	p.EnterOuterAlt(localctx, 1)
 	{
 		p.SetState(1677)
@@ -22833,14 +22957,23 @@ func (p *CqlParser) CompositeKey() (localctx ICompositeKeyContext) {
 		p.SetState(1679)
 		p.SyntaxBracketRr()
 	}
-
-	{
-		p.SetState(1680)
-		p.SyntaxComma()
+	p.SetState(3390)
+	p.GetErrorHandler().Sync(p)
+	if p.HasError() {
+		goto errorExit
 	}
-	{
-		p.SetState(1681)
-		p.ClusteringKeyList()
+	_la = p.GetTokenStream().LA(1)
+
+	if _la == CqlParserCOMMA {
+		{
+			p.SetState(1680)
+			p.SyntaxComma()
+		}
+		{
+			p.SetState(1681)
+			p.ClusteringKeyList()
+		}
+
 	}
 
 errorExit:
@@ -29158,6 +29291,8 @@ type IDeleteColumnItemContext interface {
 	RS_BRACKET() antlr.TerminalNode
 	StringLiteral() IStringLiteralContext
 	DecimalLiteral() IDecimalLiteralContext
+	QMARK() antlr.TerminalNode
+	NamedMarker() INamedMarkerContext
 
 	// IsDeleteColumnItemContext differentiates from other interfaces.
 	IsDeleteColumnItemContext()
@@ -29239,6 +29374,26 @@ func (s *DeleteColumnItemContext) DecimalLiteral() IDecimalLiteralContext {
 	return t.(IDecimalLiteralContext)
 }
 
+func (s *DeleteColumnItemContext) QMARK() antlr.TerminalNode {
+	return s.GetToken(CqlParserQMARK, 0)
+}
+
+func (s *DeleteColumnItemContext) NamedMarker() INamedMarkerContext {
+	var t antlr.RuleContext
+	for _, ctx := range s.GetChildren() {
+		if _, ok := ctx.(INamedMarkerContext); ok {
+			t = ctx.(antlr.RuleContext)
+			break
+		}
+	}
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(INamedMarkerContext)
+}
+
 func (s *DeleteColumnItemContext) GetRuleContext() antlr.RuleContext {
 	return s
 }
@@ -29317,6 +29472,22 @@ func (p *CqlParser) DeleteColumnItem() (localctx IDeleteColumnItemContext) {
 				p.DecimalLiteral()
 			}
 
+		case CqlParserQMARK:
+			{
+				p.SetState(3396)
+				p.Match(CqlParserQMARK)
+				if p.HasError() {
+					// Recognition error - abort rule
+					goto errorExit
+				}
+			}
+
+		case CqlParserCOLON:
+			{
+				p.SetState(3397)
+				p.NamedMarker()
+			}
+
 		default:
 			p.SetError(antlr.NewNoViableAltException(p, nil, nil, nil, nil, nil))
 			goto errorExit
@@ -31239,6 +31410,8 @@ type IAssignmentElementContext interface {
 	SyntaxBracketLs() ISyntaxBracketLsContext
 	AssignmentIndexKey() IAssignmentIndexKeyContext
 	SyntaxBracketRs() ISyntaxBracketRsContext
+	DOT() antlr.TerminalNode
+	OBJECT_NAME() antlr.TerminalNode
 
 	// IsAssignmentElementContext differentiates from other interfaces.
 	IsAssignmentElementContext()
@@ -31473,6 +31646,14 @@ func (s *AssignmentElementContext) SyntaxBracketRs() ISyntaxBracketRsContext {
 	return t.(ISyntaxBracketRsContext)
 }
 
+func (s *AssignmentElementContext) DOT() antlr.TerminalNode {
+	return s.GetToken(CqlParserDOT, 0)
+}
+
+func (s *AssignmentElementContext) OBJECT_NAME() antlr.TerminalNode {
+	return s.GetToken(CqlParserOBJECT_NAME, 0)
+}
+
 func (s *AssignmentElementContext) GetRuleContext() antlr.RuleContext {
 	return s
 }
@@ -31829,6 +32010,71 @@ func (p *CqlParser) AssignmentElement() (localctx IAssignmentElementContext) {
 			p.Constant()
 		}
 
+	case 10:
+		p.EnterOuterAlt(localctx, 10)
+		{
+			p.SetState(3380)
+			p.ColumnRef()
+		}
+		{
+			p.SetState(3381)
+			p.Match(CqlParserDOT)
+			if p.HasError() {
+				// Recognition error - abort rule
+				goto errorExit
+			}
+		}
+		{
+			p.SetState(3382)
+			p.Match(CqlParserOBJECT_NAME)
+			if p.HasError() {
+				// Recognition error - abort rule
+				goto errorExit
+			}
+		}
+		{
+			p.SetState(3383)
+			p.Match(CqlParserOPERATOR_EQ)
+			if p.HasError() {
+				// Recognition error - abort rule
+				goto errorExit
+			}
+		}
+		p.SetState(3388)
+		p.GetErrorHandler().Sync(p)
+		if p.HasError() {
+			goto errorExit
+		}
+
+		switch p.GetInterpreter().AdaptivePredict(p.BaseParser, p.GetTokenStream(), 275, p.GetParserRuleContext()) {
+		case 1:
+			{
+				p.SetState(3384)
+				p.Constant()
+			}
+
+		case 2:
+			{
+				p.SetState(3385)
+				p.AssignmentMap()
+			}
+
+		case 3:
+			{
+				p.SetState(3386)
+				p.AssignmentSet()
+			}
+
+		case 4:
+			{
+				p.SetState(3387)
+				p.AssignmentList()
+			}
+
+		case antlr.ATNInvalidAltNumber:
+			goto errorExit
+		}
+
 	case antlr.ATNInvalidAltNumber:
 		goto errorExit
 	}
@@ -31858,6 +32104,8 @@ type IAssignmentIndexKeyContext interface {
 	StringLiteral() IStringLiteralContext
 	BooleanLiteral() IBooleanLiteralContext
 	KwNull() IKwNullContext
+	QMARK() antlr.TerminalNode
+	NamedMarker() INamedMarkerContext
 
 	// IsAssignmentIndexKeyContext differentiates from other interfaces.
 	IsAssignmentIndexKeyContext()
@@ -31959,6 +32207,26 @@ func (s *AssignmentIndexKeyContext) KwNull() IKwNullContext {
 	return t.(IKwNullContext)
 }
 
+func (s *AssignmentIndexKeyContext) QMARK() antlr.TerminalNode {
+	return s.GetToken(CqlParserQMARK, 0)
+}
+
+func (s *AssignmentIndexKeyContext) NamedMarker() INamedMarkerContext {
+	var t antlr.RuleContext
+	for _, ctx := range s.GetChildren() {
+		if _, ok := ctx.(INamedMarkerContext); ok {
+			t = ctx.(antlr.RuleContext)
+			break
+		}
+	}
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(INamedMarkerContext)
+}
+
 func (s *AssignmentIndexKeyContext) GetRuleContext() antlr.RuleContext {
 	return s
 }
@@ -32017,6 +32285,24 @@ func (p *CqlParser) AssignmentIndexKey() (localctx IAssignmentIndexKeyContext) {
 			p.KwNull()
 		}
 
+	case CqlParserQMARK:
+		p.EnterOuterAlt(localctx, 5)
+		{
+			p.SetState(3398)
+			p.Match(CqlParserQMARK)
+			if p.HasError() {
+				// Recognition error - abort rule
+				goto errorExit
+			}
+		}
+
+	case CqlParserCOLON:
+		p.EnterOuterAlt(localctx, 6)
+		{
+			p.SetState(3399)
+			p.NamedMarker()
+		}
+
 	default:
 		p.SetError(antlr.NewNoViableAltException(p, nil, nil, nil, nil, nil))
 		goto errorExit
@@ -32653,7 +32939,7 @@ func (p *CqlParser) AssignmentMap() (localctx IAssignmentMapContext) {
 	}
 	_la = p.GetTokenStream().LA(1)
 
-	if ((int64(_la) & ^0x3f) == 0 && ((int64(1)<<_la)&72057594037928488) != 0) || _la == CqlParserK_NULL || _la == CqlParserK_TRUE || ((int64((_la-196)) & ^0x3f) == 0 && ((int64(1)<<(_la-196))&11001) != 0) {
+	if ((int64(_la) & ^0x3f) == 0 && ((int64(1)<<_la)&72057594037928488) != 0) || _la == CqlParserK_NULL || _la == CqlParserK_TRUE || ((int64((_la-196)) & ^0x3f) == 0 && ((int64(1)<<(_la-196))&15097) != 0) {
 		{
 			p.SetState(2167)
 			p.AssignmentMapEntry()
@@ -32859,6 +33145,7 @@ type IAssignmentMapKeyContext interface {
 	Constant() IConstantContext
 	AssignmentList() IAssignmentListContext
 	AssignmentSet() IAssignmentSetContext
+	OBJECT_NAME() antlr.TerminalNode
 
 	// IsAssignmentMapKeyContext differentiates from other interfaces.
 	IsAssignmentMapKeyContext()
@@ -32944,6 +33231,10 @@ func (s *AssignmentMapKeyContext) AssignmentSet() IAssignmentSetContext {
 	return t.(IAssignmentSetContext)
 }
 
+func (s *AssignmentMapKeyContext) OBJECT_NAME() antlr.TerminalNode {
+	return s.GetToken(CqlParserOBJECT_NAME, 0)
+}
+
 func (s *AssignmentMapKeyContext) GetRuleContext() antlr.RuleContext {
 	return s
 }
@@ -32995,6 +33286,17 @@ func (p *CqlParser) AssignmentMapKey() (localctx IAssignmentMapKeyContext) {
 			p.AssignmentSet()
 		}
 
+	case CqlParserOBJECT_NAME:
+		p.EnterOuterAlt(localctx, 4)
+		{
+			p.SetState(3372)
+			p.Match(CqlParserOBJECT_NAME)
+			if p.HasError() {
+				// Recognition error - abort rule
+				goto errorExit
+			}
+		}
+
 	default:
 		p.SetError(antlr.NewNoViableAltException(p, nil, nil, nil, nil, nil))
 		goto errorExit
@@ -34589,6 +34891,8 @@ type ITimestampContext interface {
 	// Getter signatures
 	KwTimestamp() IKwTimestampContext
 	DecimalLiteral() IDecimalLiteralContext
+	QMARK() antlr.TerminalNode
+	NamedMarker() INamedMarkerContext
 
 	// IsTimestampContext differentiates from other interfaces.
 	IsTimestampContext()
@@ -34658,6 +34962,26 @@ func (s *TimestampContext) DecimalLiteral() IDecimalLiteralContext {
 	return t.(IDecimalLiteralContext)
 }
 
+func (s *TimestampContext) QMARK() antlr.TerminalNode {
+	return s.GetToken(CqlParserQMARK, 0)
+}
+
+func (s *TimestampContext) NamedMarker() INamedMarkerContext {
+	var t antlr.RuleContext
+	for _, ctx := range s.GetChildren() {
+		if _, ok := ctx.(INamedMarkerContext); ok {
+			t = ctx.(antlr.RuleContext)
+			break
+		}
+	}
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(INamedMarkerContext)
+}
+
 func (s *TimestampContext) GetRuleContext() antlr.RuleContext {
 	return s
 }
@@ -34686,9 +35010,38 @@ func (p *CqlParser) Timestamp() (localctx ITimestampContext) {
 		p.SetState(2294)
 		p.KwTimestamp()
 	}
-	{
-		p.SetState(2295)
-		p.DecimalLiteral()
+	p.SetState(3356)
+	p.GetErrorHandler().Sync(p)
+	if p.HasError() {
+		goto errorExit
+	}
+
+	switch p.GetTokenStream().LA(1) {
+	case CqlParserDECIMAL_LITERAL:
+		{
+			p.SetState(2295)
+			p.DecimalLiteral()
+		}
+
+	case CqlParserQMARK:
+		{
+			p.SetState(3354)
+			p.Match(CqlParserQMARK)
+			if p.HasError() {
+				// Recognition error - abort rule
+				goto errorExit
+			}
+		}
+
+	case CqlParserCOLON:
+		{
+			p.SetState(3355)
+			p.NamedMarker()
+		}
+
+	default:
+		p.SetError(antlr.NewNoViableAltException(p, nil, nil, nil, nil, nil))
+		goto errorExit
 	}
 
 errorExit:
@@ -34714,6 +35067,8 @@ type ITtlContext interface {
 	// Getter signatures
 	KwTtl() IKwTtlContext
 	DecimalLiteral() IDecimalLiteralContext
+	QMARK() antlr.TerminalNode
+	NamedMarker() INamedMarkerContext
 
 	// IsTtlContext differentiates from other interfaces.
 	IsTtlContext()
@@ -34783,6 +35138,26 @@ func (s *TtlContext) DecimalLiteral() IDecimalLiteralContext {
 	return t.(IDecimalLiteralContext)
 }
 
+func (s *TtlContext) QMARK() antlr.TerminalNode {
+	return s.GetToken(CqlParserQMARK, 0)
+}
+
+func (s *TtlContext) NamedMarker() INamedMarkerContext {
+	var t antlr.RuleContext
+	for _, ctx := range s.GetChildren() {
+		if _, ok := ctx.(INamedMarkerContext); ok {
+			t = ctx.(antlr.RuleContext)
+			break
+		}
+	}
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(INamedMarkerContext)
+}
+
 func (s *TtlContext) GetRuleContext() antlr.RuleContext {
 	return s
 }
@@ -34811,9 +35186,38 @@ func (p *CqlParser) Ttl() (localctx ITtlContext) {
 		p.SetState(2297)
 		p.KwTtl()
 	}
-	{
-		p.SetState(2298)
-		p.DecimalLiteral()
+	p.SetState(3352)
+	p.GetErrorHandler().Sync(p)
+	if p.HasError() {
+		goto errorExit
+	}
+
+	switch p.GetTokenStream().LA(1) {
+	case CqlParserDECIMAL_LITERAL:
+		{
+			p.SetState(2298)
+			p.DecimalLiteral()
+		}
+
+	case CqlParserQMARK:
+		{
+			p.SetState(3350)
+			p.Match(CqlParserQMARK)
+			if p.HasError() {
+				// Recognition error - abort rule
+				goto errorExit
+			}
+		}
+
+	case CqlParserCOLON:
+		{
+			p.SetState(3351)
+			p.NamedMarker()
+		}
+
+	default:
+		p.SetError(antlr.NewNoViableAltException(p, nil, nil, nil, nil, nil))
+		goto errorExit
 	}
 
 errorExit:
@@ -37386,6 +37790,8 @@ type IPerPartitionLimitSpecContext interface {
 	KwPartition() IKwPartitionContext
 	KwLimit() IKwLimitContext
 	DecimalLiteral() IDecimalLiteralContext
+	QMARK() antlr.TerminalNode
+	NamedMarker() INamedMarkerContext
 
 	// IsPerPartitionLimitSpecContext differentiates from other interfaces.
 	IsPerPartitionLimitSpecContext()
@@ -37487,6 +37893,26 @@ func (s *PerPartitionLimitSpecContext) DecimalLiteral() IDecimalLiteralContext {
 	return t.(IDecimalLiteralContext)
 }
 
+func (s *PerPartitionLimitSpecContext) QMARK() antlr.TerminalNode {
+	return s.GetToken(CqlParserQMARK, 0)
+}
+
+func (s *PerPartitionLimitSpecContext) NamedMarker() INamedMarkerContext {
+	var t antlr.RuleContext
+	for _, ctx := range s.GetChildren() {
+		if _, ok := ctx.(INamedMarkerContext); ok {
+			t = ctx.(antlr.RuleContext)
+			break
+		}
+	}
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(INamedMarkerContext)
+}
+
 func (s *PerPartitionLimitSpecContext) GetRuleContext() antlr.RuleContext {
 	return s
 }
@@ -37523,9 +37949,38 @@ func (p *CqlParser) PerPartitionLimitSpec() (localctx IPerPartitionLimitSpecCont
 		p.SetState(2423)
 		p.KwLimit()
 	}
-	{
-		p.SetState(2424)
-		p.DecimalLiteral()
+	p.SetState(3364)
+	p.GetErrorHandler().Sync(p)
+	if p.HasError() {
+		goto errorExit
+	}
+
+	switch p.GetTokenStream().LA(1) {
+	case CqlParserDECIMAL_LITERAL:
+		{
+			p.SetState(2424)
+			p.DecimalLiteral()
+		}
+
+	case CqlParserQMARK:
+		{
+			p.SetState(3362)
+			p.Match(CqlParserQMARK)
+			if p.HasError() {
+				// Recognition error - abort rule
+				goto errorExit
+			}
+		}
+
+	case CqlParserCOLON:
+		{
+			p.SetState(3363)
+			p.NamedMarker()
+		}
+
+	default:
+		p.SetError(antlr.NewNoViableAltException(p, nil, nil, nil, nil, nil))
+		goto errorExit
 	}
 
 errorExit:
@@ -41690,6 +42145,8 @@ type ILimitSpecContext interface {
 	// Getter signatures
 	KwLimit() IKwLimitContext
 	DecimalLiteral() IDecimalLiteralContext
+	QMARK() antlr.TerminalNode
+	NamedMarker() INamedMarkerContext
 
 	// IsLimitSpecContext differentiates from other interfaces.
 	IsLimitSpecContext()
@@ -41759,6 +42216,26 @@ func (s *LimitSpecContext) DecimalLiteral() IDecimalLiteralContext {
 	return t.(IDecimalLiteralContext)
 }
 
+func (s *LimitSpecContext) QMARK() antlr.TerminalNode {
+	return s.GetToken(CqlParserQMARK, 0)
+}
+
+func (s *LimitSpecContext) NamedMarker() INamedMarkerContext {
+	var t antlr.RuleContext
+	for _, ctx := range s.GetChildren() {
+		if _, ok := ctx.(INamedMarkerContext); ok {
+			t = ctx.(antlr.RuleContext)
+			break
+		}
+	}
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(INamedMarkerContext)
+}
+
 func (s *LimitSpecContext) GetRuleContext() antlr.RuleContext {
 	return s
 }
@@ -41787,9 +42264,38 @@ func (p *CqlParser) LimitSpec() (localctx ILimitSpecContext) {
 		p.SetState(2650)
 		p.KwLimit()
 	}
-	{
-		p.SetState(2651)
-		p.DecimalLiteral()
+	p.SetState(3360)
+	p.GetErrorHandler().Sync(p)
+	if p.HasError() {
+		goto errorExit
+	}
+
+	switch p.GetTokenStream().LA(1) {
+	case CqlParserDECIMAL_LITERAL:
+		{
+			p.SetState(2651)
+			p.DecimalLiteral()
+		}
+
+	case CqlParserQMARK:
+		{
+			p.SetState(3358)
+			p.Match(CqlParserQMARK)
+			if p.HasError() {
+				// Recognition error - abort rule
+				goto errorExit
+			}
+		}
+
+	case CqlParserCOLON:
+		{
+			p.SetState(3359)
+			p.NamedMarker()
+		}
+
+	default:
+		p.SetError(antlr.NewNoViableAltException(p, nil, nil, nil, nil, nil))
+		goto errorExit
 	}
 
 errorExit:
@@ -43351,7 +43857,8 @@ type ISelectElementContext interface {
 	GetParser() antlr.Parser
 
 	// Getter signatures
-	OBJECT_NAME() antlr.TerminalNode
+	AllOBJECT_NAME() []antlr.TerminalNode
+	OBJECT_NAME(i int) antlr.TerminalNode
 	DOT() antlr.TerminalNode
 	STAR() antlr.TerminalNode
 	ColumnRef() IColumnRefContext
@@ -43396,8 +43903,12 @@ func NewSelectElementContext(parser antlr.Parser, parent antlr.ParserRuleContext
 
 func (s *SelectElementContext) GetParser() antlr.Parser { return s.parser }
 
-func (s *SelectElementContext) OBJECT_NAME() antlr.TerminalNode {
-	return s.GetToken(CqlParserOBJECT_NAME, 0)
+func (s *SelectElementContext) AllOBJECT_NAME() []antlr.TerminalNode {
+	return s.GetTokens(CqlParserOBJECT_NAME)
+}
+
+func (s *SelectElementContext) OBJECT_NAME(i int) antlr.TerminalNode {
+	return s.GetToken(CqlParserOBJECT_NAME, i)
 }
 
 func (s *SelectElementContext) DOT() antlr.TerminalNode {
@@ -43663,6 +44174,51 @@ func (p *CqlParser) SelectElement() (localctx ISelectElementContext) {
 
 		}
 
+	case 6:
+		p.EnterOuterAlt(localctx, 6)
+		{
+			p.SetState(3373)
+			p.ColumnRef()
+		}
+		{
+			p.SetState(3374)
+			p.Match(CqlParserDOT)
+			if p.HasError() {
+				// Recognition error - abort rule
+				goto errorExit
+			}
+		}
+		{
+			p.SetState(3375)
+			p.Match(CqlParserOBJECT_NAME)
+			if p.HasError() {
+				// Recognition error - abort rule
+				goto errorExit
+			}
+		}
+		p.SetState(3378)
+		p.GetErrorHandler().Sync(p)
+		if p.HasError() {
+			goto errorExit
+		}
+		_la = p.GetTokenStream().LA(1)
+
+		if _la == CqlParserK_AS {
+			{
+				p.SetState(3376)
+				p.KwAs()
+			}
+			{
+				p.SetState(3377)
+				p.Match(CqlParserOBJECT_NAME)
+				if p.HasError() {
+					// Recognition error - abort rule
+					goto errorExit
+				}
+			}
+
+		}
+
 	case antlr.ATNInvalidAltNumber:
 		goto errorExit
 	}
@@ -43914,6 +44470,8 @@ type IRelationElementContext interface {
 	RelalationContainsKey() IRelalationContainsKeyContext
 	RelalationContains() IRelalationContainsContext
 	KwLike() IKwLikeContext
+	QMARK() antlr.TerminalNode
+	NamedMarker() INamedMarkerContext
 
 	// IsRelationElementContext differentiates from other interfaces.
 	IsRelationElementContext()
@@ -44271,6 +44829,26 @@ func (s *RelationElementContext) KwLike() IKwLikeContext {
 	return t.(IKwLikeContext)
 }
 
+func (s *RelationElementContext) QMARK() antlr.TerminalNode {
+	return s.GetToken(CqlParserQMARK, 0)
+}
+
+func (s *RelationElementContext) NamedMarker() INamedMarkerContext {
+	var t antlr.RuleContext
+	for _, ctx := range s.GetChildren() {
+		if _, ok := ctx.(INamedMarkerContext); ok {
+			t = ctx.(antlr.RuleContext)
+			break
+		}
+	}
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(INamedMarkerContext)
+}
+
 func (s *RelationElementContext) GetRuleContext() antlr.RuleContext {
 	return s
 }
@@ -44520,49 +45098,78 @@ func (p *CqlParser) RelationElement() (localctx IRelationElementContext) {
 			p.SetState(2799)
 			p.KwIn()
 		}
-		{
-			p.SetState(2800)
-			p.Match(CqlParserLR_BRACKET)
-			if p.HasError() {
-				// Recognition error - abort rule
-				goto errorExit
-			}
-		}
-		{
-			p.SetState(2801)
-			p.AssignmentTuple()
-		}
-		p.SetState(2807)
+		p.SetState(3402)
 		p.GetErrorHandler().Sync(p)
 		if p.HasError() {
 			goto errorExit
 		}
-		_la = p.GetTokenStream().LA(1)
 
-		for _la == CqlParserCOMMA {
+		switch p.GetTokenStream().LA(1) {
+		case CqlParserLR_BRACKET:
 			{
-				p.SetState(2802)
-				p.SyntaxComma()
+				p.SetState(2800)
+				p.Match(CqlParserLR_BRACKET)
+				if p.HasError() {
+					// Recognition error - abort rule
+					goto errorExit
+				}
 			}
 			{
-				p.SetState(2803)
+				p.SetState(2801)
 				p.AssignmentTuple()
 			}
-
-			p.SetState(2809)
+			p.SetState(2807)
 			p.GetErrorHandler().Sync(p)
 			if p.HasError() {
 				goto errorExit
 			}
 			_la = p.GetTokenStream().LA(1)
-		}
-		{
-			p.SetState(2810)
-			p.Match(CqlParserRR_BRACKET)
-			if p.HasError() {
-				// Recognition error - abort rule
-				goto errorExit
+
+			for _la == CqlParserCOMMA {
+				{
+					p.SetState(2802)
+					p.SyntaxComma()
+				}
+				{
+					p.SetState(2803)
+					p.AssignmentTuple()
+				}
+
+				p.SetState(2809)
+				p.GetErrorHandler().Sync(p)
+				if p.HasError() {
+					goto errorExit
+				}
+				_la = p.GetTokenStream().LA(1)
+			}
+			{
+				p.SetState(2810)
+				p.Match(CqlParserRR_BRACKET)
+				if p.HasError() {
+					// Recognition error - abort rule
+					goto errorExit
+				}
+			}
+
+		case CqlParserQMARK:
+			{
+				p.SetState(3400)
+				p.Match(CqlParserQMARK)
+				if p.HasError() {
+					// Recognition error - abort rule
+					goto errorExit
+				}
+			}
+
+		case CqlParserCOLON:
+			{
+				p.SetState(3401)
+				p.NamedMarker()
 			}
+
+		default:
+			p.SetError(antlr.NewNoViableAltException(p, nil, nil, nil, nil, nil))
+			goto errorExit
 		}
 
 	case 8:
@@ -44781,6 +45388,44 @@ func (p *CqlParser) RelationElement() (localctx IRelationElementContext) {
 			p.Constant()
 		}
 
+	case 14:
+		p.EnterOuterAlt(localctx, 14)
+		{
+			p.SetState(3366)
+			p.ColumnRef()
+		}
+		{
+			p.SetState(3367)
+			p.KwIn()
+		}
+		p.SetState(3370)
+		p.GetErrorHandler().Sync(p)
+		if p.HasError() {
+			goto errorExit
+		}
+
+		switch p.GetTokenStream().LA(1) {
+		case CqlParserQMARK:
+			{
+				p.SetState(3368)
+				p.Match(CqlParserQMARK)
+				if p.HasError() {
+					// Recognition error - abort rule
+					goto errorExit
+				}
+			}
+
+		case CqlParserCOLON:
+			{
+				p.SetState(3369)
+				p.NamedMarker()
+			}
+
+		default:
+			p.SetError(antlr.NewNoViableAltException(p, nil, nil, nil, nil, nil))
+			goto errorExit
+		}
+
 	case antlr.ATNInvalidAltNumber:
 		goto errorExit
 	}
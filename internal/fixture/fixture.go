@@ -0,0 +1,27 @@
+// Package fixture holds the parts of the YAML test fixture schemas shared by
+// the analyze, complete and hover tests.
+package fixture
+
+import "github.com/tentacle-scylla/scql/pkg/schema"
+
+// Type represents a user-defined type in a fixture schema
+type Type struct {
+	Name   string  `yaml:"name"`
+	Fields []Field `yaml:"fields"`
+}
+
+// Field represents a field of a user-defined type in a fixture schema
+type Field struct {
+	Name string `yaml:"name"`
+	Type string `yaml:"type"`
+}
+
+// AddTypes adds the user-defined types of a fixture keyspace to ks.
+func AddTypes(ks *schema.Keyspace, types []Type) {
+	for _, t := range types {
+		udt := ks.AddType(t.Name)
+		for _, f := range t.Fields {
+			udt.AddField(f.Name, f.Type)
+		}
+	}
+}
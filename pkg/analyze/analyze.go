@@ -37,59 +37,103 @@ func Analyze(cql string, opts *AnalyzeOptions) *Result {
 		refs.Keyspace = opts.DefaultKeyspace
 	}
 
-	// Schema validation (only if schema is provided)
-	if opts.Schema != nil && refs.Table != "" {
-		validateSchema(result, opts)
-	}
+	// Each statement of a BATCH is validated against its own table
+	if len(refs.Targets) > 0 && result.Type != types.StatementDescribe {
+		result.Type = types.StatementBatch
+		tables := make([]*schema.Table, len(refs.Targets))
+		for i, target := range refs.Targets {
+			if target.Keyspace == "" {
+				target.Keyspace = opts.DefaultKeyspace
+			}
+			stmt := &Result{
+				Query:        target.Text,
+				Type:         target.Type,
+				IsValid:      true,
+				References:   target.References,
+				SchemaErrors: make([]*SchemaError, 0),
+				Warnings:     make([]*Warning, 0),
+			}
+			sc := validateStatement(stmt, opts)
+			tables[i] = sc.table
+			bindMarkers(target.References, sc)
+			stmt.BindMarkers = target.markers
+			result.Statements = append(result.Statements, stmt)
+		}
+		result.BindMarkers = refs.markers
+		validateCounterBatch(refs.batchType, result.Statements, tables)
 
-	// Function validation (always, doesn't require schema)
-	if len(refs.FunctionCalls) > 0 {
-		funcErrors := ValidateFunctionCalls(refs.FunctionCalls)
-		result.SchemaErrors = append(result.SchemaErrors, funcErrors...)
+		for i, stmt := range result.Statements {
+			target := refs.Targets[i]
+
+			// Findings without a position are reported at the statement
+			for _, e := range stmt.SchemaErrors {
+				if e.Position == nil {
+					e.Position = target.Position
+				}
+			}
+			for _, w := range stmt.Warnings {
+				if w.Position == nil {
+					w.Position = target.Position
+				}
+			}
+			result.SchemaErrors = append(result.SchemaErrors, stmt.SchemaErrors...)
+			result.Warnings = append(result.Warnings, stmt.Warnings...)
+		}
+		validateLightweightTransaction(result, opts)
+		return result
 	}
 
+	sc := validateStatement(result, opts)
+	validateLightweightTransaction(result, opts)
+	bindMarkers(refs, sc)
+	result.BindMarkers = refs.markers
+
 	// Generate warnings
-	generateWarnings(result, opts)
+	generateWarnings(result, opts, sc)
 
 	return result
 }
 
-// validateSchema validates the query references against the schema.
-func validateSchema(result *Result, opts *AnalyzeOptions) {
+// validateStatement validates the references of a statement against the
+// schema and the builtin functions. It returns the scope of the statement.
+func validateStatement(result *Result, opts *AnalyzeOptions) *scope {
 	refs := result.References
-	s := opts.Schema
 
-	// Find the keyspace
-	var ks *schema.Keyspace
-	if refs.Keyspace != "" {
-		ks = s.GetKeyspace(refs.Keyspace)
-		if ks == nil {
-			result.SchemaErrors = append(result.SchemaErrors, &SchemaError{
-				Type:       ErrUnknownKeyspace,
-				Message:    fmt.Sprintf("Keyspace '%s' does not exist", refs.Keyspace),
-				Suggestion: suggestKeyspace(s, refs.Keyspace),
-				Object:     refs.Keyspace,
-			})
-			return
-		}
-	} else if opts.DefaultKeyspace != "" {
-		ks = s.GetKeyspace(opts.DefaultKeyspace)
-	}
+	// Schema validation (only if schema is provided)
+	sc := &scope{schema: opts.Schema}
+	switch {
+	case refs.definition != nil:
+		// The table of CREATE TABLE is checked on its own
+		validateTableDefinition(result, opts)
+	case refs.view != nil:
+		// The view of CREATE MATERIALIZED VIEW is checked against its base
+		validateViewDefinition(result, opts, sc)
+	case refs.alteration != nil:
+		validateTableAlteration(result, opts, sc)
+	case refs.drop != nil:
+		validateDrop(result, opts)
+	case opts.Schema != nil && refs.Table != "":
+		validateSchema(result, opts, sc)
+	}
+
+	// USING TTL values (always, doesn't require schema)
+	validateTTL(result)
 
-	if ks == nil {
-		// No keyspace context - can't validate further
-		return
+	// Function validation (always, doesn't require schema)
+	if len(refs.FunctionCalls) > 0 {
+		funcErrors := ValidateFunctionCalls(refs.FunctionCalls)
+		result.SchemaErrors = append(result.SchemaErrors, funcErrors...)
+		validateFunctionTypes(result, sc)
 	}
+	return sc
+}
 
-	// Find the table
-	tbl := ks.GetTable(refs.Table)
+// validateSchema validates the query references against the schema, and
+// records the keyspace and table it finds in sc.
+func validateSchema(result *Result, opts *AnalyzeOptions, sc *scope) {
+	refs := result.References
+	tbl := resolveTable(result, opts, sc)
 	if tbl == nil {
-		result.SchemaErrors = append(result.SchemaErrors, &SchemaError{
-			Type:       ErrUnknownTable,
-			Message:    fmt.Sprintf("Table '%s' does not exist in keyspace '%s'", refs.Table, ks.Name),
-			Suggestion: suggestTable(ks, refs.Table),
-			Object:     refs.Table,
-		})
 		return
 	}
 
@@ -109,79 +153,73 @@ func validateSchema(result *Result, opts *AnalyzeOptions) {
 		}
 	}
 
-	// Validate partition key usage for DML queries
-	if result.Type.IsDML() && result.Type != types.StatementInsert {
-		validatePartitionKey(result, tbl)
-	}
-}
+	// Validate the fields of UDT columns and the types of values compared to
+	// or assigned to columns
+	validateFields(result, sc)
+	validateValues(result, sc)
 
-// validatePartitionKey checks if the WHERE clause contains all partition key columns.
-func validatePartitionKey(result *Result, tbl *schema.Table) {
-	refs := result.References
+	// Validate the WHERE clause restrictions and IF conditions
+	validateRestrictions(result, tbl)
+	validateConditions(result, tbl)
 
-	// For SELECT/UPDATE/DELETE, partition key should be in WHERE clause
-	whereColSet := make(map[string]bool)
-	for _, col := range refs.WhereColumns {
-		whereColSet[strings.ToLower(col)] = true
-	}
+	// Validate the statements of counter tables, collection operations, the
+	// use of static columns and writetime() and ttl() selections
+	validateCounters(result, tbl)
+	validateCollections(result, tbl)
+	validateStatics(result, tbl)
+	validateSelectionFunctions(result, sc)
+}
 
-	// Check partition key columns
-	missingPK := make([]string, 0)
-	for _, pkCol := range tbl.PartitionKey {
-		if !whereColSet[strings.ToLower(pkCol)] {
-			missingPK = append(missingPK, pkCol)
-		}
-	}
+// resolveTable finds the keyspace and table of the references in the schema
+// and records them in sc. It reports an unknown keyspace or table, and
+// returns nil if the table cannot be found.
+func resolveTable(result *Result, opts *AnalyzeOptions, sc *scope) *schema.Table {
+	refs := result.References
+	s := opts.Schema
 
-	if len(missingPK) > 0 {
-		// Missing partition key columns
-		if len(missingPK) == len(tbl.PartitionKey) {
-			result.Warnings = append(result.Warnings, &Warning{
-				Type:       WarnMissingPartitionKey,
-				Severity:   SeverityError,
-				Message:    fmt.Sprintf("Query is missing partition key column(s): %s", strings.Join(missingPK, ", ")),
-				Suggestion: "Add partition key columns to WHERE clause or use ALLOW FILTERING",
-			})
-		} else {
-			result.Warnings = append(result.Warnings, &Warning{
-				Type:       WarnMissingPartitionKey,
-				Severity:   SeverityError,
-				Message:    fmt.Sprintf("Query is missing partition key column(s): %s", strings.Join(missingPK, ", ")),
-				Suggestion: "All partition key columns must be specified",
+	// Find the keyspace
+	var ks *schema.Keyspace
+	if refs.Keyspace != "" {
+		ks = s.GetKeyspace(refs.Keyspace)
+		if ks == nil {
+			result.SchemaErrors = append(result.SchemaErrors, &SchemaError{
+				Type:       ErrUnknownKeyspace,
+				Message:    fmt.Sprintf("Keyspace '%s' does not exist", refs.Keyspace),
+				Suggestion: suggestKeyspace(s, refs.Keyspace),
+				Object:     refs.Keyspace,
 			})
+			return nil
 		}
+	} else if opts.DefaultKeyspace != "" {
+		ks = s.GetKeyspace(opts.DefaultKeyspace)
 	}
 
-	// Check clustering key order
-	if len(tbl.ClusteringKey) > 0 && len(missingPK) == 0 {
-		// Partition key is complete, now check clustering key prefix
-		validateClusteringKeyOrder(result, tbl, whereColSet)
+	if ks == nil {
+		// No keyspace context - can't validate further
+		return nil
 	}
-}
+	sc.keyspace = ks
 
-// validateClusteringKeyOrder checks if clustering keys are used in proper prefix order.
-func validateClusteringKeyOrder(result *Result, tbl *schema.Table, whereColSet map[string]bool) {
-	// Clustering columns must be restricted in order
-	// You can't skip a clustering column and restrict a later one
-	foundGap := false
-	for _, ckCol := range tbl.ClusteringKey {
-		hasCol := whereColSet[strings.ToLower(ckCol)]
-		if !hasCol {
-			foundGap = true
-		} else if foundGap {
-			// Found a clustering column after a gap
-			result.Warnings = append(result.Warnings, &Warning{
-				Type:       WarnMissingClusteringKey,
-				Severity:   SeverityWarning,
-				Message:    fmt.Sprintf("Clustering column '%s' is used without preceding clustering columns", ckCol),
-				Suggestion: "Include preceding clustering columns in WHERE clause or use ALLOW FILTERING",
-			})
-		}
+	// Find the table
+	tbl := ks.GetTable(refs.Table)
+	if tbl == nil && result.Type == types.StatementDescribe && ks.GetMaterializedView(refs.Table) != nil {
+		return nil
 	}
+	if tbl == nil {
+		result.SchemaErrors = append(result.SchemaErrors, &SchemaError{
+			Type:       ErrUnknownTable,
+			Message:    fmt.Sprintf("Table '%s' does not exist in keyspace '%s'", refs.Table, ks.Name),
+			Suggestion: suggestTable(ks, refs.Table),
+			Object:     refs.Table,
+		})
+		return nil
+	}
+	sc.table = tbl
+	return tbl
 }
 
 // generateWarnings generates warnings based on query characteristics.
-func generateWarnings(result *Result, opts *AnalyzeOptions) {
+func generateWarnings(result *Result, opts *AnalyzeOptions, sc *scope) {
 	refs := result.References
 
 	// Only for SELECT queries
@@ -236,11 +274,17 @@ func generateWarnings(result *Result, opts *AnalyzeOptions) {
 
 	// Warn about ALLOW FILTERING presence
 	if refs.HasAllowFiltering {
+		suggestion := "Consider restructuring query to avoid ALLOW FILTERING"
+		if sc.table != nil {
+			if view := servingView(refs, sc.table); view != nil {
+				suggestion = viewSuggestion(view)
+			}
+		}
 		result.Warnings = append(result.Warnings, &Warning{
 			Type:       WarnAllowFilteringPresent,
 			Severity:   SeverityWarning,
 			Message:    "Query uses ALLOW FILTERING which may be slow",
-			Suggestion: "Consider restructuring query to avoid ALLOW FILTERING",
+			Suggestion: suggestion,
 		})
 	}
 }
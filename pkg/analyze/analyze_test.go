@@ -1,18 +1,36 @@
 package analyze
 
 import (
+	"fmt"
 	"os"
 	"strings"
 	"testing"
 
+	"github.com/tentacle-scylla/scql/internal/fixture"
 	"github.com/tentacle-scylla/scql/pkg/schema"
 	"gopkg.in/yaml.v3"
 )
 
 // FixtureColumn represents a column in the fixture schema
 type FixtureColumn struct {
-	Name string `yaml:"name"`
-	Type string `yaml:"type"`
+	Name   string `yaml:"name"`
+	Type   string `yaml:"type"`
+	Static bool   `yaml:"static"`
+}
+
+// FixtureIndex represents a secondary index in the fixture schema
+type FixtureIndex struct {
+	Name   string `yaml:"name"`
+	Column string `yaml:"column"`
+	Target string `yaml:"target"` // Defaults to the column
+}
+
+// FixtureView represents a materialized view of a fixture table
+type FixtureView struct {
+	Name          string   `yaml:"name"`
+	PartitionKey  []string `yaml:"partitionKey"`
+	ClusteringKey []string `yaml:"clusteringKey"`
+	Columns       []string `yaml:"columns"` // Defaults to all columns of the table
 }
 
 // FixtureTable represents a table in the fixture schema
@@ -21,12 +39,31 @@ type FixtureTable struct {
 	PartitionKey  []string        `yaml:"partitionKey"`
 	ClusteringKey []string        `yaml:"clusteringKey"`
 	Columns       []FixtureColumn `yaml:"columns"`
+	Indexes       []FixtureIndex  `yaml:"indexes"`
+	Views         []FixtureView   `yaml:"views"`
+}
+
+// FixtureFunction represents a user-defined function in the fixture schema
+type FixtureFunction struct {
+	Name       string          `yaml:"name"`
+	Parameters []FixtureColumn `yaml:"parameters"`
+	ReturnType string          `yaml:"returnType"`
+}
+
+// FixtureAggregate represents a user-defined aggregate in the fixture schema
+type FixtureAggregate struct {
+	Name       string   `yaml:"name"`
+	Parameters []string `yaml:"parameters"`
+	ReturnType string   `yaml:"returnType"`
 }
 
 // FixtureKeyspace represents a keyspace in the fixture schema
 type FixtureKeyspace struct {
-	Name   string         `yaml:"name"`
-	Tables []FixtureTable `yaml:"tables"`
+	Name       string             `yaml:"name"`
+	Types      []fixture.Type     `yaml:"types"`
+	Tables     []FixtureTable     `yaml:"tables"`
+	Functions  []FixtureFunction  `yaml:"functions"`
+	Aggregates []FixtureAggregate `yaml:"aggregates"`
 }
 
 // FixtureSchema represents the schema in a fixture
@@ -39,6 +76,7 @@ type FixtureOptions struct {
 	DefaultKeyspace     string `yaml:"defaultKeyspace"`
 	WarnOnSelectStar    bool   `yaml:"warnOnSelectStar"`
 	WarnOnNoLimit       bool   `yaml:"warnOnNoLimit"`
+	WarnOnLWT           bool   `yaml:"warnOnLightweightTransaction"`
 	LargeLimitThreshold int    `yaml:"largeLimitThreshold"`
 }
 
@@ -64,6 +102,19 @@ type AnalyzeFixture struct {
 	ExpectFunctions      []string `yaml:"expectFunctions,omitempty"`
 	ExpectLimit          *int     `yaml:"expectLimit,omitempty"`
 	ExpectHasAllowFilter bool     `yaml:"expectHasAllowFiltering,omitempty"`
+	ExpectConditions     []string `yaml:"expectConditions,omitempty"`
+
+	// Per-statement expectations (BATCH and DESCRIBE targets)
+	ExpectTargets              []string `yaml:"expectTargets,omitempty"` // keyspace.table or table
+	ExpectTargetTexts          []string `yaml:"expectTargetTexts,omitempty"`
+	ExpectStatementErrorCounts []int    `yaml:"expectStatementErrorCounts,omitempty"`
+
+	// Bind marker expectations: "[:name ]role column type", - for empty
+	ExpectBindMarkers       []string `yaml:"expectBindMarkers,omitempty"`
+	ExpectBindMarkerOffsets []int    `yaml:"expectBindMarkerOffsets,omitempty"`
+
+	// View expectation: "keyspace.view ON table PRIMARY KEY ((pk), ck) [columns]"
+	ExpectView string `yaml:"expectView,omitempty"`
 
 	// Validation expectations
 	ExpectValid            *bool  `yaml:"expectValid,omitempty"`
@@ -72,6 +123,8 @@ type AnalyzeFixture struct {
 	ExpectSchemaErrorType  string `yaml:"expectSchemaErrorType,omitempty"`
 	ExpectSchemaErrorCont  string `yaml:"expectSchemaErrorContains,omitempty"`
 	ExpectSuggestionCont   string `yaml:"expectSuggestionContains,omitempty"`
+	ExpectNoSuggestion     bool   `yaml:"expectNoSuggestion,omitempty"`
+	ExpectSchemaErrorCol   *int   `yaml:"expectSchemaErrorColumn,omitempty"`
 
 	// Warning expectations
 	ExpectWarningCount    *int   `yaml:"expectWarningCount,omitempty"`
@@ -107,11 +160,16 @@ func buildSchema(fs *FixtureSchema) *schema.Schema {
 	s := schema.NewSchema()
 	for _, fks := range fs.Keyspaces {
 		ks := s.AddKeyspace(fks.Name)
+		fixture.AddTypes(ks, fks.Types)
 		for _, ftbl := range fks.Tables {
 			tbl := ks.AddTable(ftbl.Name)
 
 			// Add columns
 			for _, fcol := range ftbl.Columns {
+				if fcol.Static {
+					tbl.AddStaticColumn(fcol.Name, fcol.Type)
+					continue
+				}
 				tbl.AddColumn(fcol.Name, fcol.Type)
 			}
 
@@ -124,6 +182,44 @@ func buildSchema(fs *FixtureSchema) *schema.Schema {
 			if len(ftbl.ClusteringKey) > 0 {
 				tbl.SetClusteringKey(ftbl.ClusteringKey...)
 			}
+
+			for _, fidx := range ftbl.Indexes {
+				idx := tbl.AddIndex(fidx.Name, fidx.Column).WithKind("COMPOSITES")
+				idx.Options["target"] = fidx.Column
+				if fidx.Target != "" {
+					idx.Options["target"] = fidx.Target
+				}
+			}
+
+			for _, fmv := range ftbl.Views {
+				mv := tbl.AddMaterializedView(fmv.Name)
+				columns := fmv.Columns
+				if len(columns) == 0 {
+					columns = tbl.ColumnOrder
+				}
+				for _, name := range columns {
+					mv.AddColumn(name, tbl.GetColumn(name).Type)
+				}
+				mv.SetPartitionKey(fmv.PartitionKey...)
+				mv.SetClusteringKey(fmv.ClusteringKey...)
+			}
+		}
+		for _, ffn := range fks.Functions {
+			fn := ks.AddFunction(ffn.Name).WithReturnType(ffn.ReturnType)
+			for _, p := range ffn.Parameters {
+				fn.AddParameter(p.Name, p.Type)
+			}
+		}
+		for _, fagg := range fks.Aggregates {
+			if ks.Aggregates == nil {
+				ks.Aggregates = make(map[string]*schema.Aggregate)
+			}
+			ks.Aggregates[fagg.Name] = &schema.Aggregate{
+				Name:       fagg.Name,
+				Keyspace:   ks.Name,
+				Parameters: fagg.Parameters,
+				ReturnType: fagg.ReturnType,
+			}
 		}
 	}
 	return s
@@ -137,6 +233,7 @@ func buildOptions(fo *FixtureOptions, s *schema.Schema) *AnalyzeOptions {
 		opts.DefaultKeyspace = fo.DefaultKeyspace
 		opts.WarnOnSelectStar = fo.WarnOnSelectStar
 		opts.WarnOnNoLimit = fo.WarnOnNoLimit
+		opts.WarnOnLightweightTransaction = fo.WarnOnLWT
 		opts.LargeLimitThreshold = fo.LargeLimitThreshold
 	}
 
@@ -246,6 +343,80 @@ func TestAnalyzeFixtures(t *testing.T) {
 				t.Error("Expected HasAllowFiltering = true")
 			}
 
+			if len(f.ExpectConditions) > 0 {
+				checkStringSlice(t, "Conditions", refs.Conditions, f.ExpectConditions)
+				if !refs.IsConditional {
+					t.Error("Expected IsConditional = true")
+				}
+			}
+
+			// Check targets
+			if len(f.ExpectTargets) > 0 {
+				var targets []string
+				for _, target := range refs.Targets {
+					name := target.Table
+					if target.Keyspace != "" {
+						name = target.Keyspace + "." + name
+					}
+					targets = append(targets, name)
+
+					// The span of the target is its text
+					if got := f.Query[target.Position.Offset:target.End.Offset]; got != target.Text {
+						t.Errorf("Target span = %q, want %q", got, target.Text)
+					}
+				}
+				checkStringSlice(t, "Targets", targets, f.ExpectTargets)
+			}
+
+			if len(f.ExpectTargetTexts) > 0 {
+				var texts []string
+				for _, target := range refs.Targets {
+					texts = append(texts, target.Text)
+				}
+				checkStringSlice(t, "TargetTexts", texts, f.ExpectTargetTexts)
+			}
+
+			if len(f.ExpectStatementErrorCounts) > 0 {
+				var counts []int
+				for _, stmt := range result.Statements {
+					counts = append(counts, len(stmt.SchemaErrors))
+				}
+				if fmt.Sprint(counts) != fmt.Sprint(f.ExpectStatementErrorCounts) {
+					t.Errorf("Statement error counts = %v, want %v", counts, f.ExpectStatementErrorCounts)
+				}
+			}
+
+			// Check bind markers
+			if f.ExpectBindMarkers != nil {
+				var markers []string
+				for i, m := range result.BindMarkers {
+					if m.Index != i {
+						t.Errorf("BindMarkers[%d].Index = %d", i, m.Index)
+					}
+					markers = append(markers, bindMarkerString(m))
+				}
+				if strings.Join(markers, "; ") != strings.Join(f.ExpectBindMarkers, "; ") {
+					t.Errorf("BindMarkers = %q, want %q", markers, f.ExpectBindMarkers)
+				}
+			}
+			if f.ExpectBindMarkerOffsets != nil {
+				var offsets []int
+				for _, m := range result.BindMarkers {
+					offsets = append(offsets, m.Position.Offset)
+				}
+				if fmt.Sprint(offsets) != fmt.Sprint(f.ExpectBindMarkerOffsets) {
+					t.Errorf("BindMarker offsets = %v, want %v", offsets, f.ExpectBindMarkerOffsets)
+				}
+			}
+
+			if f.ExpectView != "" {
+				if result.View == nil {
+					t.Errorf("View = nil, want %q", f.ExpectView)
+				} else if got := viewString(result.View); got != f.ExpectView {
+					t.Errorf("View = %q, want %q", got, f.ExpectView)
+				}
+			}
+
 			// Check schema errors
 			if f.ExpectSchemaErrorCount != nil {
 				if len(result.SchemaErrors) != *f.ExpectSchemaErrorCount {
@@ -266,6 +437,17 @@ func TestAnalyzeFixtures(t *testing.T) {
 						if f.ExpectSuggestionCont != "" && !strings.Contains(e.Suggestion, f.ExpectSuggestionCont) {
 							t.Errorf("Suggestion %q does not contain %q", e.Suggestion, f.ExpectSuggestionCont)
 						}
+						if f.ExpectNoSuggestion && e.Suggestion != "" {
+							t.Errorf("Suggestion = %q, want none", e.Suggestion)
+						}
+
+						if f.ExpectSchemaErrorCol != nil {
+							if e.Position == nil {
+								t.Errorf("Schema error has no position, want column %d", *f.ExpectSchemaErrorCol)
+							} else if e.Position.Column != *f.ExpectSchemaErrorCol {
+								t.Errorf("Schema error column = %d, want %d", e.Position.Column, *f.ExpectSchemaErrorCol)
+							}
+						}
 						break
 					}
 				}
@@ -290,6 +472,11 @@ func TestAnalyzeFixtures(t *testing.T) {
 						if f.ExpectWarningContains != "" && !strings.Contains(w.Message, f.ExpectWarningContains) {
 							t.Errorf("Warning message %q does not contain %q", w.Message, f.ExpectWarningContains)
 						}
+
+						// Suggestions are those of schema errors, if any are expected
+						if f.ExpectSchemaErrorType == "" && f.ExpectSuggestionCont != "" && !strings.Contains(w.Suggestion, f.ExpectSuggestionCont) {
+							t.Errorf("Warning suggestion %q does not contain %q", w.Suggestion, f.ExpectSuggestionCont)
+						}
 						break
 					}
 				}
@@ -301,6 +488,29 @@ func TestAnalyzeFixtures(t *testing.T) {
 	}
 }
 
+// bindMarkerString formats a bind marker as in expectBindMarkers.
+func bindMarkerString(m *BindMarker) string {
+	dash := func(s string) string {
+		if s == "" {
+			return "-"
+		}
+		return s
+	}
+	s := fmt.Sprintf("%s %s %s", dash(string(m.Role)), dash(m.Column), dash(m.CQLType))
+	if m.Name != "" {
+		s = ":" + m.Name + " " + s
+	}
+	return s
+}
+
+func viewString(mv *schema.MaterializedView) string {
+	key := "(" + strings.Join(mv.PartitionKey, ", ") + ")"
+	if len(mv.ClusteringKey) > 0 {
+		key += ", " + strings.Join(mv.ClusteringKey, ", ")
+	}
+	return fmt.Sprintf("%s.%s ON %s PRIMARY KEY (%s) [%s]", mv.Keyspace, mv.Name, mv.BaseTable, key, strings.Join(mv.ColumnOrder, ", "))
+}
+
 func checkStringSlice(t *testing.T, name string, got, want []string) {
 	t.Helper()
 
@@ -0,0 +1,147 @@
+package analyze
+
+import (
+	"strings"
+
+	"github.com/antlr4-go/antlr/v4"
+	parser "github.com/tentacle-scylla/scql/gen/parser"
+)
+
+// markerRole returns the role of a bind marker constant from the function
+// call or the clause it is in, or "" outside the clauses of BindRole.
+// Markers of LIMIT, TTL, TIMESTAMP, IN ? and m[?] are not constants; they
+// are recorded by enterMarker.
+func markerRole(ctx antlr.Tree) BindRole {
+	for p := ctx.GetParent(); p != nil; p = p.GetParent() {
+		switch p := p.(type) {
+		case *parser.FunctionArgsContext:
+			// The arguments of IN (...) are functionArgs too
+			switch p.GetParent().(type) {
+			case *parser.FunctionCallContext, *parser.QualifiedFunctionCallContext:
+				return BindArgument
+			}
+		case *parser.RelationElementContext:
+			if p.KwIn() != nil {
+				return BindInList
+			}
+			return BindWhere
+		case *parser.RelalationContainsContext, *parser.RelalationContainsKeyContext:
+			return BindWhere
+		case *parser.AssignmentElementContext:
+			return BindSet
+		case *parser.InsertValuesSpecContext:
+			return BindValue
+		case *parser.IfConditionContext:
+			return BindCondition
+		case *parser.UsingTtlTimestampContext, *parser.UsingTimestampSpecContext, *parser.UsingTimeoutSpecContext:
+			// The only constant of USING is that of TIMEOUT
+			return BindTimeout
+		}
+	}
+	return ""
+}
+
+// isTokenRelation reports whether ctx is a relation of token(), whose values
+// are bigint tokens: token(id) > ?.
+func isTokenRelation(ctx antlr.Tree) bool {
+	r, ok := ctx.(*parser.RelationElementContext)
+	if !ok {
+		return false
+	}
+	call := r.FunctionCall(0)
+	return call != nil && call.KwToken() != nil && len(r.AllColumnRef()) == 0
+}
+
+// bindMarkers sets the column and type of the bind markers of a statement
+// from the values they are in, typed by the table of sc when it is known.
+// Markers in collection, tuple and UDT literals take the type of their
+// element or field, and function arguments that of their parameter.
+func bindMarkers(refs *References, sc *scope) {
+	if len(refs.markers) == 0 {
+		return
+	}
+	markers := make(map[int]*BindMarker)
+	for _, m := range refs.markers {
+		markers[m.Position.Offset] = m
+	}
+
+	var bind func(v *value, column string, t *cqlType)
+	bind = func(v *value, column string, t *cqlType) {
+		if m := markers[v.position.Offset]; m != nil && v.kind == valueUnknown {
+			m.Column = column
+			if t != nil {
+				m.CQLType = t.String()
+			}
+			return
+		}
+		for i, element := range v.elements {
+			var et *cqlType
+			if t != nil {
+				switch v.kind {
+				case valueList, valueSet, valueMap:
+					et = t.arg(0)
+				case valueTuple:
+					et = t.arg(i)
+				}
+			}
+			bind(element, column, et)
+		}
+		for i, mv := range v.values {
+			var vt *cqlType
+			switch {
+			case t != nil && v.kind == valueUDT:
+				vt = t.field(v.fields[i].name)
+			case t != nil:
+				vt = t.arg(1)
+			}
+			bind(mv, column, vt)
+		}
+	}
+
+	for _, cv := range refs.values {
+		if cv.columns != nil {
+			var t *cqlType
+			if tuple := sc.tupleType(cv.columns); tuple != nil {
+				t = targetType(tuple, cv.target)
+			}
+			bind(cv.value, "("+strings.Join(cv.columns, ", ")+")", t)
+			continue
+		}
+		column := cv.column
+		var t *cqlType
+		if col := sc.table.GetColumn(cv.column); col != nil {
+			column = col.Name
+			t = targetType(sc.cqlType(col.Type), cv.target)
+			if cv.field != "" {
+				column = col.Name + "." + cv.field
+				t = nil
+				if typ := sc.fieldType(col, cv.field); typ != "" {
+					t = sc.cqlType(typ)
+				}
+			}
+		}
+		bind(cv.value, column, t)
+	}
+	for _, call := range refs.FunctionCalls {
+		params := sc.parameterTypes(call)
+		for i, arg := range call.args {
+			if m := markers[arg.position.Offset]; m != nil && arg.kind == valueUnknown && params[i] != "" {
+				m.CQLType = params[i]
+			}
+		}
+	}
+}
+
+// tupleType returns the type of the tuples of a multi-column relation on
+// columns of the table of sc, or nil if a column is unknown.
+func (sc *scope) tupleType(columns []string) *cqlType {
+	t := &cqlType{name: "tuple"}
+	for _, name := range columns {
+		col := sc.table.GetColumn(name)
+		if col == nil {
+			return nil
+		}
+		t.args = append(t.args, sc.cqlType(col.Type))
+	}
+	return t
+}
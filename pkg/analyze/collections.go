@@ -0,0 +1,107 @@
+package analyze
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/tentacle-scylla/scql/pkg/schema"
+)
+
+// deletion is a collection element in the column list of a DELETE: c[key].
+type deletion struct {
+	column   string
+	text     string // Source text
+	position *Position
+}
+
+// validateCollections checks the collection operations of a statement
+// against the types of their columns: additions, subtractions and element
+// updates or deletions only apply to non-frozen collections, values can only
+// be prepended to lists, set elements cannot be updated by key and fields can
+// only be set in non-frozen UDTs. The types of keys and values are checked
+// with the other values of the statement.
+func validateCollections(result *Result, tbl *schema.Table) {
+	refs := result.References
+	schemaError := func(object string, position *Position, format string, args ...any) {
+		result.SchemaErrors = append(result.SchemaErrors, &SchemaError{
+			Type:     ErrInvalidCollectionOp,
+			Message:  fmt.Sprintf(format, args...),
+			Object:   object,
+			Position: position,
+		})
+	}
+
+	for _, a := range refs.assignments {
+		col := tbl.GetColumn(a.column)
+		// Counters and increments are checked with the counters
+		if col == nil || a.kind == assignValue || a.increment || isCounterType(col.Type) {
+			continue
+		}
+		t := parseCQLType(col.Type)
+		collection := isCollectionType(col.Type)
+		frozen := isFrozenType(col.Type)
+
+		if a.kind == assignField {
+			// Fields of columns that are not UDTs are reported by validateFields
+			if frozen && !knownTypes[t.name] {
+				schemaError(col.Name, a.position, "Invalid operation (%s) for frozen UDT column %s", a.text, col.Name)
+			}
+			continue
+		}
+
+		if a.kind == assignElement {
+			switch {
+			case !collection:
+				schemaError(col.Name, a.position, "Invalid operation (%s) for non collection column %s", a.text, col.Name)
+			case t.name == "set":
+				schemaError(col.Name, a.position, "Invalid operation (%s) for set column %s", a.text, col.Name)
+			case frozen:
+				schemaError(col.Name, a.position, "Invalid operation (%s) for frozen collection column %s", a.text, col.Name)
+			}
+			continue
+		}
+
+		switch {
+		case a.prepend && a.kind == assignSubtract:
+			schemaError(col.Name, a.position, "Only expressions of the form X = X -<value> are supported.")
+		case a.prepend && !strings.EqualFold(a.operand, col.Name):
+			schemaError(col.Name, a.position, "Only expressions of the form X = <value> + X are supported.")
+		case !strings.EqualFold(a.operand, col.Name):
+			op := "+"
+			if a.kind == assignSubtract {
+				op = "-"
+			}
+			schemaError(col.Name, a.position, "Only expressions of the form X = X %s<value> are supported.", op)
+		case a.prepend && t.name != "list":
+			schemaError(col.Name, a.position, "Invalid operation (%s) for non list column %s", a.text, col.Name)
+		case a.prepend && frozen:
+			schemaError(col.Name, a.position, "Invalid operation (%s) for frozen list column %s", a.text, col.Name)
+		case !collection:
+			// Reported with the counters
+		case frozen:
+			schemaError(col.Name, a.position, "Invalid operation (%s) for frozen collection column %s", a.text, col.Name)
+		}
+	}
+
+	for _, d := range refs.deletions {
+		col := tbl.GetColumn(d.column)
+		if col == nil {
+			continue
+		}
+		switch t := parseCQLType(col.Type); {
+		case t.name != "list" && t.name != "set" && t.name != "map":
+			schemaError(col.Name, d.position, "Invalid deletion operation for non collection column %s", col.Name)
+		case isFrozenType(col.Type):
+			schemaError(col.Name, d.position, "Invalid deletion operation for frozen collection column %s", col.Name)
+		}
+	}
+}
+
+// isCollectionType reports whether a column type string is a list, set or map.
+func isCollectionType(typ string) bool {
+	switch parseCQLType(typ).name {
+	case "list", "set", "map":
+		return true
+	}
+	return false
+}
@@ -0,0 +1,217 @@
+package analyze
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/antlr4-go/antlr/v4"
+	parser "github.com/tentacle-scylla/scql/gen/parser"
+	"github.com/tentacle-scylla/scql/pkg/schema"
+	"github.com/tentacle-scylla/scql/pkg/types"
+)
+
+// conditionOf returns an IF condition as a relation on its column.
+func conditionOf(ctx *parser.IfConditionContext) *relation {
+	column := ctx.ColumnRef()
+	r := &relation{
+		columns:  []string{extractColumnName(column.GetText())},
+		position: positionOf(column.GetStart()),
+	}
+	switch {
+	case ctx.KwIn() != nil:
+		r.op = "IN"
+	case ctx.KwContains() != nil && ctx.KwKey() != nil:
+		r.op = "CONTAINS KEY"
+	case ctx.KwContains() != nil:
+		r.op = "CONTAINS"
+	case ctx.KwLike() != nil:
+		r.op = "LIKE"
+	default:
+		for _, op := range []antlr.TerminalNode{ctx.OPERATOR_EQ(), ctx.OPERATOR_LT(), ctx.OPERATOR_GT(), ctx.OPERATOR_LTE(), ctx.OPERATOR_GTE(), ctx.OPERATOR_NEQ()} {
+			if op != nil {
+				r.op = op.GetText()
+			}
+		}
+	}
+	return r
+}
+
+// validateConditions checks the IF conditions of a lightweight transaction
+// against the primary key of tbl: primary key columns cannot have
+// conditions, and conditional updates and deletions apply to a single row,
+// whose primary key is restricted with = only. Missing partition key columns
+// are reported with the other restrictions.
+func validateConditions(result *Result, tbl *schema.Table) {
+	refs := result.References
+	schemaError := func(object string, position *Position, format string, args ...any) {
+		result.SchemaErrors = append(result.SchemaErrors, &SchemaError{
+			Type:     ErrInvalidCondition,
+			Message:  fmt.Sprintf(format, args...),
+			Object:   object,
+			Position: position,
+		})
+	}
+
+	for _, r := range refs.conditions {
+		col := tbl.GetColumn(r.columns[0])
+		if col != nil && (col.IsPartitionKey || col.IsClusteringKey) {
+			schemaError(col.Name, r.position, "PRIMARY KEY column '%s' cannot have IF conditions", col.Name)
+		}
+	}
+
+	stmt := result.Type
+	if !refs.IsConditional || (stmt != types.StatementUpdate && stmt != types.StatementDelete) {
+		return
+	}
+	kind, verb := "updates", "UPDATE"
+	if stmt == types.StatementDelete {
+		kind, verb = "deletions", "DELETE"
+	}
+	restricted := make(map[string][]*relation)
+	for _, r := range refs.relations {
+		if r.token {
+			continue
+		}
+		for _, name := range r.columns {
+			if col := tbl.GetColumn(name); col != nil {
+				restricted[col.Name] = append(restricted[col.Name], r)
+			}
+		}
+	}
+	for _, name := range tbl.PartitionKey {
+		for _, r := range restricted[name] {
+			if r.op == "IN" {
+				schemaError(name, r.position, "IN on the partition key is not supported with conditional %s", kind)
+				return
+			}
+		}
+	}
+	for _, name := range tbl.ClusteringKey {
+		eq := false
+		for _, r := range restricted[name] {
+			if r.op == "IN" {
+				schemaError(name, r.position, "IN on the clustering key columns is not supported with conditional %s", kind)
+				return
+			}
+			eq = eq || r.op == "="
+		}
+		if !eq {
+			schemaError(name, refs.conditional,
+				"%s statements must restrict all PRIMARY KEY columns with equality relations in order to use IF conditions", verb)
+			return
+		}
+	}
+}
+
+// validateLightweightTransaction reports USING TIMESTAMP on conditional
+// statements, which Scylla rejects, and with WarnOnLightweightTransaction
+// the use of lightweight transactions. The IF conditions of a BATCH must
+// apply to a single partition of a single table.
+func validateLightweightTransaction(result *Result, opts *AnalyzeOptions) {
+	refs := result.References
+	if !refs.IsConditional {
+		return
+	}
+	schemaError := func(position *Position, message string) {
+		result.SchemaErrors = append(result.SchemaErrors, &SchemaError{
+			Type:     ErrInvalidTimestamp,
+			Message:  message,
+			Object:   refs.Table,
+			Position: position,
+		})
+	}
+
+	if result.Type != types.StatementBatch {
+		if refs.timestamp != nil {
+			schemaError(refs.timestamp, "Cannot provide custom timestamp for conditional updates")
+		}
+	} else {
+		if refs.timestamp != nil {
+			schemaError(refs.timestamp, "Cannot provide custom timestamp for conditional BATCH")
+		}
+		for _, target := range refs.Targets {
+			if target.timestamp != nil {
+				schemaError(target.timestamp, "Cannot provide custom timestamp for conditional updates")
+			}
+		}
+		validateBatchConditions(result, opts)
+	}
+
+	if opts.WarnOnLightweightTransaction {
+		result.Warnings = append(result.Warnings, &Warning{
+			Type:       WarnLightweightTransaction,
+			Severity:   SeverityInfo,
+			Message:    "Lightweight transaction (IF ...) requires a Paxos round, which is slower than a regular write",
+			Suggestion: "Use conditions only where needed, and avoid mixing conditional and regular writes to the same data",
+			Position:   refs.conditional,
+		})
+	}
+}
+
+// validateBatchConditions checks that the statements of a BATCH with
+// conditions target a single table, and the same partition when their
+// partition keys are literals.
+func validateBatchConditions(result *Result, opts *AnalyzeOptions) {
+	targets := result.References.Targets
+	schemaError := func(target *Target, message string) {
+		result.SchemaErrors = append(result.SchemaErrors, &SchemaError{
+			Type:     ErrInvalidCondition,
+			Message:  message,
+			Object:   target.Table,
+			Position: target.Position,
+		})
+	}
+
+	first := targets[0]
+	for _, target := range targets[1:] {
+		if !strings.EqualFold(target.Keyspace, first.Keyspace) || !strings.EqualFold(target.Table, first.Table) {
+			schemaError(target, "Batch with conditions cannot span multiple tables")
+			return
+		}
+	}
+
+	if opts.Schema == nil {
+		return
+	}
+	ks := opts.Schema.GetKeyspace(first.Keyspace)
+	if ks == nil {
+		return
+	}
+	tbl := ks.GetTable(first.Table)
+	if tbl == nil {
+		return
+	}
+	var partition string
+	for _, target := range targets {
+		key := partitionOf(target.References, tbl)
+		switch {
+		case key == "":
+		case partition == "":
+			partition = key
+		case key != partition:
+			schemaError(target, "Batch with conditions cannot span multiple partitions")
+			return
+		}
+	}
+}
+
+// partitionOf returns the partition key values of a statement, or "" unless
+// each partition key column has a single value other than a ? marker or a
+// function call such as uuid() or now(), which differs on each call.
+func partitionOf(refs *References, tbl *schema.Table) string {
+	var key []string
+	for _, name := range tbl.PartitionKey {
+		var values []*value
+		for _, cv := range refs.values {
+			if strings.EqualFold(cv.column, name) && cv.target == targetColumn {
+				values = append(values, cv.value)
+			}
+		}
+		// Calls keep their name once typed
+		if len(values) != 1 || values[0].text == "?" || values[0].name != "" {
+			return ""
+		}
+		key = append(key, values[0].text)
+	}
+	return strings.Join(key, ", ")
+}
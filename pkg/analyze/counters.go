@@ -0,0 +1,177 @@
+package analyze
+
+import (
+	"fmt"
+	"strings"
+
+	parser "github.com/tentacle-scylla/scql/gen/parser"
+	"github.com/tentacle-scylla/scql/pkg/schema"
+	"github.com/tentacle-scylla/scql/pkg/types"
+)
+
+// assignment is an element of the SET clause of an UPDATE.
+type assignment struct {
+	column    string
+	kind      assignmentKind
+	operand   string // Column added to or subtracted from: column = operand + value
+	increment bool   // The value added or subtracted is an integer
+	prepend   bool   // The value comes first: column = value + operand
+	text      string // Source text
+	position  *Position
+}
+
+// assignmentKind is the operation of an assignment.
+type assignmentKind int
+
+const (
+	assignValue    assignmentKind = iota // c = value
+	assignAdd                            // c = c + value or c = value + c
+	assignSubtract                       // c = c - value
+	assignElement                        // c[key] = value
+	assignField                          // c.field = value
+)
+
+// assignmentOf returns the assignment of a SET clause element.
+func assignmentOf(ctx *parser.AssignmentElementContext) *assignment {
+	columns := ctx.AllColumnRef()
+	a := &assignment{
+		column:   extractColumnName(columns[0].GetText()),
+		text:     sourceText(ctx),
+		position: positionOf(ctx.GetStart()),
+	}
+	switch {
+	case ctx.DOT() != nil:
+		a.kind = assignField
+	case ctx.SyntaxBracketLs() != nil:
+		a.kind = assignElement
+	case len(columns) == 2:
+		a.kind = assignAdd
+		op := ctx.PLUS()
+		if ctx.MINUS() != nil {
+			a.kind = assignSubtract
+			op = ctx.MINUS()
+		}
+		a.operand = extractColumnName(columns[1].GetText())
+		a.increment = ctx.DecimalLiteral() != nil
+		a.prepend = columns[1].GetStart().GetTokenIndex() > op.GetSymbol().GetTokenIndex()
+	}
+	return a
+}
+
+// isCounterTable reports whether tbl is a counter table, whose columns other
+// than the primary key are counters.
+func isCounterTable(tbl *schema.Table) bool {
+	for _, col := range tbl.AllColumns() {
+		if !col.IsPartitionKey && !col.IsClusteringKey && isCounterType(col.Type) {
+			return true
+		}
+	}
+	return false
+}
+
+// isCounterType reports whether a column type string is counter.
+func isCounterType(typ string) bool {
+	return parseCQLType(typ).name == "counter"
+}
+
+// validateCounters checks statements on counter tables: rows cannot be
+// inserted, counters can only be incremented or decremented, not set, and
+// cannot have a TTL or a custom timestamp. Increments of columns that are
+// not counters, and statements setting both kinds of columns, are reported
+// too.
+func validateCounters(result *Result, tbl *schema.Table) {
+	refs := result.References
+	schemaError := func(typ SchemaErrorType, object string, position *Position, format string, args ...any) {
+		result.SchemaErrors = append(result.SchemaErrors, &SchemaError{
+			Type:     typ,
+			Message:  fmt.Sprintf(format, args...),
+			Object:   object,
+			Position: position,
+		})
+	}
+
+	if isCounterTable(tbl) {
+		switch result.Type {
+		case types.StatementInsert:
+			schemaError(ErrCounterInsert, tbl.Name, refs.target, "INSERT statements are not allowed on counter tables, use UPDATE instead")
+		case types.StatementUpdate:
+			if refs.ttl != nil {
+				schemaError(ErrCounterTTL, tbl.Name, refs.ttl, "Cannot provide custom TTL for counter updates")
+			}
+			if refs.timestamp != nil {
+				schemaError(ErrInvalidTimestamp, tbl.Name, refs.timestamp, "Cannot provide custom timestamp for counter updates")
+			}
+		}
+	}
+
+	var counter, regular *assignment
+	for _, a := range refs.assignments {
+		col := tbl.GetColumn(a.column)
+		if col == nil {
+			continue
+		}
+		if !isCounterType(col.Type) {
+			if regular == nil {
+				regular = a
+			}
+			// c = c + value on a column that is neither a counter nor a
+			// collection; other additions are checked with the collections
+			addition := (a.kind == assignAdd || a.kind == assignSubtract) && !a.prepend && strings.EqualFold(a.operand, col.Name)
+			if a.increment || (addition && !isCollectionType(col.Type)) {
+				schemaError(ErrCounterAssignment, col.Name, a.position, "Invalid operation (%s) for non counter column %s", a.text, col.Name)
+			}
+			continue
+		}
+		if counter == nil {
+			counter = a
+		}
+		switch {
+		case a.kind == assignValue || a.kind == assignElement || a.kind == assignField:
+			schemaError(ErrCounterAssignment, col.Name, a.position,
+				"Cannot set the value of counter column %s (counters can only be incremented/decremented, not set)", col.Name)
+		case !a.increment || !strings.EqualFold(a.operand, col.Name):
+			schemaError(ErrCounterAssignment, col.Name, a.position, "Only expressions of the form X = X +<value> are supported.")
+		}
+	}
+	if counter != nil && regular != nil {
+		later := counter
+		if regular.position.Offset > counter.position.Offset {
+			later = regular
+		}
+		schemaError(ErrCounterMix, later.column, later.position, "Cannot mix counter and non counter columns in the same statement")
+	}
+}
+
+// validateCounterBatch checks the statements of a BATCH of the given type
+// against the tables they modify: statements on counter tables cannot be in
+// a LOGGED batch, which is the default, other statements cannot be in a
+// COUNTER batch, and an UNLOGGED batch cannot have both.
+func validateCounterBatch(batchType string, stmts []*Result, tables []*schema.Table) {
+	first := -1 // First statement on a known table
+	for i, stmt := range stmts {
+		tbl := tables[i]
+		if tbl == nil {
+			continue
+		}
+		counter := isCounterTable(tbl)
+		var message string
+		switch {
+		case counter && (batchType == "" || batchType == "LOGGED"):
+			message = "Cannot include a counter statement in a logged batch"
+		case !counter && batchType == "COUNTER":
+			message = "Cannot include non-counter statement in a counter batch"
+		case first >= 0 && counter != isCounterTable(tables[first]):
+			message = "Counter and non-counter mutations cannot exist in the same batch"
+		}
+		if first < 0 {
+			first = i
+		}
+		if message != "" {
+			stmt.SchemaErrors = append(stmt.SchemaErrors, &SchemaError{
+				Type:    ErrCounterBatch,
+				Message: message,
+				Object:  tbl.Name,
+			})
+		}
+	}
+}
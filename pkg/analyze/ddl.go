@@ -0,0 +1,398 @@
+package analyze
+
+import (
+	"fmt"
+	"strings"
+
+	parser "github.com/tentacle-scylla/scql/gen/parser"
+	"github.com/tentacle-scylla/scql/pkg/schema"
+)
+
+// tableDefinition is the table of a CREATE TABLE statement.
+type tableDefinition struct {
+	keyspace        string
+	columns         []*columnDefinition
+	partitionKey    []*identifier
+	clusteringKey   []*identifier
+	clusteringOrder []*identifier // Columns of CLUSTERING ORDER BY
+	primaryKeys     int           // Number of PRIMARY KEY declarations
+}
+
+// columnDefinition is a column of a CREATE TABLE or ALTER TABLE ADD statement.
+type columnDefinition struct {
+	name      string
+	typ       string // Source text of the type
+	static    bool
+	userTypes []*identifier // User-defined types named by the type
+	position  *Position
+}
+
+// identifier is a name in a DDL statement, such as a primary key column or
+// a user-defined type, with its position.
+type identifier struct {
+	name     string
+	position *Position
+}
+
+// identifierOf returns the name and position of a column.
+func identifierOf(ctx parser.IColumnContext) *identifier {
+	return &identifier{name: extractColumnName(ctx.GetText()), position: positionOf(ctx.GetStart())}
+}
+
+// tableDefinitionOf returns the columns, primary key and clustering order of
+// a CREATE TABLE.
+func tableDefinitionOf(ctx *parser.CreateTableContext) *tableDefinition {
+	def := &tableDefinition{}
+	if ks := ctx.Keyspace(); ks != nil {
+		def.keyspace = strings.Trim(ks.GetText(), "\"")
+	}
+	list := ctx.ColumnDefinitionList()
+	if list == nil {
+		return def
+	}
+	for _, c := range list.AllColumnDefinition() {
+		col := columnDefinitionOf(c.Column(), c.DataType(), c.StaticColumn() != nil)
+		def.columns = append(def.columns, col)
+		if c.PrimaryKeyColumn() != nil {
+			def.partitionKey = append(def.partitionKey, &identifier{name: col.name, position: col.position})
+			def.primaryKeys++
+		}
+	}
+
+	if pk := list.PrimaryKeyElement(); pk != nil {
+		def.primaryKeys++
+	}
+	partitionKey, clusteringKey := primaryKeyOf(list.PrimaryKeyElement())
+	for _, c := range partitionKey {
+		def.partitionKey = append(def.partitionKey, identifierOf(c))
+	}
+	for _, c := range clusteringKey {
+		def.clusteringKey = append(def.clusteringKey, identifierOf(c))
+	}
+
+	if with := ctx.WithElement(); with != nil {
+		for opts := with.TableOptions(); opts != nil; opts = opts.TableOptions() {
+			if order := opts.ClusteringOrder(); order != nil {
+				for _, c := range order.AllColumn() {
+					def.clusteringOrder = append(def.clusteringOrder, identifierOf(c))
+				}
+			}
+		}
+	}
+	return def
+}
+
+// columnDefinitionOf returns the definition of a column of a CREATE TABLE
+// or ALTER TABLE ADD statement.
+func columnDefinitionOf(column parser.IColumnContext, typ parser.IDataTypeContext, static bool) *columnDefinition {
+	return &columnDefinition{
+		name:      extractColumnName(column.GetText()),
+		typ:       sourceText(typ),
+		static:    static,
+		userTypes: userTypesOf(typ),
+		position:  positionOf(column.GetStart()),
+	}
+}
+
+// userTypesOf returns the user-defined types named by a data type, such as
+// address in frozen<list<address>>.
+func userTypesOf(typ parser.IDataTypeContext) []*identifier {
+	if typ == nil {
+		return nil
+	}
+	var names []*identifier
+	if name := typ.DataTypeName(); name != nil && name.OBJECT_NAME() != nil {
+		names = append(names, &identifier{name: strings.Trim(name.GetText(), "\""), position: positionOf(name.GetStart())})
+	}
+	if args := typ.DataTypeDefinition(); args != nil {
+		for _, arg := range args.AllDataTypeArg() {
+			names = append(names, userTypesOf(arg.DataType())...)
+		}
+	}
+	return names
+}
+
+// primaryKeyOf returns the columns of the partition key and clustering key
+// of a PRIMARY KEY clause.
+func primaryKeyOf(pk parser.IPrimaryKeyElementContext) (partitionKey, clusteringKey []parser.IColumnContext) {
+	if pk == nil || pk.PrimaryKeyDefinition() == nil {
+		return nil, nil
+	}
+	key := pk.PrimaryKeyDefinition()
+	var clustering parser.IClusteringKeyListContext
+	switch {
+	case key.SinglePrimaryKey() != nil:
+		partitionKey = append(partitionKey, key.SinglePrimaryKey().Column())
+	case key.CompoundKey() != nil:
+		partitionKey = append(partitionKey, key.CompoundKey().PartitionKey().Column())
+		clustering = key.CompoundKey().ClusteringKeyList()
+	case key.CompositeKey() != nil:
+		for _, c := range key.CompositeKey().PartitionKeyList().AllPartitionKey() {
+			partitionKey = append(partitionKey, c.Column())
+		}
+		clustering = key.CompositeKey().ClusteringKeyList()
+	}
+	if clustering != nil {
+		for _, c := range clustering.AllClusteringKey() {
+			clusteringKey = append(clusteringKey, c.Column())
+		}
+	}
+	return partitionKey, clusteringKey
+}
+
+// validateTableDefinition checks a CREATE TABLE statement: columns are
+// defined once, the primary key is declared once with defined columns,
+// CLUSTERING ORDER BY names the clustering columns in order, counters are
+// not in the primary key and the columns outside it are either all counters
+// or none, and static columns need clustering columns. With a schema, the
+// user-defined types of the columns exist in the keyspace.
+func validateTableDefinition(result *Result, opts *AnalyzeOptions) {
+	def := result.References.definition
+	schemaError := func(typ SchemaErrorType, object string, position *Position, format string, args ...any) {
+		result.SchemaErrors = append(result.SchemaErrors, &SchemaError{
+			Type:     typ,
+			Message:  fmt.Sprintf(format, args...),
+			Object:   object,
+			Position: position,
+		})
+	}
+
+	var defined []string
+	for _, col := range def.columns {
+		if containsFold(defined, col.name) {
+			schemaError(ErrDuplicateColumn, col.name, col.position, "Multiple definition of identifier %s", col.name)
+		}
+		defined = append(defined, col.name)
+	}
+
+	switch {
+	case def.primaryKeys == 0:
+		schemaError(ErrInvalidPrimaryKey, "", nil, "No PRIMARY KEY specifed (exactly one required)")
+	case def.primaryKeys > 1:
+		schemaError(ErrInvalidPrimaryKey, "", nil, "Multiple PRIMARY KEYs specifed (exactly one required)")
+	}
+	for _, key := range [][]*identifier{def.partitionKey, def.clusteringKey} {
+		for _, col := range key {
+			if !containsFold(defined, col.name) {
+				schemaError(ErrInvalidPrimaryKey, col.name, col.position, "Unknown definition %s referenced in PRIMARY KEY", col.name)
+			}
+		}
+	}
+
+	previous := -1
+	for _, col := range def.clusteringOrder {
+		i := indexOfIdentifier(def.clusteringKey, col.name)
+		switch {
+		case i == -1:
+			schemaError(ErrInvalidClusteringOrder, col.name, col.position, "Only clustering key columns can be defined in CLUSTERING ORDER directive")
+		case i < previous:
+			schemaError(ErrInvalidClusteringOrder, col.name, col.position,
+				"The order of columns in the CLUSTERING ORDER directive must be the one of the clustering key (%s must appear before %s)",
+				col.name, def.clusteringKey[previous].name)
+		default:
+			previous = i
+		}
+	}
+
+	var first *columnDefinition // First column outside the primary key
+	for _, col := range def.columns {
+		if containsIdentifier(def.partitionKey, col.name) || containsIdentifier(def.clusteringKey, col.name) {
+			if isCounterType(col.typ) {
+				schemaError(ErrInvalidPrimaryKey, col.name, col.position, "counter type is not supported for PRIMARY KEY part %s", col.name)
+			}
+			continue
+		}
+		if first == nil {
+			first = col
+			continue
+		}
+		if isCounterType(col.typ) != isCounterType(first.typ) {
+			schemaError(ErrCounterMix, col.name, col.position, "Cannot mix counter and non counter columns in the same table")
+			break
+		}
+	}
+
+	if len(def.clusteringKey) == 0 {
+		for _, col := range def.columns {
+			if col.static {
+				schemaError(ErrInvalidStatic, col.name, col.position, "%s", staticWithoutClusteringMessage)
+				break
+			}
+		}
+	}
+
+	if ks := definitionKeyspace(def.keyspace, opts); ks != nil {
+		for _, col := range def.columns {
+			validateUserTypes(result, ks, col)
+		}
+	}
+}
+
+// definitionKeyspace returns the keyspace of a DDL statement from the
+// schema: the named keyspace, or the default one. It returns nil without a
+// schema or if the keyspace is not in it.
+func definitionKeyspace(keyspace string, opts *AnalyzeOptions) *schema.Keyspace {
+	if keyspace == "" {
+		keyspace = opts.DefaultKeyspace
+	}
+	return opts.Schema.GetKeyspace(keyspace)
+}
+
+// validateUserTypes reports the user-defined types of a column definition
+// that are not in the keyspace.
+func validateUserTypes(result *Result, ks *schema.Keyspace, col *columnDefinition) {
+	for _, typ := range col.userTypes {
+		if ks.GetType(typ.name) == nil {
+			result.SchemaErrors = append(result.SchemaErrors, &SchemaError{
+				Type:     ErrUnknownType,
+				Message:  fmt.Sprintf("Unknown type %s.%s", ks.Name, typ.name),
+				Object:   typ.name,
+				Position: typ.position,
+			})
+		}
+	}
+}
+
+// tableAlteration is the change of an ALTER TABLE statement.
+type tableAlteration struct {
+	added                []*columnDefinition
+	altered              *columnDefinition // ALTER column TYPE type
+	dropped              []*identifier
+	renameFrom, renameTo *identifier
+}
+
+// tableAlterationOf returns the columns added, altered, dropped or renamed
+// by an ALTER TABLE.
+func tableAlterationOf(ctx *parser.AlterTableContext) *tableAlteration {
+	alter := &tableAlteration{}
+	op := ctx.AlterTableOperation()
+	if op == nil {
+		return alter
+	}
+	switch {
+	case op.AlterTableAdd() != nil:
+		add := op.AlterTableAdd()
+		if add.Column() != nil {
+			alter.added = append(alter.added, columnDefinitionOf(add.Column(), add.DataType(), add.StaticColumn() != nil))
+		}
+		for _, c := range add.AllColumnDefinition() {
+			alter.added = append(alter.added, columnDefinitionOf(c.Column(), c.DataType(), c.StaticColumn() != nil))
+		}
+	case op.KwAlter() != nil:
+		if op.Column() != nil && op.DataType() != nil {
+			alter.altered = columnDefinitionOf(op.Column(), op.DataType(), false)
+		}
+	case op.AlterTableDropColumns() != nil:
+		if list := op.AlterTableDropColumns().AlterTableDropColumnList(); list != nil {
+			for _, c := range list.AllColumn() {
+				alter.dropped = append(alter.dropped, identifierOf(c))
+			}
+		}
+	case op.AlterTableRename() != nil:
+		if columns := op.AlterTableRename().AllColumn(); len(columns) == 2 {
+			alter.renameFrom, alter.renameTo = identifierOf(columns[0]), identifierOf(columns[1])
+		}
+	}
+	return alter
+}
+
+// validateTableAlteration checks an ALTER TABLE statement against the
+// schema: the table exists, added columns are new and of known types,
+// altered columns exist and change to a compatible type, dropped columns
+// exist outside the primary key, and only primary key columns are renamed,
+// to a new name.
+func validateTableAlteration(result *Result, opts *AnalyzeOptions, sc *scope) {
+	alter := result.References.alteration
+	if opts.Schema == nil || result.References.Table == "" {
+		return
+	}
+	tbl := resolveTable(result, opts, sc)
+	if tbl == nil {
+		return
+	}
+	schemaError := func(typ SchemaErrorType, object string, position *Position, format string, args ...any) {
+		result.SchemaErrors = append(result.SchemaErrors, &SchemaError{
+			Type:     typ,
+			Message:  fmt.Sprintf(format, args...),
+			Object:   object,
+			Position: position,
+		})
+	}
+	unknownColumn := func(col *identifier) {
+		result.SchemaErrors = append(result.SchemaErrors, &SchemaError{
+			Type:       ErrUnknownColumn,
+			Message:    fmt.Sprintf("Column %s was not found in table %s", col.name, tbl.Name),
+			Suggestion: suggestColumn(tbl, col.name),
+			Object:     col.name,
+			Position:   col.position,
+		})
+	}
+
+	var added []string
+	for _, col := range alter.added {
+		switch {
+		case tbl.GetColumn(col.name) != nil:
+			schemaError(ErrDuplicateColumn, col.name, col.position, "Invalid column name %s because it conflicts with an existing column", col.name)
+		case containsFold(added, col.name):
+			schemaError(ErrDuplicateColumn, col.name, col.position, "Multiple definition of identifier %s", col.name)
+		}
+		added = append(added, col.name)
+		validateUserTypes(result, sc.keyspace, col)
+	}
+
+	if col := alter.altered; col != nil {
+		validateUserTypes(result, sc.keyspace, col)
+		c := tbl.GetColumn(col.name)
+		if c == nil {
+			unknownColumn(&identifier{name: col.name, position: col.position})
+		} else if from, to := parseCQLType(c.Type).String(), parseCQLType(col.typ).String(); !compatibleTypes(to, from) {
+			schemaError(ErrInvalidAlter, col.name, col.position, "Cannot change %s from type %s to type %s: types are incompatible.", col.name, from, to)
+		}
+	}
+
+	for _, col := range alter.dropped {
+		switch c := tbl.GetColumn(col.name); {
+		case c == nil:
+			unknownColumn(col)
+		case c.IsPartitionKey || c.IsClusteringKey:
+			schemaError(ErrInvalidAlter, col.name, col.position, "Cannot drop PRIMARY KEY part %s", col.name)
+		}
+	}
+
+	if alter.renameFrom != nil {
+		from, to := alter.renameFrom, alter.renameTo
+		switch c := tbl.GetColumn(from.name); {
+		case c == nil:
+			unknownColumn(from)
+		case !c.IsPartitionKey && !c.IsClusteringKey:
+			schemaError(ErrInvalidAlter, from.name, from.position, "Cannot rename non PRIMARY KEY part %s", from.name)
+		case tbl.GetColumn(to.name) != nil:
+			schemaError(ErrDuplicateColumn, to.name, to.position,
+				"Cannot rename column %s to %s in keyspace %s; another column of that name already exist", from.name, to.name, sc.keyspace.Name)
+		}
+	}
+}
+
+// containsIdentifier reports whether identifiers has one named name.
+func containsIdentifier(identifiers []*identifier, name string) bool {
+	return indexOfIdentifier(identifiers, name) != -1
+}
+
+// indexOfIdentifier returns the index of the identifier named name, or -1.
+func indexOfIdentifier(identifiers []*identifier, name string) int {
+	for i, id := range identifiers {
+		if strings.EqualFold(id.name, name) {
+			return i
+		}
+	}
+	return -1
+}
+
+// identifierNames returns the names of identifiers.
+func identifierNames(identifiers []*identifier) []string {
+	names := make([]string, len(identifiers))
+	for i, id := range identifiers {
+		names[i] = id.name
+	}
+	return names
+}
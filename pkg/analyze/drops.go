@@ -0,0 +1,150 @@
+package analyze
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/tentacle-scylla/scql/pkg/schema"
+)
+
+// dropStatement is the object of a DROP statement.
+type dropStatement struct {
+	kind     string // keyspace, table, type, index, materialized view, function or aggregate
+	keyspace string // Empty for the default keyspace; the name for DROP KEYSPACE
+	name     string
+	ifExists bool
+	position *Position
+}
+
+// validateDrop checks a DROP statement against the schema: without IF
+// EXISTS, the object exists, and a table has no materialized views and a
+// type is not used by tables or other types.
+func validateDrop(result *Result, opts *AnalyzeOptions) {
+	drop := result.References.drop
+	if opts.Schema == nil {
+		return
+	}
+	schemaError := func(typ SchemaErrorType, format string, args ...any) {
+		result.SchemaErrors = append(result.SchemaErrors, &SchemaError{
+			Type:     typ,
+			Message:  fmt.Sprintf(format, args...),
+			Object:   drop.name,
+			Position: drop.position,
+		})
+	}
+
+	if drop.kind == "keyspace" {
+		if opts.Schema.GetKeyspace(drop.name) == nil && !drop.ifExists {
+			schemaError(ErrObjectNotFound, "Cannot drop non existing keyspace '%s'.", drop.name)
+		}
+		return
+	}
+
+	name := drop.keyspace
+	if name == "" {
+		name = opts.DefaultKeyspace
+	}
+	if name == "" {
+		// No keyspace context - can't validate further
+		return
+	}
+	ks := opts.Schema.GetKeyspace(name)
+
+	switch drop.kind {
+	case "table":
+		tbl := ks.GetTable(drop.name)
+		switch {
+		case tbl == nil && !drop.ifExists:
+			schemaError(ErrObjectNotFound, "Cannot drop non existing table '%s' in keyspace '%s'.", drop.name, name)
+		case tbl != nil && len(tbl.MaterializedViews) > 0:
+			views := make([]string, 0, len(tbl.MaterializedViews))
+			for view := range tbl.MaterializedViews {
+				views = append(views, view)
+			}
+			sort.Strings(views)
+			schemaError(ErrObjectInUse, "Cannot drop table when materialized views still depend on it (%s.{%s})",
+				name, strings.Join(views, ", "))
+		}
+	case "materialized view":
+		if ks.GetMaterializedView(drop.name) == nil && !drop.ifExists {
+			schemaError(ErrObjectNotFound, "Cannot drop non existing materialized view '%s' in keyspace '%s'.", drop.name, name)
+		}
+	case "type":
+		udt := ks.GetType(drop.name)
+		switch {
+		case udt == nil && !drop.ifExists:
+			schemaError(ErrObjectNotFound, "No user type named %s exists.", drop.name)
+		case udt != nil:
+			if user := typeUser(ks, udt); user != "" {
+				schemaError(ErrObjectInUse, "Cannot drop user type %s.%s as it is still used by %s", name, udt.Name, user)
+			}
+		}
+	case "index":
+		if findIndex(ks, drop.name) == nil && !drop.ifExists {
+			schemaError(ErrObjectNotFound, "Index '%s' could not be found in any of the tables of keyspace '%s'", drop.name, name)
+		}
+	case "function":
+		if ks.GetFunction(drop.name) == nil && !drop.ifExists {
+			schemaError(ErrObjectNotFound, "Cannot drop non existing function '%s'", drop.name)
+		}
+	case "aggregate":
+		if ks.GetAggregate(drop.name) == nil && !drop.ifExists {
+			schemaError(ErrObjectNotFound, "Cannot drop non existing aggregate '%s'", drop.name)
+		}
+	}
+}
+
+// typeUser returns the first table or user-defined type of the keyspace,
+// in name order, that uses udt: "table ks.name" or "user type ks.name".
+// It returns "" if udt is unused.
+func typeUser(ks *schema.Keyspace, udt *schema.UserType) string {
+	uses := func(cqlType string) bool {
+		for _, ref := range ks.ReferencedTypes(cqlType) {
+			if ref == udt {
+				return true
+			}
+		}
+		return false
+	}
+
+	tables := ks.TableNames()
+	sort.Strings(tables)
+	for _, name := range tables {
+		for _, col := range ks.Tables[name].AllColumns() {
+			if uses(col.Type) {
+				return fmt.Sprintf("table %s.%s", ks.Name, name)
+			}
+		}
+	}
+	types := make([]string, 0, len(ks.Types))
+	for name := range ks.Types {
+		types = append(types, name)
+	}
+	sort.Strings(types)
+	for _, name := range types {
+		other := ks.Types[name]
+		if other == udt {
+			continue
+		}
+		for _, field := range other.FieldNames() {
+			if uses(other.Fields[field]) {
+				return fmt.Sprintf("user type %s.%s", ks.Name, name)
+			}
+		}
+	}
+	return ""
+}
+
+// findIndex returns an index by name from any table of the keyspace.
+func findIndex(ks *schema.Keyspace, name string) *schema.Index {
+	if ks == nil {
+		return nil
+	}
+	for _, tbl := range ks.Tables {
+		if idx := tbl.GetIndex(name); idx != nil {
+			return idx
+		}
+	}
+	return nil
+}
@@ -1,6 +1,7 @@
 package analyze
 
 import (
+	"math"
 	"strconv"
 	"strings"
 
@@ -34,6 +35,17 @@ type referenceExtractor struct {
 	inOrderBy bool
 	inUpdate  bool
 	inInsert  bool
+
+	// batch holds the references of a BATCH while refs collects those of
+	// one of its statements
+	batch *References
+
+	// splitBatch is set between a statement with a BEGIN BATCH prefix and
+	// APPLY BATCH, when a BATCH is split by semicolons
+	splitBatch bool
+
+	// markers counts the bind markers of the query
+	markers int
 }
 
 func newReferenceExtractor() *referenceExtractor {
@@ -91,9 +103,11 @@ func (e *referenceExtractor) EnterSelectElements(ctx *parser.SelectElementsConte
 }
 
 func (e *referenceExtractor) EnterSelectElement(ctx *parser.SelectElementContext) {
-	// Extract column name from select element
-	// This can be complex (function calls, aliases, etc.)
-	// For now, extract simple column references
+	// Columns are extracted by EnterColumnRef; only UDT fields are left:
+	// SELECT addr.city
+	if ctx.ColumnRef() != nil && ctx.DOT() != nil {
+		e.addField(ctx.ColumnRef(), ctx.OBJECT_NAME(0), ctx.GetStart())
+	}
 }
 
 // WHERE clause handling
@@ -106,6 +120,75 @@ func (e *referenceExtractor) ExitWhereSpec(ctx *parser.WhereSpecContext) {
 	e.inWhere = false
 }
 
+func (e *referenceExtractor) EnterRelationElement(ctx *parser.RelationElementContext) {
+	if e.inWhere {
+		if r := relationOf(ctx); r != nil {
+			e.refs.relations = append(e.refs.relations, r)
+		}
+	}
+
+	columns := ctx.AllColumnRef()
+	switch {
+	case len(columns) == 0 || ctx.KwLike() != nil:
+		// token() relations and LIKE patterns are not type checked
+	case ctx.DOT() != nil:
+		// UDT fields: addr.city = 'x'
+		field := e.addField(columns[0], ctx.OBJECT_NAME(), ctx.GetStart())
+		e.refs.values = append(e.refs.values, &columnValue{
+			column: extractColumnName(columns[0].GetText()),
+			field:  field,
+			value:  constantValue(ctx.Constant()),
+		})
+	case len(ctx.AllAssignmentTuple()) > 0:
+		// (a, b) = (1, 2) and (a, b) IN ((1, 2), (3, 4))
+		for _, tuple := range ctx.AllAssignmentTuple() {
+			for i, expr := range tuple.AllExpression() {
+				if i < len(columns) {
+					e.addValue(columns[i], targetColumn, expressionValue(expr))
+				}
+			}
+		}
+	case ctx.KwIn() != nil:
+		if m := e.enterMarker(ctx.QMARK(), ctx.NamedMarker(), BindInList); m != nil {
+			// IN ? binds the whole list, of tuples for (a, b) IN ?
+			cv := &columnValue{
+				column: extractColumnName(columns[0].GetText()),
+				target: targetList,
+				value:  &value{kind: valueUnknown, position: m.Position},
+			}
+			if len(columns) > 1 {
+				cv.column = ""
+				for _, column := range columns {
+					cv.columns = append(cv.columns, extractColumnName(column.GetText()))
+				}
+			}
+			e.refs.values = append(e.refs.values, cv)
+		}
+		if args := ctx.FunctionArgs(); args != nil {
+			for _, arg := range args.GetChildren() {
+				switch arg := arg.(type) {
+				case parser.IConstantContext:
+					e.addValue(columns[0], targetColumn, constantValue(arg))
+				case parser.IFunctionCallContext:
+					e.addValue(columns[0], targetColumn, functionValue(arg))
+				}
+			}
+		}
+	case ctx.Constant() != nil:
+		e.addValue(columns[0], targetColumn, constantValue(ctx.Constant()))
+	case len(ctx.AllFunctionCall()) == 1:
+		e.addValue(columns[0], targetColumn, functionValue(ctx.FunctionCall(0)))
+	}
+}
+
+func (e *referenceExtractor) EnterRelalationContains(ctx *parser.RelalationContainsContext) {
+	e.addValue(ctx.ColumnRef(), targetElement, constantValue(ctx.Constant()))
+}
+
+func (e *referenceExtractor) EnterRelalationContainsKey(ctx *parser.RelalationContainsKeyContext) {
+	e.addValue(ctx.ColumnRef(), targetKey, constantValue(ctx.Constant()))
+}
+
 // ORDER BY handling
 
 func (e *referenceExtractor) EnterOrderSpec(ctx *parser.OrderSpecContext) {
@@ -129,6 +212,9 @@ func (e *referenceExtractor) EnterOrderSpecElement(ctx *parser.OrderSpecElementC
 
 func (e *referenceExtractor) EnterUpdate(ctx *parser.UpdateContext) {
 	e.inUpdate = true
+	if ks := ctx.Keyspace(); ks != nil {
+		e.refs.Keyspace = strings.Trim(ks.GetText(), "\"")
+	}
 }
 
 func (e *referenceExtractor) ExitUpdate(ctx *parser.UpdateContext) {
@@ -141,6 +227,76 @@ func (e *referenceExtractor) EnterAssignmentElement(ctx *parser.AssignmentElemen
 		colName := extractColumnName(colRefs[0].GetText())
 		e.refs.UpdateColumns = append(e.refs.UpdateColumns, colName)
 		e.addColumn(colName)
+		e.refs.assignments = append(e.refs.assignments, assignmentOf(ctx))
+	}
+
+	// Values of c = value, c = c + value, c = value + c and c[key] = value
+	columns := ctx.AllColumnRef()
+	if len(columns) == 0 {
+		return
+	}
+	column := columns[0]
+	target := targetColumn
+	var field string
+	switch {
+	case ctx.DOT() != nil:
+		// UDT fields: addr.city = 'x'
+		field = e.addField(column, ctx.OBJECT_NAME(), ctx.GetStart())
+	case ctx.SyntaxBracketLs() != nil:
+		key := ctx.AssignmentIndexKey()
+		e.enterMarker(key.QMARK(), key.NamedMarker(), BindSet)
+		e.addValue(column, targetIndex, indexKeyValue(key))
+		target = targetElement
+	case len(columns) == 2 && ctx.MINUS() != nil:
+		target = targetRemoved
+	case len(columns) == 2:
+		target = targetAdded
+	}
+	var v *value
+	switch {
+	case ctx.Constant() != nil:
+		v = constantValue(ctx.Constant())
+	case ctx.AssignmentMap() != nil:
+		v = mapValue(ctx.AssignmentMap())
+	case ctx.AssignmentSet() != nil:
+		v = setValue(ctx.AssignmentSet())
+	case ctx.AssignmentList() != nil:
+		v = listValue(ctx.AssignmentList())
+	case ctx.FunctionCall() != nil:
+		v = functionValue(ctx.FunctionCall())
+	default:
+		return
+	}
+	if field != "" {
+		e.refs.values = append(e.refs.values, &columnValue{
+			column: extractColumnName(column.GetText()),
+			field:  field,
+			value:  v,
+		})
+		return
+	}
+	e.addValue(column, target, v)
+}
+
+// DELETE statement handling
+
+func (e *referenceExtractor) EnterDeleteColumnItem(ctx *parser.DeleteColumnItemContext) {
+	column := ctx.OBJECT_NAME()
+	e.addColumn(column.GetText())
+	if ctx.LS_BRACKET() == nil {
+		return
+	}
+	e.refs.deletions = append(e.refs.deletions, &deletion{
+		column:   extractColumnName(column.GetText()),
+		text:     sourceText(ctx),
+		position: positionOf(ctx.GetStart()),
+	})
+	if key := ctx.StringLiteral(); key != nil {
+		e.addValue(column, targetIndex, newValue(valueString, key))
+	} else if key := ctx.DecimalLiteral(); key != nil {
+		e.addValue(column, targetIndex, newValue(valueInteger, key))
+	} else if m := e.enterMarker(ctx.QMARK(), ctx.NamedMarker(), BindSet); m != nil {
+		e.addValue(column, targetIndex, &value{kind: valueUnknown, position: m.Position})
 	}
 }
 
@@ -148,6 +304,28 @@ func (e *referenceExtractor) EnterAssignmentElement(ctx *parser.AssignmentElemen
 
 func (e *referenceExtractor) EnterInsert(ctx *parser.InsertContext) {
 	e.inInsert = true
+	e.enterInsert(ctx.Keyspace(), ctx.Table(), ctx.InsertColumnSpec(), ctx.InsertValuesSpec())
+}
+
+func (e *referenceExtractor) enterInsert(ks parser.IKeyspaceContext, table parser.ITableContext, spec parser.IInsertColumnSpecContext, values parser.IInsertValuesSpecContext) {
+	switch {
+	case ks != nil:
+		e.refs.Keyspace = strings.Trim(ks.GetText(), "\"")
+		e.refs.target = positionOf(ks.GetStart())
+	case table != nil:
+		e.refs.target = positionOf(table.GetStart())
+	}
+
+	// Pair the VALUES with the column list
+	if spec == nil || spec.ColumnList() == nil || values == nil || values.ExpressionList() == nil {
+		return
+	}
+	columns := spec.ColumnList().AllColumn()
+	for i, expr := range values.ExpressionList().AllExpression() {
+		if i < len(columns) {
+			e.addValue(columns[i], targetColumn, expressionValue(expr))
+		}
+	}
 }
 
 func (e *referenceExtractor) ExitInsert(ctx *parser.InsertContext) {
@@ -165,6 +343,361 @@ func (e *referenceExtractor) EnterInsertColumnSpec(ctx *parser.InsertColumnSpecC
 	}
 }
 
+// BATCH handling: each statement collects its own references
+
+func (e *referenceExtractor) EnterBatchInsert(ctx *parser.BatchInsertContext) {
+	e.enterBatchStatement()
+	e.inInsert = true
+	e.enterInsert(ctx.Keyspace(), ctx.Table(), ctx.InsertColumnSpec(), ctx.InsertValuesSpec())
+}
+
+func (e *referenceExtractor) ExitBatchInsert(ctx *parser.BatchInsertContext) {
+	e.inInsert = false
+	e.exitBatchStatement(types.StatementInsert, ctx.GetStart(), ctx.GetStop())
+}
+
+func (e *referenceExtractor) EnterBatchUpdate(ctx *parser.BatchUpdateContext) {
+	e.enterBatchStatement()
+	e.inUpdate = true
+	if ks := ctx.Keyspace(); ks != nil {
+		e.refs.Keyspace = strings.Trim(ks.GetText(), "\"")
+	}
+}
+
+func (e *referenceExtractor) ExitBatchUpdate(ctx *parser.BatchUpdateContext) {
+	e.inUpdate = false
+	e.exitBatchStatement(types.StatementUpdate, ctx.GetStart(), ctx.GetStop())
+}
+
+func (e *referenceExtractor) EnterBatchDelete(ctx *parser.BatchDeleteContext) {
+	e.enterBatchStatement()
+}
+
+func (e *referenceExtractor) ExitBatchDelete(ctx *parser.BatchDeleteContext) {
+	e.exitBatchStatement(types.StatementDelete, ctx.GetStart(), ctx.GetStop())
+}
+
+// A BATCH split by semicolons parses as the statements from one with a
+// BEGIN BATCH prefix to APPLY BATCH
+
+func (e *referenceExtractor) EnterCql(ctx *parser.CqlContext) {
+	if ctx.ApplyBatch() != nil {
+		e.splitBatch = false
+		return
+	}
+	_, _, begins := splitBatchStatement(ctx)
+	if begins {
+		e.splitBatch = true
+	}
+	if e.splitBatch {
+		e.enterBatchStatement()
+	}
+}
+
+func (e *referenceExtractor) ExitCql(ctx *parser.CqlContext) {
+	if e.batch == nil {
+		return
+	}
+	typ, start, _ := splitBatchStatement(ctx)
+	if start == nil {
+		// Not a statement of a batch: keep its references
+		e.refs, e.batch = e.batch, nil
+		return
+	}
+	e.exitBatchStatement(typ, start, ctx.GetStop())
+}
+
+// splitBatchStatement returns the type and first token, after any BEGIN
+// BATCH prefix, of an INSERT, UPDATE or DELETE statement, and whether it has
+// the prefix. The token is nil for other statements.
+func splitBatchStatement(ctx *parser.CqlContext) (types.StatementType, antlr.Token, bool) {
+	switch {
+	case ctx.Insert() != nil:
+		stmt := ctx.Insert()
+		return types.StatementInsert, stmt.KwInsert().GetStart(), stmt.BeginBatch() != nil
+	case ctx.Update() != nil:
+		stmt := ctx.Update()
+		return types.StatementUpdate, stmt.KwUpdate().GetStart(), stmt.BeginBatch() != nil
+	case ctx.Delete_() != nil:
+		stmt := ctx.Delete_()
+		return types.StatementDelete, stmt.KwDelete().GetStart(), stmt.BeginBatch() != nil
+	}
+	return types.StatementUnknown, nil, false
+}
+
+func (e *referenceExtractor) enterBatchStatement() {
+	e.batch, e.refs = e.refs, NewReferences()
+}
+
+// exitBatchStatement adds the statement from start to stop as a target of
+// the batch, whose references also include those of the statement.
+func (e *referenceExtractor) exitBatchStatement(typ types.StatementType, start, stop antlr.Token) {
+	stmt := e.refs
+	e.refs, e.batch = e.batch, nil
+	e.refs.Targets = append(e.refs.Targets, &Target{
+		Type:       typ,
+		Text:       start.GetInputStream().GetText(start.GetStart(), stop.GetStop()),
+		Position:   positionOf(start),
+		End:        positionAfter(stop),
+		References: stmt,
+	})
+
+	e.refs.Keyspace, e.refs.Table = stmt.Keyspace, stmt.Table
+	for _, col := range stmt.Columns {
+		e.addColumn(col)
+	}
+	e.refs.WhereColumns = append(e.refs.WhereColumns, stmt.WhereColumns...)
+	e.refs.UpdateColumns = append(e.refs.UpdateColumns, stmt.UpdateColumns...)
+	e.refs.InsertColumns = append(e.refs.InsertColumns, stmt.InsertColumns...)
+	for _, col := range stmt.Conditions {
+		if !contains(e.refs.Conditions, col) {
+			e.refs.Conditions = append(e.refs.Conditions, col)
+		}
+	}
+	if stmt.IsConditional && !e.refs.IsConditional {
+		e.refs.IsConditional, e.refs.conditional = true, stmt.conditional
+	}
+	for _, fn := range stmt.Functions {
+		if !contains(e.refs.Functions, fn) {
+			e.refs.Functions = append(e.refs.Functions, fn)
+		}
+	}
+	e.refs.FunctionCalls = append(e.refs.FunctionCalls, stmt.FunctionCalls...)
+	e.refs.markers = append(e.refs.markers, stmt.markers...)
+}
+
+// DESCRIBE handling
+
+func (e *referenceExtractor) EnterDescribeTarget(ctx *parser.DescribeTargetContext) {
+	var name antlr.ParseTree
+	switch {
+	case ctx.Table() != nil:
+		name = ctx.Table()
+	case ctx.KwMaterialized() != nil && ctx.OBJECT_NAME() != nil:
+		name = ctx.OBJECT_NAME()
+	default:
+		return
+	}
+	target := &Target{
+		Type:       types.StatementDescribe,
+		Text:       sourceText(ctx),
+		Position:   positionOf(ctx.GetStart()),
+		End:        positionAfter(ctx.GetStop()),
+		References: NewReferences(),
+	}
+	if ks := ctx.Keyspace(); ks != nil {
+		target.Keyspace = strings.Trim(ks.GetText(), "\"")
+	}
+	target.Table = strings.Trim(name.GetText(), "\"")
+	e.refs.Keyspace, e.refs.Table = target.Keyspace, target.Table
+	e.refs.Targets = append(e.refs.Targets, target)
+}
+
+// IF conditions and USING TIMESTAMP handling
+
+func (e *referenceExtractor) EnterIfNotExist(ctx *parser.IfNotExistContext) {
+	e.enterIf(ctx)
+}
+
+func (e *referenceExtractor) EnterIfExist(ctx *parser.IfExistContext) {
+	e.enterIf(ctx)
+}
+
+func (e *referenceExtractor) EnterIfSpec(ctx *parser.IfSpecContext) {
+	e.enterIf(ctx)
+}
+
+// enterIf marks INSERT, UPDATE and DELETE statements, alone or in a BATCH,
+// as lightweight transactions; IF [NOT] EXISTS of DDL statements is not one.
+func (e *referenceExtractor) enterIf(ctx antlr.ParserRuleContext) {
+	switch ctx.GetParent().(type) {
+	case *parser.InsertContext, *parser.UpdateContext, *parser.Delete_Context,
+		*parser.BatchInsertContext, *parser.BatchUpdateContext, *parser.BatchDeleteContext:
+	default:
+		return
+	}
+	e.refs.IsConditional = true
+	e.refs.conditional = positionOf(ctx.GetStart())
+}
+
+func (e *referenceExtractor) EnterIfCondition(ctx *parser.IfConditionContext) {
+	r := conditionOf(ctx)
+	if !contains(e.refs.Conditions, r.columns[0]) {
+		e.refs.Conditions = append(e.refs.Conditions, r.columns[0])
+	}
+	e.refs.conditions = append(e.refs.conditions, r)
+
+	// Conditions on elements of collections are not type checked
+	column := ctx.ColumnRef()
+	if ctx.SyntaxBracketLs() != nil || ctx.SyntaxBracketLc() != nil {
+		return
+	}
+	switch r.op {
+	case "IN", "=", "!=", "<", ">", "<=", ">=":
+		for _, v := range ctx.AllIfConditionValue() {
+			e.addValue(column, targetColumn, conditionValue(v))
+		}
+	case "CONTAINS":
+		e.addValue(column, targetElement, conditionValue(ctx.IfConditionValue(0)))
+	case "CONTAINS KEY":
+		e.addValue(column, targetKey, conditionValue(ctx.IfConditionValue(0)))
+	}
+}
+
+func (e *referenceExtractor) EnterTimestamp(ctx *parser.TimestampContext) {
+	refs := e.refs
+	switch ctx.GetParent().GetParent().(type) {
+	case *parser.BeginBatchContext, *parser.BatchContext:
+		// USING TIMESTAMP of a whole BATCH
+		refs = e.batchReferences()
+	}
+	refs.timestamp = positionOf(ctx.GetStart())
+	if m := e.enterMarker(ctx.QMARK(), ctx.NamedMarker(), BindTimestamp); m != nil {
+		m.CQLType = "bigint"
+	}
+}
+
+func (e *referenceExtractor) EnterTtl(ctx *parser.TtlContext) {
+	e.refs.ttl = positionOf(ctx.GetStart())
+	if m := e.enterMarker(ctx.QMARK(), ctx.NamedMarker(), BindTTL); m != nil {
+		m.CQLType = "int"
+	}
+	if lit := ctx.DecimalLiteral(); lit != nil {
+		seconds, err := strconv.ParseInt(lit.GetText(), 10, 64)
+		if err != nil {
+			// Out of range
+			seconds = math.MaxInt64
+		}
+		e.refs.ttlSeconds, e.refs.ttlText = seconds, lit.GetText()
+	}
+}
+
+func (e *referenceExtractor) EnterBatchType(ctx *parser.BatchTypeContext) {
+	e.batchReferences().batchType = strings.ToUpper(ctx.GetText())
+}
+
+// batchReferences returns the references of the BATCH being extracted,
+// which BEGIN BATCH may precede a statement of.
+func (e *referenceExtractor) batchReferences() *References {
+	if e.batch != nil {
+		return e.batch
+	}
+	return e.refs
+}
+
+// CREATE TABLE handling
+
+func (e *referenceExtractor) EnterCreateTable(ctx *parser.CreateTableContext) {
+	e.refs.definition = tableDefinitionOf(ctx)
+	e.refs.Keyspace = e.refs.definition.keyspace
+}
+
+func (e *referenceExtractor) EnterAlterTable(ctx *parser.AlterTableContext) {
+	e.refs.alteration = tableAlterationOf(ctx)
+	e.enterKeyspace(ctx.Keyspace())
+}
+
+// DROP statements name their object after any IF EXISTS and keyspace
+
+func (e *referenceExtractor) EnterDropKeyspace(ctx *parser.DropKeyspaceContext) {
+	e.enterDrop("keyspace", nil, ctx.Keyspace(), ctx.IfExist() != nil)
+}
+
+func (e *referenceExtractor) EnterDropTable(ctx *parser.DropTableContext) {
+	e.enterDrop("table", ctx.Keyspace(), ctx.Table(), ctx.IfExist() != nil)
+}
+
+func (e *referenceExtractor) EnterDropMaterializedView(ctx *parser.DropMaterializedViewContext) {
+	e.enterDrop("materialized view", ctx.Keyspace(), ctx.MaterializedView(), ctx.IfExist() != nil)
+}
+
+func (e *referenceExtractor) EnterDropType(ctx *parser.DropTypeContext) {
+	e.enterDrop("type", ctx.Keyspace(), ctx.Type_(), ctx.IfExist() != nil)
+}
+
+func (e *referenceExtractor) EnterDropIndex(ctx *parser.DropIndexContext) {
+	e.enterDrop("index", ctx.Keyspace(), ctx.IndexName(), ctx.IfExist() != nil)
+}
+
+func (e *referenceExtractor) EnterDropFunction(ctx *parser.DropFunctionContext) {
+	e.enterDrop("function", ctx.Keyspace(), ctx.Function_(), ctx.IfExist() != nil)
+}
+
+func (e *referenceExtractor) EnterDropAggregate(ctx *parser.DropAggregateContext) {
+	e.enterDrop("aggregate", ctx.Keyspace(), ctx.Aggregate(), ctx.IfExist() != nil)
+}
+
+func (e *referenceExtractor) enterDrop(kind string, keyspace parser.IKeyspaceContext, name antlr.ParserRuleContext, ifExists bool) {
+	if name == nil {
+		return
+	}
+	e.enterKeyspace(keyspace)
+	e.refs.drop = &dropStatement{
+		kind:     kind,
+		keyspace: e.refs.Keyspace,
+		name:     strings.Trim(strings.Trim(name.GetText(), "\""), "'"),
+		ifExists: ifExists,
+		position: positionOf(name.GetStart()),
+	}
+	if kind == "keyspace" {
+		e.refs.drop.keyspace = e.refs.drop.name
+	}
+}
+
+// enterKeyspace records the keyspace of a DDL statement, if it names one.
+func (e *referenceExtractor) enterKeyspace(ctx parser.IKeyspaceContext) {
+	if ctx != nil {
+		e.refs.Keyspace = strings.Trim(ctx.GetText(), "\"")
+	}
+}
+
+func (e *referenceExtractor) EnterCreateMaterializedView(ctx *parser.CreateMaterializedViewContext) {
+	e.refs.view = viewDefinitionOf(ctx)
+}
+
+// Bind marker handling
+
+func (e *referenceExtractor) EnterConstant(ctx *parser.ConstantContext) {
+	if ctx.QMARK() == nil && ctx.NamedMarker() == nil {
+		return
+	}
+	m := e.addMarker(ctx.GetStart(), ctx.NamedMarker(), markerRole(ctx))
+	switch {
+	case m.Role == BindTimeout:
+		m.CQLType = "duration"
+	case isTokenRelation(ctx.GetParent()):
+		m.CQLType = "bigint"
+	}
+}
+
+// enterMarker records the ? or :name marker of a rule that takes one in
+// place of a value: LIMIT, TTL, TIMESTAMP and IN ?. It returns nil if the
+// rule has a literal value.
+func (e *referenceExtractor) enterMarker(qmark antlr.TerminalNode, named parser.INamedMarkerContext, role BindRole) *BindMarker {
+	switch {
+	case qmark != nil:
+		return e.addMarker(qmark.GetSymbol(), nil, role)
+	case named != nil:
+		return e.addMarker(named.GetStart(), named, role)
+	}
+	return nil
+}
+
+// addMarker records the bind marker starting at tok, named if named is not nil.
+func (e *referenceExtractor) addMarker(tok antlr.Token, named parser.INamedMarkerContext, role BindRole) *BindMarker {
+	m := &BindMarker{
+		Index:    e.markers,
+		Position: positionOf(tok),
+		Role:     role,
+	}
+	if named != nil {
+		m.Name = extractColumnName(named.OBJECT_NAME().GetText())
+	}
+	e.markers++
+	e.refs.markers = append(e.refs.markers, m)
+	return m
+}
+
 // Column reference handling
 
 func (e *referenceExtractor) EnterColumn(ctx *parser.ColumnContext) {
@@ -221,20 +754,7 @@ func (e *referenceExtractor) EnterColumnRef(ctx *parser.ColumnRefContext) {
 // Function call handling
 
 func (e *referenceExtractor) EnterFunctionCall(ctx *parser.FunctionCallContext) {
-	// Extract function name
-	var fnName string
-	if objName := ctx.OBJECT_NAME(); objName != nil {
-		fnName = strings.ToLower(objName.GetText())
-	} else if uuid := ctx.K_UUID(); uuid != nil {
-		fnName = "uuid"
-	} else if token := ctx.KwToken(); token != nil {
-		fnName = "token"
-	} else if ttl := ctx.KwTtl(); ttl != nil {
-		fnName = "ttl"
-	} else if writetime := ctx.KwWritetime(); writetime != nil {
-		fnName = "writetime"
-	}
-
+	fnName := functionName(ctx)
 	if fnName == "" {
 		return
 	}
@@ -246,12 +766,9 @@ func (e *referenceExtractor) EnterFunctionCall(ctx *parser.FunctionCallContext)
 
 	// Create detailed function call info
 	fc := &FunctionCall{
-		Name: fnName,
-		Position: &Position{
-			Line:   ctx.GetStart().GetLine(),
-			Column: ctx.GetStart().GetColumn(),
-			Offset: ctx.GetStart().GetStart(),
-		},
+		Name:     fnName,
+		Position: positionOf(ctx.GetStart()),
+		args:     functionValue(ctx).args,
 	}
 
 	// Check for star argument (e.g., count(*))
@@ -268,11 +785,45 @@ func (e *referenceExtractor) EnterFunctionCall(ctx *parser.FunctionCallContext)
 		argCount += len(argsCtx.AllFunctionCall())
 		argCount += len(argsCtx.AllQualifiedFunctionCall())
 		fc.ArgCount = argCount
+	} else {
+		// writetime(col) and ttl(col) take a column name
+		fc.ArgCount = len(fc.args)
 	}
 
 	e.refs.FunctionCalls = append(e.refs.FunctionCalls, fc)
 }
 
+func (e *referenceExtractor) EnterQualifiedFunctionCall(ctx *parser.QualifiedFunctionCallContext) {
+	call := qualifiedFunctionValue(ctx)
+	if !contains(e.refs.Functions, call.name) {
+		e.refs.Functions = append(e.refs.Functions, call.name)
+	}
+	e.refs.FunctionCalls = append(e.refs.FunctionCalls, &FunctionCall{
+		Name:     call.name,
+		Keyspace: call.keyspace,
+		ArgCount: len(call.args),
+		Position: call.position,
+		args:     call.args,
+	})
+}
+
+// functionName returns the lowercase name of a called function.
+func functionName(ctx parser.IFunctionCallContext) string {
+	switch {
+	case ctx.K_UUID() != nil:
+		return "uuid"
+	case ctx.KwToken() != nil:
+		return "token"
+	case ctx.KwTtl() != nil:
+		return "ttl"
+	case ctx.KwWritetime() != nil:
+		return "writetime"
+	case ctx.OBJECT_NAME() != nil:
+		return strings.ToLower(ctx.OBJECT_NAME().GetText())
+	}
+	return ""
+}
+
 // ALLOW FILTERING handling
 
 func (e *referenceExtractor) EnterAllowFilteringSpec(ctx *parser.AllowFilteringSpecContext) {
@@ -282,6 +833,11 @@ func (e *referenceExtractor) EnterAllowFilteringSpec(ctx *parser.AllowFilteringS
 // LIMIT handling
 
 func (e *referenceExtractor) EnterLimitSpec(ctx *parser.LimitSpecContext) {
+	if m := e.enterMarker(ctx.QMARK(), ctx.NamedMarker(), BindLimit); m != nil {
+		m.CQLType = "int"
+		e.refs.Limit = 0
+		return
+	}
 	// Extract limit value
 	text := ctx.GetText()
 	// Remove "LIMIT" prefix
@@ -292,6 +848,12 @@ func (e *referenceExtractor) EnterLimitSpec(ctx *parser.LimitSpecContext) {
 	}
 }
 
+func (e *referenceExtractor) EnterPerPartitionLimitSpec(ctx *parser.PerPartitionLimitSpecContext) {
+	if m := e.enterMarker(ctx.QMARK(), ctx.NamedMarker(), BindLimit); m != nil {
+		m.CQLType = "int"
+	}
+}
+
 // Helper methods
 
 func (e *referenceExtractor) addColumn(name string) {
@@ -301,6 +863,26 @@ func (e *referenceExtractor) addColumn(name string) {
 	}
 }
 
+func (e *referenceExtractor) addValue(column antlr.ParseTree, target valueTarget, v *value) {
+	name := extractColumnName(column.GetText())
+	if name == "" {
+		return
+	}
+	e.refs.values = append(e.refs.values, &columnValue{column: name, target: target, value: v})
+}
+
+// addField records the selection of a field of a UDT column and returns the
+// name of the field.
+func (e *referenceExtractor) addField(column, field antlr.ParseTree, start antlr.Token) string {
+	name := extractColumnName(field.GetText())
+	e.refs.fields = append(e.refs.fields, &fieldSelection{
+		column:   extractColumnName(column.GetText()),
+		field:    name,
+		position: positionOf(start),
+	})
+	return name
+}
+
 func extractColumnName(text string) string {
 	// Remove quotes and whitespace
 	text = strings.TrimSpace(text)
@@ -2,9 +2,11 @@ package analyze
 
 import (
 	"fmt"
+	"sort"
 	"strings"
 
 	"github.com/tentacle-scylla/scql/gen/cqldata"
+	"github.com/tentacle-scylla/scql/pkg/schema"
 )
 
 // FunctionSignature describes the expected arguments for a built-in function.
@@ -138,11 +140,11 @@ func ValidateFunctionCalls(calls []*FunctionCall) []*SchemaError {
 
 // validateFunctionCall validates a single function call.
 func validateFunctionCall(call *FunctionCall) *SchemaError {
-	sig, ok := builtinFunctions[call.Name]
-	if !ok {
-		// Unknown function - could be a UDF, don't error
+	if !isBuiltin(call.Keyspace, call.Name) {
+		// Unknown function - could be a UDF, checked against the schema if any
 		return nil
 	}
+	sig := builtinFunctions[call.Name]
 
 	// Handle star argument (e.g., count(*))
 	if call.HasStar {
@@ -194,6 +196,323 @@ func validateFunctionCall(call *FunctionCall) *SchemaError {
 	return nil
 }
 
+// validateFunctionTypes resolves each function call against the overloads
+// of a builtin function, or against a user-defined function or aggregate of
+// the schema, using the types of its arguments: literals, columns of the
+// table and the results of nested calls. With a schema, calls to functions
+// that are neither builtin nor user-defined are reported. Wrong numbers of
+// arguments to builtins are left to ValidateFunctionCalls.
+func validateFunctionTypes(result *Result, sc *scope) {
+	for _, call := range result.References.FunctionCalls {
+		if isBuiltin(call.Keyspace, call.Name) {
+			if err := validateBuiltinCall(call, sc); err != nil {
+				result.SchemaErrors = append(result.SchemaErrors, err)
+			}
+			continue
+		}
+		if err := validateUserCall(call, sc); err != nil {
+			result.SchemaErrors = append(result.SchemaErrors, err)
+		}
+	}
+}
+
+// validateBuiltinCall resolves a builtin function call against the overloads
+// of the function.
+func validateBuiltinCall(call *FunctionCall, sc *scope) *SchemaError {
+	if call.HasStar || validateFunctionCall(call) != nil {
+		return nil
+	}
+	candidates := overloads(call.Name, len(call.args))
+	if len(candidates) == 0 {
+		return nil
+	}
+	for _, arg := range call.args {
+		sc.resolve(arg)
+	}
+	if len(matchingOverloads(candidates, call.args)) > 0 {
+		return nil
+	}
+
+	known := make([]string, 0, len(candidates))
+	for _, f := range overloads(call.Name, -1) {
+		known = append(known, fmt.Sprintf("system.%s : (%s) -> %s", f.Name, strings.Join(f.Params, ", "), f.ReturnType))
+	}
+	argTypes := make([]string, len(call.args))
+	for i, arg := range call.args {
+		argTypes[i] = argumentType(arg)
+	}
+	return &SchemaError{
+		Type: ErrNoMatchingOverload,
+		Message: fmt.Sprintf("Invalid call to function %s, none of its type signatures match (known type signatures: %s)",
+			call.Name, strings.Join(known, ", ")),
+		Suggestion: fmt.Sprintf("Called with (%s)", strings.Join(argTypes, ", ")),
+		Object:     call.Name,
+		Position:   call.Position,
+	}
+}
+
+// validateUserCall checks a call to a user-defined function or aggregate.
+// Without a schema, or without a keyspace for an unqualified call, nothing is
+// checked. Qualified calls are only matched to the functions of their
+// keyspace.
+func validateUserCall(call *FunctionCall, sc *scope) *SchemaError {
+	name := call.Name
+	if call.Keyspace != "" {
+		name = call.Keyspace + "." + call.Name
+	}
+	unknownFunction := func(ks *schema.Keyspace) *SchemaError {
+		return &SchemaError{
+			Type:       ErrUnknownFunction,
+			Message:    fmt.Sprintf("Unknown function '%s'", name),
+			Suggestion: suggestFunction(ks, call.Name, call.Keyspace == "" || strings.EqualFold(call.Keyspace, "system")),
+			Object:     name,
+			Position:   call.Position,
+		}
+	}
+
+	ks := sc.functionKeyspace(call.Keyspace)
+	switch {
+	case ks != nil:
+	case call.Keyspace == "" || sc.schema == nil:
+		return nil
+	case strings.EqualFold(call.Keyspace, "system"):
+		// The keyspace of the builtins
+		return unknownFunction(nil)
+	default:
+		return &SchemaError{
+			Type:       ErrUnknownKeyspace,
+			Message:    fmt.Sprintf("Keyspace '%s' does not exist", call.Keyspace),
+			Suggestion: suggestKeyspace(sc.schema, call.Keyspace),
+			Object:     call.Keyspace,
+			Position:   call.Position,
+		}
+	}
+
+	fn := userFunctionOf(ks, call.Name)
+	if fn == nil {
+		return unknownFunction(ks)
+	}
+	if len(call.args) != len(fn.params) {
+		return &SchemaError{
+			Type:     ErrFunctionArgCount,
+			Message:  fmt.Sprintf("%s() takes %d argument(s), got %d", name, len(fn.params), len(call.args)),
+			Object:   name,
+			Position: call.Position,
+		}
+	}
+	for i, arg := range call.args {
+		sc.resolve(arg)
+		if !assignable(parseCQLType(fn.params[i]), arg) {
+			return &SchemaError{
+				Type: ErrTypeMismatch,
+				Message: fmt.Sprintf("Type error: %s cannot be passed as argument %d of function %s.%s of type %s",
+					arg.text, i, ks.Name, call.Name, parseCQLType(fn.params[i])),
+				Suggestion: fmt.Sprintf("Called with (%s)", argumentType(arg)),
+				Object:     name,
+				Position:   arg.position,
+			}
+		}
+	}
+	return nil
+}
+
+// userFunction is the signature of a user-defined function or aggregate.
+type userFunction struct {
+	params     []string // Parameter types
+	returnType string
+}
+
+// userFunctionOf returns the user-defined function or aggregate of ks with the
+// given name, or nil.
+func userFunctionOf(ks *schema.Keyspace, name string) *userFunction {
+	if fn := ks.GetFunction(name); fn != nil {
+		uf := &userFunction{returnType: fn.ReturnType}
+		for _, p := range fn.Parameters {
+			uf.params = append(uf.params, p.Type)
+		}
+		return uf
+	}
+	if agg := ks.GetAggregate(name); agg != nil {
+		return &userFunction{params: agg.Parameters, returnType: agg.ReturnType}
+	}
+	return nil
+}
+
+// isBuiltin reports whether a call, qualified by keyspace if not empty, is to
+// a builtin function. Builtins live in the system keyspace.
+func isBuiltin(keyspace, name string) bool {
+	return (keyspace == "" || strings.EqualFold(keyspace, "system")) && builtinFunctions[name] != nil
+}
+
+// suggestFunction suggests the user-defined function of ks, or the builtin
+// if builtins is set, closest to name. ks may be nil.
+func suggestFunction(ks *schema.Keyspace, name string, builtins bool) string {
+	var names []string
+	if builtins {
+		for n := range builtinFunctions {
+			names = append(names, n)
+		}
+	}
+	if ks != nil {
+		for n := range ks.Functions {
+			names = append(names, n)
+		}
+		for n := range ks.Aggregates {
+			names = append(names, n)
+		}
+	}
+	sort.Strings(names)
+	if suggestion := findClosest(name, names); suggestion != "" {
+		return fmt.Sprintf("Did you mean '%s'?", suggestion)
+	}
+	return ""
+}
+
+// overloads returns the builtin overloads of a function taking argCount
+// arguments, or all of them if argCount is negative.
+func overloads(name string, argCount int) []cqldata.GenFunctionDef {
+	var defs []cqldata.GenFunctionDef
+	for _, f := range cqldata.GenFunctions {
+		if strings.EqualFold(f.Name, name) && (argCount < 0 || len(f.Params) == argCount) {
+			defs = append(defs, f)
+		}
+	}
+	return defs
+}
+
+// matchingOverloads returns the overloads whose parameters accept args.
+func matchingOverloads(candidates []cqldata.GenFunctionDef, args []*value) []cqldata.GenFunctionDef {
+	var matching []cqldata.GenFunctionDef
+	for _, f := range candidates {
+		matches := true
+		for i, param := range f.Params {
+			if !parameterAccepts(param, args[i]) {
+				matches = false
+				break
+			}
+		}
+		if matches {
+			matching = append(matching, f)
+		}
+	}
+	return matching
+}
+
+// numericTypes are the types accepted by "number" parameters (avg, sum).
+var numericTypes = []string{"tinyint", "smallint", "int", "bigint", "varint", "counter", "decimal", "float", "double"}
+
+// parameterAccepts reports whether a builtin function parameter accepts arg.
+// Blob parameters only take blobs: Scylla would reinterpret any value as a
+// blob, which is never what a blobAs* call on a non-blob column means.
+func parameterAccepts(param string, arg *value) bool {
+	switch param {
+	case "any", "column", "partition_key", "type":
+		return true
+	case "number":
+		for _, t := range numericTypes {
+			if parameterAccepts(t, arg) {
+				return true
+			}
+		}
+		return false
+	case "blob":
+		if arg.kind == valueTyped {
+			return arg.typ == "blob"
+		}
+	}
+	return assignable(parseCQLType(param), arg)
+}
+
+// callType returns the return type of a function called with args, or "" if
+// it is unknown. Builtin calls are typed by their single matching overload;
+// builtins returning "any" or "number" return the type of their argument.
+func (sc *scope) callType(keyspace, name string, args []*value) string {
+	if !isBuiltin(keyspace, name) {
+		ks := sc.functionKeyspace(keyspace)
+		if ks == nil {
+			return ""
+		}
+		fn := userFunctionOf(ks, name)
+		if fn == nil || len(fn.params) != len(args) {
+			return ""
+		}
+		return parseCQLType(fn.returnType).String()
+	}
+
+	typ := ""
+	for _, f := range matchingOverloads(overloads(name, len(args)), args) {
+		ret := f.ReturnType
+		if (ret == "any" || ret == "number") && len(args) == 1 && args[0].kind == valueTyped {
+			ret = args[0].typ
+		}
+		if !knownTypes[parseCQLType(ret).name] || (typ != "" && typ != ret) {
+			return ""
+		}
+		typ = ret
+	}
+	return typ
+}
+
+// parameterTypes returns the parameter types of a function call, "" where it
+// is unknown: those of the user-defined function or aggregate, or those on
+// which the builtin overloads matching the arguments agree.
+func (sc *scope) parameterTypes(call *FunctionCall) []string {
+	params := make([]string, len(call.args))
+	if !isBuiltin(call.Keyspace, call.Name) {
+		ks := sc.functionKeyspace(call.Keyspace)
+		if ks == nil {
+			return params
+		}
+		if fn := userFunctionOf(ks, call.Name); fn != nil && len(fn.params) == len(call.args) {
+			for i, p := range fn.params {
+				params[i] = parseCQLType(p).String()
+			}
+		}
+		return params
+	}
+
+	for _, arg := range call.args {
+		sc.resolve(arg)
+	}
+	for n, f := range matchingOverloads(overloads(call.Name, len(call.args)), call.args) {
+		for i, p := range f.Params {
+			if !knownTypes[parseCQLType(p).name] {
+				p = ""
+			}
+			switch {
+			case n == 0:
+				params[i] = p
+			case params[i] != p:
+				params[i] = ""
+			}
+		}
+	}
+	return params
+}
+
+// functionKeyspace returns the keyspace of a call qualified by keyspace, or of
+// an unqualified call, or nil.
+func (sc *scope) functionKeyspace(keyspace string) *schema.Keyspace {
+	if keyspace == "" {
+		return sc.keyspace
+	}
+	return sc.schema.GetKeyspace(keyspace)
+}
+
+// argumentType describes the type of a resolved argument for messages.
+func argumentType(arg *value) string {
+	switch arg.kind {
+	case valueTyped:
+		return arg.typ
+	case valueUnknown, valueNull:
+		return "?"
+	}
+	if name, ok := constantNames[arg.kind]; ok {
+		return strings.ToLower(name) + " literal"
+	}
+	return "literal"
+}
+
 // GetFunctionSignature returns the signature for a built-in function, or nil if unknown.
 func GetFunctionSignature(name string) *FunctionSignature {
 	return builtinFunctions[strings.ToLower(name)]
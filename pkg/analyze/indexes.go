@@ -0,0 +1,149 @@
+package analyze
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/tentacle-scylla/scql/pkg/schema"
+)
+
+// indexedRelation returns the first relation that a secondary index of tbl
+// serves, or nil. Scylla reads through a single index per query; the other
+// restrictions are filtered.
+func indexedRelation(relations []*relation, tbl *schema.Table, pkComplete bool) *relation {
+	for _, r := range relations {
+		if r.token || len(r.columns) != 1 {
+			continue
+		}
+		col := tbl.GetColumn(r.columns[0])
+		if col == nil {
+			continue
+		}
+		for _, idx := range tbl.Indexes {
+			if strings.EqualFold(idx.TargetColumn, col.Name) && indexServes(idx, col, r, pkComplete) {
+				return r
+			}
+		}
+	}
+	return nil
+}
+
+// indexServes reports whether idx, an index on col, serves r. Indexes on the
+// values of a collection serve CONTAINS, keys() indexes serve CONTAINS KEY and
+// full() indexes and indexes on other columns serve =. Local indexes also need
+// the partition key. Custom indexes are not used for restrictions.
+func indexServes(idx *schema.Index, col *schema.Column, r *relation, pkComplete bool) bool {
+	if idx.Kind == "CUSTOM" {
+		return false
+	}
+	target := strings.ToLower(idx.Options["target"])
+	switch {
+	case strings.HasPrefix(target, "{"):
+		return pkComplete && r.op == "="
+	case strings.HasPrefix(target, "keys("):
+		return r.op == "CONTAINS KEY"
+	case strings.HasPrefix(target, "entries("):
+		return false
+	case strings.HasPrefix(target, "full("):
+		return r.op == "="
+	}
+	switch parseCQLType(col.Type).name {
+	case "list", "set", "map":
+		if !isFrozenType(col.Type) {
+			return r.op == "CONTAINS"
+		}
+	}
+	return r.op == "="
+}
+
+// servingView returns a materialized view of tbl whose primary key serves the
+// restrictions of the query without filtering, and that has the selected
+// columns, or nil: = or IN on its whole partition key, and a prefix of its
+// clustering columns where only the last one is a slice.
+func servingView(refs *References, tbl *schema.Table) *schema.MaterializedView {
+	if len(refs.relations) == 0 {
+		return nil
+	}
+	restricted := make(map[string][]*relation)
+	for _, r := range refs.relations {
+		if r.token || (!r.isEQ() && !r.isSlice()) {
+			return nil
+		}
+		for _, name := range r.columns {
+			restricted[strings.ToLower(name)] = append(restricted[strings.ToLower(name)], r)
+		}
+	}
+
+	names := make([]string, 0, len(tbl.MaterializedViews))
+	for name := range tbl.MaterializedViews {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		mv := tbl.MaterializedViews[name]
+		if viewServes(mv, restricted) && viewHasColumns(mv, tbl, refs.SelectColumns) {
+			return mv
+		}
+	}
+	return nil
+}
+
+// viewServes reports whether the primary key of mv serves the restrictions.
+func viewServes(mv *schema.MaterializedView, restricted map[string][]*relation) bool {
+	used := 0
+	for _, name := range mv.PartitionKey {
+		rels := restricted[strings.ToLower(name)]
+		if len(rels) == 0 {
+			return false
+		}
+		for _, r := range rels {
+			if !r.isEQ() || len(r.columns) != 1 {
+				return false
+			}
+		}
+		used++
+	}
+	gap, slice := false, false
+	for _, name := range mv.ClusteringKey {
+		rels := restricted[strings.ToLower(name)]
+		if len(rels) == 0 {
+			gap = true
+			continue
+		}
+		if gap || slice {
+			return false
+		}
+		for _, r := range rels {
+			if r.isSlice() {
+				slice = true
+			}
+		}
+		used++
+	}
+	// Every restricted column is part of the view primary key
+	return used == len(restricted)
+}
+
+// viewHasColumns reports whether mv has the selected columns.
+func viewHasColumns(mv *schema.MaterializedView, tbl *schema.Table, selected []string) bool {
+	for _, name := range selected {
+		if name == "*" {
+			for _, col := range tbl.AllColumns() {
+				if mv.Columns[col.Name] == nil {
+					return false
+				}
+			}
+			continue
+		}
+		if col := tbl.GetColumn(name); col != nil && mv.Columns[col.Name] == nil {
+			return false
+		}
+	}
+	return true
+}
+
+// viewSuggestion suggests querying view instead of filtering.
+func viewSuggestion(view *schema.MaterializedView) string {
+	return fmt.Sprintf("Query materialized view '%s' instead, its primary key serves this query", view.Name)
+}
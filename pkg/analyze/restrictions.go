@@ -0,0 +1,398 @@
+package analyze
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/antlr4-go/antlr/v4"
+	parser "github.com/tentacle-scylla/scql/gen/parser"
+	"github.com/tentacle-scylla/scql/pkg/schema"
+	"github.com/tentacle-scylla/scql/pkg/types"
+)
+
+// relation is a restriction of the WHERE clause.
+type relation struct {
+	columns  []string // Restricted columns, several for (a, b) = (1, 2)
+	op       string   // =, <, >, <=, >=, IN, CONTAINS, CONTAINS KEY or LIKE
+	token    bool     // token(columns) op value
+	text     string   // Source text
+	position *Position
+}
+
+// isEQ reports whether the relation selects single values: = or IN.
+func (r *relation) isEQ() bool {
+	return r.op == "=" || r.op == "IN"
+}
+
+// isSlice reports whether the relation selects a range: <, >, <= or >=.
+func (r *relation) isSlice() bool {
+	switch r.op {
+	case "<", ">", "<=", ">=":
+		return true
+	}
+	return false
+}
+
+// relationOf returns the restriction of a WHERE relation, or nil for
+// relations that are not checked (UDT fields, SCYLLA_CLUSTERING_BOUND and
+// functions other than token()).
+func relationOf(ctx *parser.RelationElementContext) *relation {
+	r := &relation{text: sourceText(ctx), position: positionOf(ctx.GetStart())}
+	switch {
+	case ctx.RelalationContains() != nil:
+		r.op = "CONTAINS"
+		r.columns = []string{extractColumnName(ctx.RelalationContains().ColumnRef().GetText())}
+		return r
+	case ctx.RelalationContainsKey() != nil:
+		r.op = "CONTAINS KEY"
+		r.columns = []string{extractColumnName(ctx.RelalationContainsKey().ColumnRef().GetText())}
+		return r
+	case ctx.KwIn() != nil:
+		r.op = "IN"
+	case ctx.KwLike() != nil:
+		r.op = "LIKE"
+	default:
+		for _, op := range []antlr.TerminalNode{ctx.OPERATOR_EQ(), ctx.OPERATOR_LT(), ctx.OPERATOR_GT(), ctx.OPERATOR_LTE(), ctx.OPERATOR_GTE()} {
+			if op != nil {
+				r.op = op.GetText()
+			}
+		}
+	}
+	if ctx.DOT() != nil || ctx.ScyllaClusteringBound() != nil {
+		return nil
+	}
+
+	if columns := ctx.AllColumnRef(); len(columns) > 0 {
+		for _, column := range columns {
+			r.columns = append(r.columns, extractColumnName(column.GetText()))
+		}
+		return r
+	}
+	call := ctx.FunctionCall(0)
+	if call == nil || call.KwToken() == nil {
+		return nil
+	}
+	r.token = true
+	for _, arg := range functionValue(call).args {
+		if arg.kind != valueColumn {
+			return nil
+		}
+		r.columns = append(r.columns, arg.name)
+	}
+	return r
+}
+
+// inClusteringOrder reports whether the clustering columns of a relation
+// follow each other in PRIMARY KEY order: (a, b) = (1, 2) but not (b, a).
+func inClusteringOrder(columns []string, tbl *schema.Table) bool {
+	prev := -1
+	for _, name := range columns {
+		col := tbl.GetColumn(name)
+		for i, ck := range tbl.ClusteringKey {
+			if ck != col.Name {
+				continue
+			}
+			if prev >= 0 && i != prev+1 {
+				return false
+			}
+			prev = i
+		}
+	}
+	return true
+}
+
+// requiresFilteringMessage is Scylla's error for queries that need ALLOW FILTERING.
+const requiresFilteringMessage = "Cannot execute this query as it might involve data filtering and thus may have unpredictable performance. " +
+	"If you want to execute this query despite the performance unpredictability, use ALLOW FILTERING"
+
+// validateRestrictions checks the WHERE clause of SELECT, UPDATE and DELETE
+// statements against the restrictions Scylla supports on the primary key:
+// = or IN on every partition key column, or token() ranges, but not both; a
+// prefix of the clustering columns, of which only the last may be restricted
+// by a slice, named in order by multi-column relations.
+// Restrictions that Scylla can only serve by filtering are reported unless
+// the query uses ALLOW FILTERING.
+func validateRestrictions(result *Result, tbl *schema.Table) {
+	stmt := result.Type
+	if stmt != types.StatementSelect && stmt != types.StatementUpdate && stmt != types.StatementDelete {
+		return
+	}
+	refs := result.References
+	mutation := stmt != types.StatementSelect
+	filtering := refs.HasAllowFiltering
+
+	// filtered are the relations that need filtering; the first one that is
+	// not served by an index or already reported is reported
+	var filtered []*relation
+	reported := make(map[*relation]bool)
+	needsFiltering := func(r *relation) {
+		filtered = append(filtered, r)
+	}
+	// A view whose primary key serves the query is suggested instead of filtering
+	view := servingView(refs, tbl)
+	withView := func(suggestion string) string {
+		if view != nil {
+			return viewSuggestion(view)
+		}
+		return suggestion
+	}
+	schemaError := func(r *relation, format string, args ...any) {
+		reported[r] = true
+		result.SchemaErrors = append(result.SchemaErrors, &SchemaError{
+			Type:     ErrInvalidRestriction,
+			Message:  fmt.Sprintf(format, args...),
+			Object:   r.columns[0],
+			Position: r.position,
+		})
+	}
+	warning := func(typ WarningType, r *relation, message, suggestion string) {
+		w := &Warning{Type: typ, Severity: SeverityError, Message: message, Suggestion: suggestion}
+		if r != nil {
+			reported[r] = true
+			w.Position = r.position
+		}
+		result.Warnings = append(result.Warnings, w)
+	}
+
+	// Restrictions of each primary key column, and of the other columns
+	restricted := make(map[string][]*relation)
+	var token *relation
+	var regular []string
+	for _, r := range refs.relations {
+		if r.token {
+			if !sameColumns(r.columns, tbl.PartitionKey) {
+				schemaError(r, "The token function arguments must be in the partition key order: %s", strings.Join(tbl.PartitionKey, ", "))
+			} else if mutation {
+				schemaError(r, "The token function cannot be used in WHERE clauses for %s statements", stmt)
+			}
+			token = r
+			continue
+		}
+
+		valid := true
+		for _, name := range r.columns {
+			col := tbl.GetColumn(name)
+			if col == nil {
+				// Reported as an unknown column
+				valid = false
+				break
+			}
+			t := parseCQLType(col.Type)
+			switch {
+			case r.op == "CONTAINS" && t.name != "list" && t.name != "set" && t.name != "map":
+				schemaError(r, "Cannot use CONTAINS on non-collection column %s", col.Name)
+				valid = false
+			case r.op == "CONTAINS KEY" && t.name != "map":
+				schemaError(r, "Cannot use CONTAINS KEY on non-map column %s", col.Name)
+				valid = false
+			}
+			if !valid {
+				break
+			}
+			for _, other := range restricted[col.Name] {
+				if r.isEQ() || other.isEQ() {
+					kind := "an Equal"
+					if r.op == "IN" || (other.op == "IN" && !r.isEQ()) {
+						kind = "a IN"
+					}
+					schemaError(r, "%s cannot be restricted by more than one relation if it includes %s", col.Name, kind)
+					valid = false
+					break
+				}
+			}
+		}
+		if !valid {
+			continue
+		}
+		if !inClusteringOrder(r.columns, tbl) {
+			schemaError(r, "Clustering columns must appear in the PRIMARY KEY order in multi-column relations: %s", r.text)
+			continue
+		}
+		for _, name := range r.columns {
+			col := tbl.GetColumn(name)
+			restricted[col.Name] = append(restricted[col.Name], r)
+			if !col.IsPartitionKey && !col.IsClusteringKey {
+				if mutation {
+					regular = append(regular, col.Name)
+				} else {
+					needsFiltering(r)
+				}
+			}
+		}
+	}
+	if len(regular) > 0 {
+		result.SchemaErrors = append(result.SchemaErrors, &SchemaError{
+			Type:     ErrInvalidRestriction,
+			Message:  fmt.Sprintf("Non PRIMARY KEY columns found in where clause: %s", strings.Join(regular, ", ")),
+			Object:   regular[0],
+			Position: restricted[regular[0]][0].position,
+		})
+	}
+
+	// Partition key: token() ranges, or = or IN on every column
+	if token != nil {
+		var normal []string
+		later := token
+		for _, name := range tbl.PartitionKey {
+			for _, r := range restricted[name] {
+				if len(normal) == 0 || normal[len(normal)-1] != name {
+					normal = append(normal, name)
+				}
+				if r.position.Offset > later.position.Offset {
+					later = r
+				}
+			}
+		}
+		if len(normal) > 0 {
+			schemaError(later, "Columns \"%s\" cannot be restricted by both a normal relation and a token relation", strings.Join(normal, ", "))
+		}
+	}
+	var missing []string
+	pkComplete := token == nil
+	for _, name := range tbl.PartitionKey {
+		rels := restricted[name]
+		if len(rels) == 0 {
+			missing = append(missing, name)
+			pkComplete = false
+			continue
+		}
+		for _, r := range rels {
+			if r.isEQ() {
+				continue
+			}
+			pkComplete = false
+			switch {
+			case mutation:
+				schemaError(r, "Only EQ and IN relation are supported on the partition key (unless you use the token() function)")
+			case !filtering:
+				warning(WarnAllowFilteringNeeded, r,
+					"Only EQ and IN relation are supported on the partition key (unless you use the token() function or allow filtering)",
+					withView("Restrict the partition key with = or IN, use token() ranges or add ALLOW FILTERING"))
+			}
+		}
+	}
+	if len(missing) > 0 && token == nil {
+		switch {
+		case mutation:
+			warning(WarnMissingPartitionKey, nil,
+				fmt.Sprintf("Some partition key parts are missing: %s", strings.Join(missing, ", ")),
+				"All partition key columns must be specified")
+		default:
+			w := &Warning{
+				Type:       WarnMissingPartitionKey,
+				Severity:   SeverityWarning,
+				Message:    fmt.Sprintf("Query is missing partition key column(s): %s", strings.Join(missing, ", ")),
+				Suggestion: "Add partition key columns to WHERE clause to read a single partition",
+			}
+			result.Warnings = append(result.Warnings, w)
+			for _, name := range tbl.PartitionKey {
+				for _, r := range restricted[name] {
+					needsFiltering(r)
+				}
+			}
+		}
+	}
+
+	// Scylla serves one restriction with a secondary index, without filtering
+	indexed := indexedRelation(refs.relations, tbl, pkComplete)
+
+	// Clustering columns: a prefix, where only the last column may be a slice
+	var unrestricted string // First clustering column without restriction
+	var slice *relation     // Slice on a preceding clustering column
+	var sliced string       // Column of slice
+	var clustering []*relation
+	for _, name := range tbl.ClusteringKey {
+		rels := restricted[name]
+		if len(rels) == 0 {
+			if unrestricted == "" {
+				unrestricted = name
+			}
+			continue
+		}
+		var columnSlice *relation
+		for _, r := range rels {
+			clustering = append(clustering, r)
+			switch {
+			case r == indexed:
+			case !pkComplete && !mutation:
+				// Clustering restrictions without a partition are filtered
+				needsFiltering(r)
+			case !r.isEQ() && !r.isSlice():
+				needsFiltering(r)
+			case unrestricted != "":
+				if !filtering {
+					warning(WarnMissingClusteringKey, r,
+						fmt.Sprintf("PRIMARY KEY column \"%s\" cannot be restricted as preceding column \"%s\" is not restricted", name, unrestricted),
+						withView("Restrict the preceding clustering columns, or add ALLOW FILTERING"))
+				}
+			case slice != nil && slice != r:
+				if !filtering {
+					warning(WarnMissingClusteringKey, r,
+						fmt.Sprintf("Clustering column \"%s\" cannot be restricted (preceding column \"%s\" is restricted by a non-EQ relation)", name, sliced),
+						withView("Only the last restricted clustering column can use a range, or add ALLOW FILTERING"))
+				}
+			}
+			if r.isSlice() {
+				columnSlice = r
+			}
+		}
+		if columnSlice != nil {
+			slice, sliced = columnSlice, name
+		}
+	}
+	if stmt == types.StatementUpdate && len(tbl.ClusteringKey) > 0 && !onlyStaticColumns(refs.UpdateColumns, tbl) {
+		for _, r := range clustering {
+			if r.isSlice() {
+				schemaError(r, "Slice restrictions are not supported on the clustering columns in UPDATE statements")
+			}
+		}
+		for _, name := range tbl.ClusteringKey {
+			if len(restricted[name]) == 0 {
+				warning(WarnMissingClusteringKey, nil, fmt.Sprintf("Missing mandatory PRIMARY KEY part %s", name),
+					"UPDATE must restrict every clustering column with = or IN")
+				break
+			}
+		}
+	}
+
+	if filtering {
+		return
+	}
+	for _, r := range filtered {
+		if r == indexed || reported[r] {
+			continue
+		}
+		suggestion := "Restrict the primary key, or add ALLOW FILTERING"
+		if r.op == "=" || r.op == "CONTAINS" || r.op == "CONTAINS KEY" {
+			suggestion = fmt.Sprintf("Restrict the primary key, create an index on %s, or add ALLOW FILTERING", r.columns[0])
+		}
+		warning(WarnAllowFilteringNeeded, r, requiresFilteringMessage, withView(suggestion))
+		break
+	}
+}
+
+// onlyStaticColumns reports whether every column of names is static in tbl.
+func onlyStaticColumns(names []string, tbl *schema.Table) bool {
+	if len(names) == 0 {
+		return false
+	}
+	for _, name := range names {
+		if col := tbl.GetColumn(name); col == nil || !col.IsStatic {
+			return false
+		}
+	}
+	return true
+}
+
+// sameColumns reports whether a and b name the same columns in the same order.
+func sameColumns(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if !strings.EqualFold(a[i], b[i]) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,49 @@
+package analyze
+
+import (
+	"github.com/tentacle-scylla/scql/pkg/schema"
+	"github.com/tentacle-scylla/scql/pkg/types"
+)
+
+// staticWithoutClusteringMessage is Scylla's error for static columns in a
+// table without clustering columns.
+const staticWithoutClusteringMessage = "Static columns are only useful (and thus allowed) if the table has at least one clustering column"
+
+// validateStatics checks the use of static columns, which are shared by the
+// rows of a partition: their table has clustering columns, and an UPDATE of
+// static columns only applies to a partition, so it cannot restrict
+// clustering columns.
+func validateStatics(result *Result, tbl *schema.Table) {
+	refs := result.References
+	schemaError := func(object string, position *Position, message string) {
+		result.SchemaErrors = append(result.SchemaErrors, &SchemaError{
+			Type:     ErrInvalidStatic,
+			Message:  message,
+			Object:   object,
+			Position: position,
+		})
+	}
+
+	if len(tbl.ClusteringKey) == 0 {
+		for _, name := range refs.Columns {
+			if col := tbl.GetColumn(name); col != nil && col.IsStatic {
+				schemaError(col.Name, nil, staticWithoutClusteringMessage)
+				return
+			}
+		}
+		return
+	}
+
+	if result.Type != types.StatementUpdate || !onlyStaticColumns(refs.UpdateColumns, tbl) {
+		return
+	}
+	for _, r := range refs.relations {
+		for _, name := range r.columns {
+			if col := tbl.GetColumn(name); col != nil && col.IsClusteringKey {
+				schemaError(col.Name, r.position,
+					"Invalid restrictions on clustering columns since the UPDATE statement modifies only static columns")
+				return
+			}
+		}
+	}
+}
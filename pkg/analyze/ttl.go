@@ -0,0 +1,59 @@
+package analyze
+
+import "fmt"
+
+// maxTTL is the largest TTL Scylla accepts, 20 years in seconds.
+const maxTTL = 20 * 365 * 24 * 60 * 60
+
+// validateTTL checks the value of USING TTL. Negative TTLs are syntax errors.
+func validateTTL(result *Result) {
+	refs := result.References
+	if refs.ttl == nil || refs.ttlSeconds <= maxTTL {
+		return
+	}
+	result.SchemaErrors = append(result.SchemaErrors, &SchemaError{
+		Type:     ErrInvalidTTL,
+		Message:  fmt.Sprintf("ttl is too large. requested (%s) maximum (%d)", refs.ttlText, maxTTL),
+		Position: refs.ttl,
+	})
+}
+
+// validateSelectionFunctions checks the columns of writetime() and ttl(),
+// which have no cell timestamp or TTL for primary key columns, non-frozen
+// collections or non-frozen UDTs.
+func validateSelectionFunctions(result *Result, sc *scope) {
+	for _, call := range result.References.FunctionCalls {
+		if call.Keyspace != "" || (call.Name != "writetime" && call.Name != "ttl") || len(call.args) != 1 {
+			continue
+		}
+		arg := call.args[0]
+		if arg.kind != valueColumn {
+			continue
+		}
+		col := sc.table.GetColumn(arg.name)
+		if col == nil {
+			continue
+		}
+		name := "ttl"
+		if call.Name == "writetime" {
+			name = "writeTime"
+		}
+		var message string
+		switch t := parseCQLType(col.Type); {
+		case col.IsPartitionKey || col.IsClusteringKey:
+			message = fmt.Sprintf("Cannot use selection function %s on PRIMARY KEY part %s", name, col.Name)
+		case (t.name == "list" || t.name == "set" || t.name == "map") && !isFrozenType(col.Type):
+			message = fmt.Sprintf("Cannot use selection function %s on non-frozen collection %s", name, col.Name)
+		case sc.keyspace.UserTypeOf(col.Type) != nil && !isFrozenType(col.Type):
+			message = fmt.Sprintf("Cannot use selection function %s on non-frozen UDT %s", name, col.Name)
+		default:
+			continue
+		}
+		result.SchemaErrors = append(result.SchemaErrors, &SchemaError{
+			Type:     ErrInvalidSelection,
+			Message:  message,
+			Object:   col.Name,
+			Position: arg.position,
+		})
+	}
+}
@@ -0,0 +1,743 @@
+package analyze
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/antlr4-go/antlr/v4"
+	"github.com/tentacle-scylla/scql/gen/cqldata"
+	parser "github.com/tentacle-scylla/scql/gen/parser"
+	"github.com/tentacle-scylla/scql/pkg/schema"
+)
+
+// cqlType is a parsed CQL type string such as "map<text, frozen<list<int>>>".
+type cqlType struct {
+	name   string     // Lowercase type name; frozen<> is unwrapped
+	args   []*cqlType // Element types of collections, tuples and vectors; field types of user types
+	fields []string   // Field names of a user type resolved by scope.cqlType
+}
+
+// parseCQLType parses a type string as stored in schema.Column.Type.
+func parseCQLType(s string) *cqlType {
+	t, _ := parseCQLTypeAt(s, 0)
+	return t
+}
+
+func parseCQLTypeAt(s string, i int) (*cqlType, int) {
+	start := i
+	for i < len(s) && s[i] != '<' && s[i] != '>' && s[i] != ',' {
+		i++
+	}
+	t := &cqlType{name: strings.ToLower(strings.Trim(strings.TrimSpace(s[start:i]), "\""))}
+	if i < len(s) && s[i] == '<' {
+		for i < len(s) && s[i] != '>' {
+			var arg *cqlType
+			arg, i = parseCQLTypeAt(s, i+1)
+			t.args = append(t.args, arg)
+		}
+		i++ // Skip '>'
+	}
+	if t.name == "frozen" && len(t.args) == 1 {
+		return t.args[0], i
+	}
+	return t, i
+}
+
+// isFrozenType reports whether a type string is frozen<...>.
+func isFrozenType(s string) bool {
+	return strings.HasPrefix(strings.ToLower(strings.TrimSpace(s)), "frozen")
+}
+
+func (t *cqlType) String() string {
+	if len(t.args) == 0 || t.fields != nil {
+		return t.name
+	}
+	args := make([]string, len(t.args))
+	for i, arg := range t.args {
+		args[i] = arg.String()
+	}
+	return t.name + "<" + strings.Join(args, ", ") + ">"
+}
+
+// field returns the type of a field of a user type, or nil if it has none.
+func (t *cqlType) field(name string) *cqlType {
+	for i, f := range t.fields {
+		if f == name {
+			return t.args[i]
+		}
+	}
+	return nil
+}
+
+// arg returns the i-th type argument, or nil if the type has none.
+func (t *cqlType) arg(i int) *cqlType {
+	if i < len(t.args) {
+		return t.args[i]
+	}
+	return nil
+}
+
+// valueKind classifies a value expression for type checking.
+type valueKind int
+
+const (
+	valueUnknown valueKind = iota // Bind markers and expressions of unknown type
+	valueNull
+	valueString
+	valueInteger
+	valueFloat
+	valueBoolean
+	valueUUID
+	valueBlob
+	valueDuration
+	valueList
+	valueSet
+	valueMap
+	valueUDT // A UDT literal: {city: 'Paris'}
+	valueTuple
+	valueColumn // A column, typed by the schema
+	valueCall   // A function call, typed by its resolved overload
+	valueTyped  // A column or function result of a known type
+)
+
+// literalTypes lists the types each kind of literal can be assigned to.
+var literalTypes = map[valueKind][]string{
+	valueString:   {"ascii", "text", "varchar", "inet", "date", "time", "timestamp", "duration"},
+	valueInteger:  {"tinyint", "smallint", "int", "bigint", "varint", "counter", "decimal", "float", "double", "date", "time", "timestamp"},
+	valueFloat:    {"decimal", "float", "double"},
+	valueBoolean:  {"boolean"},
+	valueUUID:     {"uuid", "timeuuid"},
+	valueBlob:     {"blob"},
+	valueDuration: {"duration"},
+}
+
+// constantNames names literal kinds like Scylla does in its error messages.
+var constantNames = map[valueKind]string{
+	valueString:   "STRING",
+	valueInteger:  "INTEGER",
+	valueFloat:    "FLOAT",
+	valueBoolean:  "BOOLEAN",
+	valueUUID:     "UUID",
+	valueBlob:     "HEX",
+	valueDuration: "DURATION",
+}
+
+// knownTypes are the types whose values can be checked. Values assigned to
+// other types are not checked, except for the user types of the keyspace.
+var knownTypes = func() map[string]bool {
+	known := map[string]bool{"list": true, "set": true, "map": true, "tuple": true, "vector": true}
+	for _, types := range literalTypes {
+		for _, t := range types {
+			known[t] = true
+		}
+	}
+	return known
+}()
+
+// value is a value expression: a literal, a bind marker, a collection or tuple
+// literal, or a function call.
+type value struct {
+	kind     valueKind
+	text     string   // Source text
+	name     string   // Name of a valueColumn or valueCall
+	keyspace string   // Keyspace of a qualified valueCall
+	typ      string   // Type of a valueTyped
+	args     []*value // Arguments of a valueCall
+	elements []*value // Elements of lists, sets and tuples; keys of maps
+	values   []*value // Values of maps and UDT literals
+	fields   []*value // Field names of UDT literals
+	position *Position
+}
+
+func newValue(kind valueKind, ctx antlr.ParserRuleContext) *value {
+	return &value{kind: kind, text: sourceText(ctx), position: positionOf(ctx.GetStart())}
+}
+
+// constantValue returns the value of a constant.
+func constantValue(ctx parser.IConstantContext) *value {
+	kind := valueUnknown
+	switch {
+	case ctx.StringLiteral() != nil, ctx.CodeBlock() != nil:
+		kind = valueString
+	case ctx.DecimalLiteral() != nil:
+		kind = valueInteger
+	case ctx.FloatLiteral() != nil:
+		kind = valueInteger
+		if strings.ContainsAny(ctx.GetText(), ".eE") {
+			kind = valueFloat
+		}
+	case ctx.UUID() != nil:
+		kind = valueUUID
+	case ctx.HexadecimalLiteral() != nil:
+		kind = valueBlob
+	case ctx.BooleanLiteral() != nil:
+		kind = valueBoolean
+	case ctx.DurationLiteral() != nil:
+		kind = valueDuration
+	case ctx.KwNull() != nil:
+		kind = valueNull
+	}
+	return newValue(kind, ctx)
+}
+
+// indexKeyValue returns the value of the index or key of c[key] = value.
+func indexKeyValue(ctx parser.IAssignmentIndexKeyContext) *value {
+	kind := valueNull
+	switch {
+	case ctx.DecimalLiteral() != nil:
+		kind = valueInteger
+	case ctx.StringLiteral() != nil:
+		kind = valueString
+	case ctx.BooleanLiteral() != nil:
+		kind = valueBoolean
+	case ctx.QMARK() != nil, ctx.NamedMarker() != nil:
+		kind = valueUnknown
+	}
+	return newValue(kind, ctx)
+}
+
+// functionValue returns the value of a function call with its arguments.
+func functionValue(ctx parser.IFunctionCallContext) *value {
+	v := newValue(valueCall, ctx)
+	v.name = functionName(ctx)
+	if ctx.STAR() != nil {
+		return v
+	}
+	if args := ctx.FunctionArgs(); args != nil {
+		v.args = argumentValues(args)
+	} else if column := ctx.OBJECT_NAME(); column != nil && (ctx.KwTtl() != nil || ctx.KwWritetime() != nil) {
+		v.args = []*value{{
+			kind:     valueColumn,
+			text:     column.GetText(),
+			name:     extractColumnName(column.GetText()),
+			position: positionOf(column.GetSymbol()),
+		}}
+	}
+	return v
+}
+
+// qualifiedFunctionValue returns the value of a keyspace-qualified call.
+func qualifiedFunctionValue(ctx parser.IQualifiedFunctionCallContext) *value {
+	v := newValue(valueCall, ctx)
+	names := ctx.AllOBJECT_NAME()
+	v.keyspace = strings.Trim(names[0].GetText(), "\"")
+	v.name = strings.ToLower(names[1].GetText())
+	if args := ctx.FunctionArgs(); args != nil {
+		v.args = argumentValues(args)
+	}
+	return v
+}
+
+// argumentValues returns the values of function arguments, in order.
+func argumentValues(ctx parser.IFunctionArgsContext) []*value {
+	var args []*value
+	for _, child := range ctx.GetChildren() {
+		switch arg := child.(type) {
+		case parser.IConstantContext:
+			args = append(args, constantValue(arg))
+		case parser.IColumnRefContext:
+			v := newValue(valueColumn, arg)
+			v.name = extractColumnName(arg.GetText())
+			args = append(args, v)
+		case parser.IFunctionCallContext:
+			args = append(args, functionValue(arg))
+		case parser.IQualifiedFunctionCallContext:
+			args = append(args, qualifiedFunctionValue(arg))
+		}
+	}
+	return args
+}
+
+// expressionValue returns the value of an INSERT value or tuple component.
+func expressionValue(ctx parser.IExpressionContext) *value {
+	switch {
+	case ctx.Constant() != nil:
+		return constantValue(ctx.Constant())
+	case ctx.FunctionCall() != nil:
+		return functionValue(ctx.FunctionCall())
+	case ctx.AssignmentMap() != nil:
+		return mapValue(ctx.AssignmentMap())
+	case ctx.AssignmentSet() != nil:
+		return setValue(ctx.AssignmentSet())
+	case ctx.AssignmentList() != nil:
+		return listValue(ctx.AssignmentList())
+	case ctx.AssignmentTuple() != nil:
+		return tupleValue(ctx.AssignmentTuple())
+	}
+	return newValue(valueUnknown, ctx)
+}
+
+// conditionValue returns the value compared to a column in an IF condition.
+func conditionValue(ctx parser.IIfConditionValueContext) *value {
+	switch {
+	case ctx.Constant() != nil:
+		return constantValue(ctx.Constant())
+	case ctx.FunctionCall() != nil:
+		return functionValue(ctx.FunctionCall())
+	case ctx.AssignmentMap() != nil:
+		return mapValue(ctx.AssignmentMap())
+	case ctx.AssignmentSet() != nil:
+		return setValue(ctx.AssignmentSet())
+	case ctx.AssignmentList() != nil:
+		return listValue(ctx.AssignmentList())
+	}
+	return newValue(valueUnknown, ctx)
+}
+
+// elementValue returns the value of a collection element, which is a
+// constant or a nested collection.
+func elementValue(ctx interface {
+	antlr.ParserRuleContext
+	Constant() parser.IConstantContext
+	AssignmentSet() parser.IAssignmentSetContext
+	AssignmentList() parser.IAssignmentListContext
+}) *value {
+	switch {
+	case ctx.Constant() != nil:
+		return constantValue(ctx.Constant())
+	case ctx.AssignmentSet() != nil:
+		return setValue(ctx.AssignmentSet())
+	case ctx.AssignmentList() != nil:
+		return listValue(ctx.AssignmentList())
+	}
+	if m, ok := ctx.(interface {
+		AssignmentMap() parser.IAssignmentMapContext
+	}); ok && m.AssignmentMap() != nil {
+		return mapValue(m.AssignmentMap())
+	}
+	return newValue(valueUnknown, ctx)
+}
+
+func listValue(ctx parser.IAssignmentListContext) *value {
+	v := newValue(valueList, ctx)
+	for _, e := range ctx.AllAssignmentListElement() {
+		v.elements = append(v.elements, elementValue(e))
+	}
+	return v
+}
+
+func setValue(ctx parser.IAssignmentSetContext) *value {
+	v := newValue(valueSet, ctx)
+	for _, e := range ctx.AllAssignmentSetElement() {
+		v.elements = append(v.elements, elementValue(e))
+	}
+	return v
+}
+
+// mapValue returns the value of a map literal, or of a UDT literal when its
+// first key is a field name.
+func mapValue(ctx parser.IAssignmentMapContext) *value {
+	entries := ctx.AllAssignmentMapEntry()
+	if len(entries) > 0 && entries[0].AssignmentMapKey().OBJECT_NAME() != nil {
+		v := newValue(valueUDT, ctx)
+		for _, e := range entries {
+			field := newValue(valueUnknown, e.AssignmentMapKey())
+			field.name = extractColumnName(field.text)
+			v.fields = append(v.fields, field)
+			v.values = append(v.values, elementValue(e.AssignmentMapValue()))
+		}
+		return v
+	}
+	v := newValue(valueMap, ctx)
+	for _, e := range entries {
+		v.elements = append(v.elements, elementValue(e.AssignmentMapKey()))
+		v.values = append(v.values, elementValue(e.AssignmentMapValue()))
+	}
+	return v
+}
+
+func tupleValue(ctx parser.IAssignmentTupleContext) *value {
+	v := newValue(valueTuple, ctx)
+	for _, e := range ctx.AllExpression() {
+		v.elements = append(v.elements, expressionValue(e))
+	}
+	return v
+}
+
+// scope is what the names in a statement resolve against. Its fields are
+// nil without a schema, or when the keyspace or table is unknown.
+type scope struct {
+	schema   *schema.Schema
+	keyspace *schema.Keyspace
+	table    *schema.Table
+}
+
+// cqlType parses a type string and resolves the user types of the keyspace
+// in it to their fields, so that UDT literals can be checked.
+func (sc *scope) cqlType(s string) *cqlType {
+	t := parseCQLType(s)
+	sc.resolveUserTypes(t)
+	return t
+}
+
+func (sc *scope) resolveUserTypes(t *cqlType) {
+	for _, arg := range t.args {
+		sc.resolveUserTypes(arg)
+	}
+	if knownTypes[t.name] || len(t.args) > 0 {
+		return
+	}
+	udt := sc.keyspace.UserTypeOf(t.name)
+	if udt == nil {
+		return
+	}
+	for _, name := range udt.FieldNames() {
+		t.fields = append(t.fields, name)
+		t.args = append(t.args, sc.cqlType(udt.Fields[name]))
+	}
+}
+
+// resolve types the columns and function calls in v. Columns and calls whose
+// type cannot be determined become unknown.
+func (sc *scope) resolve(v *value) {
+	for _, e := range v.elements {
+		sc.resolve(e)
+	}
+	for _, e := range v.values {
+		sc.resolve(e)
+	}
+	switch v.kind {
+	case valueColumn:
+		v.kind = valueUnknown
+		if col := sc.table.GetColumn(v.name); col != nil {
+			v.kind = valueTyped
+			v.typ = parseCQLType(col.Type).String()
+		}
+	case valueCall:
+		for _, arg := range v.args {
+			sc.resolve(arg)
+		}
+		v.kind = valueUnknown
+		if typ := sc.callType(v.keyspace, v.name, v.args); typ != "" {
+			v.kind = valueTyped
+			v.typ = typ
+		}
+	}
+}
+
+// valueTarget is the part of a column a value is compared to or assigned to.
+type valueTarget int
+
+const (
+	targetColumn  valueTarget = iota
+	targetElement             // An element of a list or set, or a value of a map (CONTAINS, c[key] = value)
+	targetKey                 // A key of a map (CONTAINS KEY)
+	targetIndex               // An index of a list, an element of a set or a key of a map (c[index])
+	targetAdded               // The values added to a collection: c = c + {...}
+	targetRemoved             // The values removed from a collection, the keys of a map: c = c - {...}
+	targetList                // The whole list of IN ?
+)
+
+// targetType returns the type of the target of a value in a column of type
+// t, or nil if the column has no such part.
+func targetType(t *cqlType, target valueTarget) *cqlType {
+	switch target {
+	case targetElement:
+		switch t.name {
+		case "list", "set":
+			return t.arg(0)
+		case "map":
+			return t.arg(1)
+		}
+		return nil
+	case targetKey:
+		if t.name == "map" {
+			return t.arg(0)
+		}
+		return nil
+	case targetIndex:
+		switch t.name {
+		case "list":
+			return &cqlType{name: "int"}
+		case "set", "map":
+			return t.arg(0)
+		}
+		return nil
+	case targetList:
+		return &cqlType{name: "list", args: []*cqlType{t}}
+	case targetAdded, targetRemoved:
+		switch t.name {
+		case "list", "set":
+			return t
+		case "map":
+			if target == targetAdded || t.arg(0) == nil {
+				return t
+			}
+			return &cqlType{name: "set", args: []*cqlType{t.arg(0)}}
+		}
+		return nil
+	}
+	return t
+}
+
+// columnValue is a value compared to or assigned to a column.
+type columnValue struct {
+	column  string
+	columns []string // Columns of a multi-column relation: (a, b) IN ?
+	field   string   // Field of a UDT column: addr.city = value
+	target  valueTarget
+	value   *value
+}
+
+// validateValues checks that the values compared to or assigned to columns
+// have the types of the columns.
+func validateValues(result *Result, sc *scope) {
+	for _, cv := range result.References.values {
+		col := sc.table.GetColumn(cv.column)
+		if col == nil {
+			continue
+		}
+		sc.resolve(cv.value)
+		t := sc.cqlType(col.Type)
+		receiver := col.Name
+		if cv.field != "" {
+			typ := sc.fieldType(col, cv.field)
+			if typ == "" {
+				// Reported by validateFields
+				continue
+			}
+			t = sc.cqlType(typ)
+			receiver = col.Name + "." + cv.field
+		}
+		switch {
+		case cv.target == targetElement, cv.target == targetIndex && t.name == "set":
+			receiver = "value(" + col.Name + ")"
+		case cv.target == targetKey, cv.target == targetIndex && t.name == "map":
+			receiver = "key(" + col.Name + ")"
+		case cv.target == targetIndex:
+			receiver = "idx(" + col.Name + ")"
+		}
+		t = targetType(t, cv.target)
+		if t == nil {
+			continue
+		}
+		if err := checkValue(receiver, t, cv.value); err != nil {
+			if err.Object == "" {
+				err.Object = col.Name
+			}
+			result.SchemaErrors = append(result.SchemaErrors, err)
+		}
+	}
+}
+
+// checkValue returns an ErrTypeMismatch if v cannot be assigned to a receiver
+// of type t.
+func checkValue(receiver string, t *cqlType, v *value) *SchemaError {
+	if assignable(t, v) {
+		return nil
+	}
+	mismatch := func(at *value, format string, args ...any) *SchemaError {
+		return &SchemaError{
+			Type:     ErrTypeMismatch,
+			Message:  fmt.Sprintf(format, args...),
+			Position: at.position,
+		}
+	}
+
+	switch v.kind {
+	case valueTyped:
+		return mismatch(v, "Type error: cannot assign result of function %s (type %s) to %s (type %s)",
+			v.text, v.typ, receiver, t)
+	case valueList, valueSet, valueMap, valueTuple:
+		literal := map[valueKind]string{valueList: "list", valueSet: "set", valueMap: "map", valueTuple: "tuple"}[v.kind]
+		if !collectionMatches(t, v) {
+			return mismatch(v, "Invalid %s literal for %s of type %s", literal, receiver, t)
+		}
+		for i, e := range v.elements {
+			elemType, what := elementType(t, v, i)
+			if !assignable(elemType, e) {
+				return mismatch(e, "Invalid %s literal for %s: %s is not of type %s", literal, receiver, what, elemType)
+			}
+		}
+		for _, e := range v.values {
+			if !assignable(t.arg(1), e) {
+				return mismatch(e, "Invalid map literal for %s: value %s is not of type %s", receiver, e.text, t.arg(1))
+			}
+		}
+		return nil
+	case valueUDT:
+		if t.fields == nil {
+			return mismatch(v, "Invalid user type literal for %s of type %s", receiver, t)
+		}
+		for i, f := range v.fields {
+			ft := t.field(f.name)
+			switch {
+			case ft == nil:
+				return &SchemaError{
+					Type:       ErrUnknownField,
+					Message:    fmt.Sprintf("Unknown field '%s' in value of user defined type %s", f.name, t),
+					Suggestion: suggestField(f.name, t.fields),
+					Object:     f.name,
+					Position:   f.position,
+				}
+			case !assignable(ft, v.values[i]):
+				return mismatch(v.values[i], "Invalid user type literal for %s: field %s is not of type %s", receiver, f.name, ft)
+			}
+		}
+		return nil
+	}
+
+	err := mismatch(v, "Invalid %s constant (%s) for \"%s\" of type %s", constantNames[v.kind], unquote(v.text), receiver, t)
+	if v.kind == valueString {
+		// A quoted UUID, number or boolean
+		unquoted := &value{kind: literalKind(unquote(v.text))}
+		if unquoted.kind != valueUnknown && assignable(t, unquoted) {
+			err.Suggestion = fmt.Sprintf("Remove the quotes: %s", unquote(v.text))
+		}
+	}
+	return err
+}
+
+// assignable reports whether v can be assigned to a receiver of type t.
+func assignable(t *cqlType, v *value) bool {
+	if t == nil || (!knownTypes[t.name] && t.fields == nil) {
+		return true
+	}
+	switch v.kind {
+	case valueUnknown, valueNull, valueColumn, valueCall:
+		return true
+	case valueTyped:
+		return compatibleTypes(t.String(), v.typ)
+	case valueList, valueSet, valueMap, valueTuple:
+		if !collectionMatches(t, v) {
+			return false
+		}
+		for i, e := range v.elements {
+			if elemType, _ := elementType(t, v, i); !assignable(elemType, e) {
+				return false
+			}
+		}
+		for _, e := range v.values {
+			if !assignable(t.arg(1), e) {
+				return false
+			}
+		}
+		return true
+	case valueUDT:
+		if t.fields == nil {
+			return false
+		}
+		for i, f := range v.fields {
+			if ft := t.field(f.name); ft == nil || !assignable(ft, v.values[i]) {
+				return false
+			}
+		}
+		return true
+	}
+	return containsFold(literalTypes[v.kind], t.name)
+}
+
+// collectionMatches reports whether a collection or tuple literal has the
+// shape of type t. Empty braces are both an empty set and an empty map.
+func collectionMatches(t *cqlType, v *value) bool {
+	switch v.kind {
+	case valueList:
+		return t.name == "list" || t.name == "vector"
+	case valueSet:
+		return t.name == "set" || (t.name == "map" && len(v.elements) == 0)
+	case valueMap:
+		return t.name == "map" || (t.name == "set" && len(v.elements) == 0)
+	case valueTuple:
+		return t.name == "tuple" && len(v.elements) <= len(t.args)
+	}
+	return false
+}
+
+// elementType returns the type of the i-th element of a collection or tuple
+// literal, and what the element is called in error messages.
+func elementType(t *cqlType, v *value, i int) (*cqlType, string) {
+	switch {
+	case v.kind == valueTuple:
+		return t.arg(i), fmt.Sprintf("component %d", i)
+	case t.name == "map":
+		return t.arg(0), "key " + v.elements[i].text
+	}
+	return t.arg(0), "value " + v.elements[i].text
+}
+
+// compatibleTypes reports whether a value of type source can be assigned to
+// a receiver of type target.
+func compatibleTypes(target, source string) bool {
+	if target == "varchar" {
+		target = "text"
+	}
+	if source == "varchar" {
+		source = "text"
+	}
+	return target == source || containsFold(cqldata.GenTypeCompatibility[target], source)
+}
+
+// literalKind returns the kind of an unquoted literal, or valueUnknown.
+func literalKind(text string) valueKind {
+	lexer := parser.NewCqlLexer(antlr.NewInputStream(text))
+	lexer.RemoveErrorListeners()
+	tokens := lexer.GetAllTokens()
+	if len(tokens) != 1 || len(tokens[0].GetText()) != len(text) {
+		return valueUnknown
+	}
+	switch tokens[0].GetTokenType() {
+	case parser.CqlLexerUUID:
+		return valueUUID
+	case parser.CqlLexerDECIMAL_LITERAL:
+		return valueInteger
+	case parser.CqlLexerFLOAT_LITERAL:
+		if strings.ContainsAny(text, ".eE") {
+			return valueFloat
+		}
+		return valueInteger
+	case parser.CqlLexerK_TRUE, parser.CqlLexerK_FALSE:
+		return valueBoolean
+	}
+	return valueUnknown
+}
+
+// unquote strips the quotes of a string literal or the dollars of a code block.
+func unquote(text string) string {
+	switch {
+	case len(text) >= 2 && strings.HasPrefix(text, "'") && strings.HasSuffix(text, "'"):
+		return strings.ReplaceAll(text[1:len(text)-1], "''", "'")
+	case len(text) >= 4 && strings.HasPrefix(text, "$$") && strings.HasSuffix(text, "$$"):
+		return text[2 : len(text)-2]
+	}
+	return text
+}
+
+// sourceText returns the source text of ctx, including whitespace.
+func sourceText(ctx antlr.ParserRuleContext) string {
+	start, stop := ctx.GetStart(), ctx.GetStop()
+	if start == nil || stop == nil || stop.GetStop() < start.GetStart() {
+		return ctx.GetText()
+	}
+	return start.GetInputStream().GetText(start.GetStart(), stop.GetStop())
+}
+
+// positionOf returns the position of a token.
+func positionOf(token antlr.Token) *Position {
+	return &Position{
+		Line:   token.GetLine(),
+		Column: token.GetColumn(),
+		Offset: token.GetStart(),
+	}
+}
+
+// positionAfter returns the position just after a token.
+func positionAfter(token antlr.Token) *Position {
+	p := &Position{Line: token.GetLine(), Column: token.GetColumn(), Offset: token.GetStop() + 1}
+	text := token.GetText()
+	if i := strings.LastIndex(text, "\n"); i >= 0 {
+		p.Line += strings.Count(text, "\n")
+		p.Column = len([]rune(text[i+1:]))
+	} else {
+		p.Column += len([]rune(text))
+	}
+	return p
+}
+
+func containsFold(list []string, s string) bool {
+	for _, item := range list {
+		if strings.EqualFold(item, s) {
+			return true
+		}
+	}
+	return false
+}
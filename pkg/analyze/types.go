@@ -1,6 +1,7 @@
 // Package analyze provides schema-aware analysis of CQL queries.
 // It can validate queries against a schema, extract referenced objects,
-// and detect potential issues like missing partition keys.
+// and detect potential issues like missing partition keys. The messages of
+// schema errors follow those of Scylla.
 package analyze
 
 import (
@@ -30,16 +31,70 @@ type Result struct {
 
 	// Warnings contains non-fatal issues (missing PK, ALLOW FILTERING needed, etc.)
 	Warnings []*Warning
+
+	// Statements are the results of each statement of a BATCH, validated
+	// against its own table, in the order of References.Targets. Their
+	// errors and warnings are also in SchemaErrors and Warnings.
+	Statements []*Result
+
+	// BindMarkers are the ? and :name markers of the query, in order
+	BindMarkers []*BindMarker
+
+	// View is the materialized view defined by CREATE MATERIALIZED VIEW,
+	// when its definition is valid against the schema
+	View *schema.MaterializedView
+}
+
+// BindMarker is a ? or :name placeholder of a prepared statement.
+type BindMarker struct {
+	// Index is the 0-based position of the marker among those of the query
+	Index int
+
+	// Name is the name of a :name marker, empty for ?
+	Name string
+
+	// Position is the location in the query
+	Position *Position
+
+	// Column is the column the value binds to, such as (a, b) for the columns
+	// of (a, b) IN ?, empty if there is none
+	Column string
+
+	// CQLType is the type of the value, from the schema; empty if unknown
+	CQLType string
+
+	// Role is the clause the marker is in
+	Role BindRole
 }
 
+// BindRole identifies the clause of a bind marker.
+type BindRole string
+
+const (
+	BindWhere     BindRole = "where"     // Compared to a column in WHERE
+	BindInList    BindRole = "in-list"   // An element of an IN list, or the whole list of IN ?
+	BindSet       BindRole = "set"       // Assigned in the SET clause of an UPDATE, or the key or index of m[?]
+	BindValue     BindRole = "value"     // An INSERT value
+	BindCondition BindRole = "condition" // Compared to a column in an IF condition
+	BindLimit     BindRole = "limit"     // LIMIT or PER PARTITION LIMIT
+	BindTTL       BindRole = "ttl"       // USING TTL
+	BindTimestamp BindRole = "timestamp" // USING TIMESTAMP
+	BindTimeout   BindRole = "timeout"   // USING TIMEOUT
+	BindArgument  BindRole = "argument"  // An argument of a function call
+)
+
 // References contains all schema objects referenced in a query.
 type References struct {
 	// Keyspace is the target keyspace (explicit or from USE)
 	Keyspace string
 
-	// Table is the target table name
+	// Table is the target table name; for a BATCH, that of its last statement
 	Table string
 
+	// Targets are the tables of the statements of a BATCH, or the table or
+	// view of DESCRIBE TABLE and DESCRIBE MATERIALIZED VIEW
+	Targets []*Target
+
 	// Columns are all column names referenced in the query
 	Columns []string
 
@@ -64,11 +119,76 @@ type References struct {
 	// FunctionCalls contains detailed info about each function call
 	FunctionCalls []*FunctionCall
 
+	// Conditions are columns in IF conditions (for UPDATE and DELETE queries)
+	Conditions []string
+
+	// IsConditional is true for lightweight transactions: INSERT, UPDATE or
+	// DELETE with IF conditions, IF EXISTS or IF NOT EXISTS
+	IsConditional bool
+
 	// HasAllowFiltering is true if ALLOW FILTERING is present
 	HasAllowFiltering bool
 
-	// Limit is the LIMIT value if present, -1 otherwise
+	// Limit is the LIMIT value if present, 0 for LIMIT ?, -1 otherwise
 	Limit int
+
+	// values are the values compared to or assigned to columns, for type checking
+	values []*columnValue
+
+	// relations are the restrictions of the WHERE clause, and fields the
+	// fields of UDT columns selected, restricted or assigned
+	relations []*relation
+	fields    []*fieldSelection
+
+	// conditions are the IF conditions, and conditional the position of IF
+	conditions  []*relation
+	conditional *Position
+
+	// target is the position of the table an INSERT writes to
+	target *Position
+
+	// timestamp and ttl are the positions of USING TIMESTAMP and USING TTL,
+	// ttlSeconds the value of the TTL, clamped to MaxInt64, and ttlText its
+	// source text
+	timestamp  *Position
+	ttl        *Position
+	ttlSeconds int64
+	ttlText    string
+
+	// assignments are the elements of the SET clause, and deletions the
+	// collection elements deleted by DELETE c[key]
+	assignments []*assignment
+	deletions   []*deletion
+
+	// batchType is LOGGED, UNLOGGED or COUNTER for a BATCH, empty if unset
+	batchType string
+
+	// definition is the table of a CREATE TABLE statement, view the view
+	// of a CREATE MATERIALIZED VIEW, alteration the change of an ALTER TABLE
+	// and drop the object of a DROP statement
+	definition *tableDefinition
+	view       *viewDefinition
+	alteration *tableAlteration
+	drop       *dropStatement
+
+	// markers are the bind markers of the statement
+	markers []*BindMarker
+}
+
+// Target is a table targeted by a statement of a BATCH or by DESCRIBE, with
+// the references of that statement alone.
+type Target struct {
+	// Type is the type of the statement: INSERT, UPDATE or DELETE in a BATCH
+	Type types.StatementType
+
+	// Text is the source of the statement
+	Text string
+
+	// Position and End delimit the statement in the query; End is exclusive
+	Position *Position
+	End      *Position
+
+	*References
 }
 
 // FunctionCall represents a function call in a query with argument details.
@@ -76,6 +196,9 @@ type FunctionCall struct {
 	// Name is the function name (lowercase)
 	Name string
 
+	// Keyspace is the keyspace of a qualified call such as ks.fn(), or empty
+	Keyspace string
+
 	// ArgCount is the number of arguments passed
 	ArgCount int
 
@@ -84,6 +207,9 @@ type FunctionCall struct {
 
 	// Position is the location in the query
 	Position *Position
+
+	// args are the argument values, for overload resolution
+	args []*value
 }
 
 // SchemaError represents an error from schema validation.
@@ -99,13 +225,35 @@ type SchemaError struct {
 type SchemaErrorType string
 
 const (
-	ErrUnknownKeyspace       SchemaErrorType = "unknown_keyspace"
-	ErrUnknownTable          SchemaErrorType = "unknown_table"
-	ErrUnknownColumn         SchemaErrorType = "unknown_column"
-	ErrUnknownFunction       SchemaErrorType = "unknown_function"
-	ErrTypeMismatch          SchemaErrorType = "type_mismatch"
-	ErrFunctionArgCount      SchemaErrorType = "function_arg_count"
-	ErrFunctionArgCountRange SchemaErrorType = "function_arg_count_range"
+	ErrUnknownKeyspace        SchemaErrorType = "unknown_keyspace"
+	ErrUnknownTable           SchemaErrorType = "unknown_table"
+	ErrUnknownColumn          SchemaErrorType = "unknown_column"
+	ErrUnknownFunction        SchemaErrorType = "unknown_function"
+	ErrUnknownField           SchemaErrorType = "unknown_field"
+	ErrTypeMismatch           SchemaErrorType = "type_mismatch"
+	ErrFunctionArgCount       SchemaErrorType = "function_arg_count"
+	ErrFunctionArgCountRange  SchemaErrorType = "function_arg_count_range"
+	ErrNoMatchingOverload     SchemaErrorType = "no_matching_overload"
+	ErrInvalidRestriction     SchemaErrorType = "invalid_restriction"
+	ErrInvalidCondition       SchemaErrorType = "invalid_condition"
+	ErrCounterInsert          SchemaErrorType = "counter_insert"
+	ErrCounterAssignment      SchemaErrorType = "counter_assignment"
+	ErrCounterMix             SchemaErrorType = "counter_mix"
+	ErrCounterTTL             SchemaErrorType = "counter_ttl"
+	ErrCounterBatch           SchemaErrorType = "counter_batch"
+	ErrInvalidTTL             SchemaErrorType = "invalid_ttl"
+	ErrInvalidTimestamp       SchemaErrorType = "invalid_timestamp"
+	ErrInvalidStatic          SchemaErrorType = "invalid_static"
+	ErrInvalidSelection       SchemaErrorType = "invalid_selection"
+	ErrInvalidCollectionOp    SchemaErrorType = "invalid_collection_operation"
+	ErrInvalidView            SchemaErrorType = "invalid_view"
+	ErrDuplicateColumn        SchemaErrorType = "duplicate_column"
+	ErrInvalidPrimaryKey      SchemaErrorType = "invalid_primary_key"
+	ErrInvalidClusteringOrder SchemaErrorType = "invalid_clustering_order"
+	ErrUnknownType            SchemaErrorType = "unknown_type"
+	ErrInvalidAlter           SchemaErrorType = "invalid_alter"
+	ErrObjectNotFound         SchemaErrorType = "object_not_found"
+	ErrObjectInUse            SchemaErrorType = "object_in_use"
 )
 
 // Warning represents a non-fatal issue with the query.
@@ -121,14 +269,15 @@ type Warning struct {
 type WarningType string
 
 const (
-	WarnMissingPartitionKey   WarningType = "missing_partition_key"
-	WarnMissingClusteringKey  WarningType = "missing_clustering_key"
-	WarnAllowFilteringNeeded  WarningType = "allow_filtering_needed"
-	WarnAllowFilteringPresent WarningType = "allow_filtering_present"
-	WarnNoWhereClause         WarningType = "no_where_clause"
-	WarnNoLimit               WarningType = "no_limit"
-	WarnLargeLimit            WarningType = "large_limit"
-	WarnSelectStar            WarningType = "select_star"
+	WarnMissingPartitionKey    WarningType = "missing_partition_key"
+	WarnMissingClusteringKey   WarningType = "missing_clustering_key"
+	WarnAllowFilteringNeeded   WarningType = "allow_filtering_needed"
+	WarnAllowFilteringPresent  WarningType = "allow_filtering_present"
+	WarnNoWhereClause          WarningType = "no_where_clause"
+	WarnNoLimit                WarningType = "no_limit"
+	WarnLargeLimit             WarningType = "large_limit"
+	WarnSelectStar             WarningType = "select_star"
+	WarnLightweightTransaction WarningType = "lightweight_transaction"
 )
 
 // Severity indicates how serious a warning is.
@@ -205,6 +354,7 @@ func NewReferences() *References {
 		UpdateColumns:  make([]string, 0),
 		InsertColumns:  make([]string, 0),
 		OrderByColumns: make([]string, 0),
+		Conditions:     make([]string, 0),
 		Functions:      make([]string, 0),
 		FunctionCalls:  make([]*FunctionCall, 0),
 		Limit:          -1,
@@ -225,6 +375,9 @@ type AnalyzeOptions struct {
 	// WarnOnNoLimit warns about SELECT queries without LIMIT
 	WarnOnNoLimit bool
 
+	// WarnOnLightweightTransaction warns about conditional statements (IF ...)
+	WarnOnLightweightTransaction bool
+
 	// LargeLimitThreshold triggers a warning when LIMIT exceeds this value (0 = disabled)
 	LargeLimitThreshold int
 }
@@ -0,0 +1,65 @@
+package analyze
+
+import (
+	"fmt"
+
+	"github.com/tentacle-scylla/scql/pkg/schema"
+)
+
+// fieldSelection is a field of a UDT column: addr.city.
+type fieldSelection struct {
+	column   string
+	field    string
+	position *Position
+}
+
+// fieldType returns the type of a field of a UDT column, or "" if the column
+// is not a UDT of the keyspace or the UDT has no such field.
+func (sc *scope) fieldType(col *schema.Column, field string) string {
+	udt := sc.keyspace.UserTypeOf(col.Type)
+	if udt == nil {
+		return ""
+	}
+	return udt.Fields[field]
+}
+
+// validateFields checks the fields selected from columns against the
+// user-defined types of the columns.
+func validateFields(result *Result, sc *scope) {
+	for _, f := range result.References.fields {
+		col := sc.table.GetColumn(f.column)
+		if col == nil {
+			// Reported as an unknown column
+			continue
+		}
+		udt := sc.keyspace.UserTypeOf(col.Type)
+		if udt == nil {
+			result.SchemaErrors = append(result.SchemaErrors, &SchemaError{
+				Type:     ErrInvalidSelection,
+				Message:  fmt.Sprintf("Invalid field selection: %s of type %s is not a user type", col.Name, col.Type),
+				Object:   col.Name,
+				Position: f.position,
+			})
+			continue
+		}
+		if _, ok := udt.Fields[f.field]; ok {
+			continue
+		}
+		result.SchemaErrors = append(result.SchemaErrors, &SchemaError{
+			Type:       ErrUnknownField,
+			Message:    fmt.Sprintf("%s of type %s has no field %s", col.Name, col.Type, f.field),
+			Suggestion: suggestField(f.field, udt.FieldNames()),
+			Object:     f.field,
+			Position:   f.position,
+		})
+	}
+}
+
+// suggestField suggests the closest of the fields of a user type to an
+// unknown field, or returns "".
+func suggestField(field string, fields []string) string {
+	if closest := findClosest(field, fields); closest != "" {
+		return fmt.Sprintf("Did you mean '%s'?", closest)
+	}
+	return ""
+}
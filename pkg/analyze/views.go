@@ -0,0 +1,173 @@
+package analyze
+
+import (
+	"fmt"
+	"strings"
+
+	parser "github.com/tentacle-scylla/scql/gen/parser"
+	"github.com/tentacle-scylla/scql/pkg/schema"
+)
+
+// viewDefinition is the view of a CREATE MATERIALIZED VIEW statement.
+type viewDefinition struct {
+	keyspace      string
+	name          string
+	columns       []*identifier // Selected columns, none for SELECT *
+	partitionKey  []*identifier
+	clusteringKey []*identifier
+	notNull       []string // Columns restricted with IS NOT NULL
+	where         string   // Source text of the WHERE clause
+	position      *Position
+	keyPosition   *Position // Position of the PRIMARY KEY clause
+}
+
+// primaryKey returns the partition key and clustering columns of the view.
+func (def *viewDefinition) primaryKey() []*identifier {
+	return append(append([]*identifier{}, def.partitionKey...), def.clusteringKey...)
+}
+
+// viewDefinitionOf returns the selected columns, restrictions and primary
+// key of a CREATE MATERIALIZED VIEW. Selected elements that are not columns
+// are reported by validateViewDefinition as columns without a name.
+func viewDefinitionOf(ctx *parser.CreateMaterializedViewContext) *viewDefinition {
+	def := &viewDefinition{
+		name:     extractColumnName(ctx.MaterializedView().GetText()),
+		position: positionOf(ctx.GetStart()),
+	}
+	if ks := ctx.Keyspace(); ks != nil {
+		def.keyspace = strings.Trim(ks.GetText(), "\"")
+	}
+	if elements := ctx.SelectElements(); elements != nil && elements.STAR() == nil {
+		for _, el := range elements.AllSelectElement() {
+			col := &identifier{position: positionOf(el.GetStart())}
+			if el.ColumnRef() != nil && el.KwAs() == nil {
+				col.name = extractColumnName(el.ColumnRef().GetText())
+			}
+			def.columns = append(def.columns, col)
+		}
+	}
+	if where := ctx.MvWhereSpec(); where != nil {
+		clauses := where.AllMvWhereClause()
+		for _, clause := range clauses {
+			if clause.KwIs() != nil {
+				def.notNull = append(def.notNull, extractColumnName(clause.ColumnRef().GetText()))
+			}
+		}
+		start, stop := clauses[0].GetStart(), clauses[len(clauses)-1].GetStop()
+		def.where = start.GetInputStream().GetText(start.GetStart(), stop.GetStop())
+	}
+	def.keyPosition = positionOf(ctx.PrimaryKeyElement().GetStart())
+	partitionKey, clusteringKey := primaryKeyOf(ctx.PrimaryKeyElement())
+	for _, c := range partitionKey {
+		def.partitionKey = append(def.partitionKey, identifierOf(c))
+	}
+	for _, c := range clusteringKey {
+		def.clusteringKey = append(def.clusteringKey, identifierOf(c))
+	}
+	return def
+}
+
+// validateViewDefinition checks a CREATE MATERIALIZED VIEW statement: only
+// columns are selected, and every primary key column of the view is
+// restricted with IS NOT NULL. With a schema, the base table and the
+// selected and key columns exist, and the primary key of the view has all
+// the primary key columns of the base table and at most one other column.
+// The view is recorded in result.View.
+func validateViewDefinition(result *Result, opts *AnalyzeOptions, sc *scope) {
+	refs := result.References
+	def := refs.view
+	reported := len(result.SchemaErrors)
+	viewError := func(object string, position *Position, format string, args ...any) {
+		result.SchemaErrors = append(result.SchemaErrors, &SchemaError{
+			Type:     ErrInvalidView,
+			Message:  fmt.Sprintf(format, args...),
+			Object:   object,
+			Position: position,
+		})
+	}
+
+	if def.keyspace != "" && refs.Keyspace != "" && !strings.EqualFold(def.keyspace, refs.Keyspace) {
+		viewError(def.name, def.position, "Cannot create a materialized view on a table in a separate keyspace")
+	}
+	for _, col := range def.columns {
+		if col.name == "" {
+			viewError(def.name, col.position, "Can only select columns by name when defining a materialized view")
+		}
+	}
+	for _, col := range def.primaryKey() {
+		if !containsFold(def.notNull, col.name) {
+			viewError(col.name, col.position, "Primary key column '%s' is required to be filtered by 'IS NOT NULL'", col.name)
+		}
+	}
+
+	if opts.Schema == nil || refs.Table == "" {
+		return
+	}
+	base := resolveTable(result, opts, sc)
+	if base == nil {
+		return
+	}
+
+	// The selected columns, with the key columns that are not selected
+	columns := def.columns
+	if len(columns) == 0 {
+		for _, name := range base.ColumnOrder {
+			columns = append(columns, &identifier{name: name})
+		}
+	}
+	for _, col := range def.primaryKey() {
+		if !containsIdentifier(columns, col.name) {
+			columns = append(columns, col)
+		}
+	}
+	known := true
+	for _, col := range columns {
+		if col.name != "" && base.GetColumn(col.name) == nil {
+			result.SchemaErrors = append(result.SchemaErrors, &SchemaError{
+				Type:       ErrUnknownColumn,
+				Message:    fmt.Sprintf("Column '%s' not found in table '%s'", col.name, base.Name),
+				Suggestion: suggestColumn(base, col.name),
+				Object:     col.name,
+				Position:   col.position,
+			})
+			known = false
+		}
+	}
+	if !known {
+		return
+	}
+
+	var missing []string
+	var regular []*identifier
+	for _, c := range base.PrimaryKeyColumns() {
+		if !containsIdentifier(def.primaryKey(), c.Name) {
+			missing = append(missing, c.Name)
+		}
+	}
+	for _, col := range def.primaryKey() {
+		if c := base.GetColumn(col.name); !c.IsPartitionKey && !c.IsClusteringKey {
+			regular = append(regular, &identifier{name: c.Name, position: col.position})
+		}
+	}
+	if len(missing) > 0 {
+		viewError(def.name, def.keyPosition, "Cannot create Materialized View %s without primary key columns from base %s (%s)",
+			def.name, base.Name, strings.Join(missing, ","))
+	}
+	if len(regular) > 1 {
+		// Reported at the first column over the limit
+		viewError(regular[1].name, regular[1].position, "Cannot include more than one non-primary key column in materialized view primary key (got %s)",
+			strings.Join(identifierNames(regular), ", "))
+	}
+	if len(result.SchemaErrors) > reported {
+		return
+	}
+
+	mv := &schema.MaterializedView{Name: def.name, Keyspace: sc.keyspace.Name, BaseTable: base.Name}
+	for _, col := range columns {
+		mv.AddColumn(base.GetColumn(col.name).Name, base.GetColumn(col.name).Type)
+	}
+	mv.SetPartitionKey(identifierNames(def.partitionKey)...)
+	mv.SetClusteringKey(identifierNames(def.clusteringKey)...)
+	mv.WithWhereClause(def.where)
+	result.View = mv
+}
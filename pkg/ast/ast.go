@@ -0,0 +1,364 @@
+// Package ast provides a typed syntax tree for CQL statements.
+//
+// The tree is built from the ANTLR parse tree produced by pkg/parse and hides
+// the generated parser's rule names behind stable Go structs. Every node records
+// the span of source text it was built from.
+//
+// Identifiers are normalized the way CQL resolves them: unquoted names are
+// lowercased and quoted names are unquoted, so "MyTable" and mytable differ while
+// MyTable and mytable are the same name.
+package ast
+
+// Span locates a node in the source text.
+type Span struct {
+	// Start is the byte offset of the first character (inclusive)
+	Start int `json:"start"`
+	// End is the byte offset after the last character (exclusive)
+	End int `json:"end"`
+	// Line is the 1-based line of Start
+	Line int `json:"line"`
+	// Column is the 0-based column of Start
+	Column int `json:"column"`
+}
+
+// NodeSpan returns the span itself, so that embedding Span implements Node.
+func (s Span) NodeSpan() Span {
+	return s
+}
+
+// Node is implemented by every syntax tree node.
+type Node interface {
+	NodeSpan() Span
+}
+
+// Statement is a complete CQL statement.
+type Statement interface {
+	Node
+	statementNode()
+}
+
+// Term is a value expression: a literal, bind marker, column, function call,
+// collection, tuple or arithmetic on those.
+type Term interface {
+	Node
+	termNode()
+}
+
+// QualifiedName is an optionally keyspace-qualified object name (table, type, view, ...).
+type QualifiedName struct {
+	Span
+	Keyspace string // Empty if not qualified
+	Name     string
+}
+
+// LiteralKind identifies the type of a literal.
+type LiteralKind string
+
+const (
+	LiteralString    LiteralKind = "string"
+	LiteralInteger   LiteralKind = "integer"
+	LiteralFloat     LiteralKind = "float"
+	LiteralBoolean   LiteralKind = "boolean"
+	LiteralHex       LiteralKind = "hex"
+	LiteralUUID      LiteralKind = "uuid"
+	LiteralDuration  LiteralKind = "duration"
+	LiteralNull      LiteralKind = "null"
+	LiteralEmpty     LiteralKind = "empty"      // EMPTY collection literal (ScyllaDB)
+	LiteralCodeBlock LiteralKind = "code_block" // $$ ... $$
+)
+
+// Literal is a constant value.
+type Literal struct {
+	Span
+	Kind LiteralKind
+	// Value is the literal text. Strings and code blocks hold their unescaped
+	// content without delimiters; other kinds hold the source text.
+	Value string
+}
+
+// BindMarker is a positional (?) or named (:name) bind marker.
+type BindMarker struct {
+	Span
+	Name string // Empty for positional markers
+}
+
+// ColumnRef references a column, optionally selecting a UDT field (col.field).
+type ColumnRef struct {
+	Span
+	Name  string
+	Field string
+}
+
+// Star is the * selector, optionally qualified (tbl.*).
+type Star struct {
+	Span
+	Qualifier string
+}
+
+// FunctionCall is a call to a native or user-defined function, including
+// token(), writetime(), ttl() and SCYLLA_CLUSTERING_BOUND().
+type FunctionCall struct {
+	Span
+	Keyspace string // Set for qualified calls (ks.fn(...))
+	Name     string
+	Args     []Term
+	Star     bool // COUNT(*)
+}
+
+// Cast is a CAST(column AS type) selector.
+type Cast struct {
+	Span
+	Expr Term
+	Type string
+}
+
+// ListLiteral is a list literal [a, b].
+type ListLiteral struct {
+	Span
+	Elements []Term
+}
+
+// SetLiteral is a set literal {a, b}.
+type SetLiteral struct {
+	Span
+	Elements []Term
+}
+
+// MapEntry is a key/value pair of a map literal.
+type MapEntry struct {
+	Span
+	Key   Term
+	Value Term
+}
+
+// MapLiteral is a map literal {k: v}.
+type MapLiteral struct {
+	Span
+	Entries []MapEntry
+}
+
+// FieldValue is a field of a user-defined type literal.
+type FieldValue struct {
+	Span
+	Name  string
+	Value Term
+}
+
+// UDTLiteral is a user-defined type literal {field: v}.
+type UDTLiteral struct {
+	Span
+	Fields []FieldValue
+}
+
+// TupleLiteral is a parenthesized tuple (a, b). Tuples of columns appear on the
+// left side of multi-column relations.
+type TupleLiteral struct {
+	Span
+	Elements []Term
+}
+
+// BinaryExpr is an arithmetic expression in an assignment (c = c + 1, l = [x] + l).
+type BinaryExpr struct {
+	Span
+	Left  Term
+	Op    string // "+" or "-"
+	Right Term
+}
+
+// IndexExpr is an element access col[key].
+type IndexExpr struct {
+	Span
+	Target Term
+	Key    Term
+}
+
+func (*Literal) termNode()      {}
+func (*BindMarker) termNode()   {}
+func (*ColumnRef) termNode()    {}
+func (*Star) termNode()         {}
+func (*FunctionCall) termNode() {}
+func (*Cast) termNode()         {}
+func (*ListLiteral) termNode()  {}
+func (*SetLiteral) termNode()   {}
+func (*MapLiteral) termNode()   {}
+func (*UDTLiteral) termNode()   {}
+func (*TupleLiteral) termNode() {}
+func (*BinaryExpr) termNode()   {}
+func (*IndexExpr) termNode()    {}
+
+// Operator is a relation or condition operator.
+type Operator string
+
+const (
+	OpEq          Operator = "="
+	OpNeq         Operator = "!="
+	OpLt          Operator = "<"
+	OpLte         Operator = "<="
+	OpGt          Operator = ">"
+	OpGte         Operator = ">="
+	OpIn          Operator = "IN"
+	OpContains    Operator = "CONTAINS"
+	OpContainsKey Operator = "CONTAINS KEY"
+	OpLike        Operator = "LIKE"
+	OpIsNotNull   Operator = "IS NOT NULL"
+)
+
+// Relation is a WHERE restriction or an IF condition.
+type Relation struct {
+	Span
+	// Left is a ColumnRef, a TupleLiteral of columns, an IndexExpr (IF conditions)
+	// or a FunctionCall (token(...), SCYLLA_CLUSTERING_BOUND(...))
+	Left Term
+	// Op is empty for bare IF conditions (IF col)
+	Op Operator
+	// Values holds the right-hand side: one term, several for IN, none for IS NOT NULL
+	Values []Term
+	// ListMarker is set for IN ? and IN :name, whose marker in Values binds the whole list
+	ListMarker bool
+}
+
+// ColumnName returns the restricted column name, or "" if Left is not a single column.
+func (r Relation) ColumnName() string {
+	switch left := r.Left.(type) {
+	case *ColumnRef:
+		return left.Name
+	case *IndexExpr:
+		if col, ok := left.Target.(*ColumnRef); ok {
+			return col.Name
+		}
+	}
+	return ""
+}
+
+// Columns returns the columns of the left side, including each column of a tuple.
+func (r Relation) Columns() []string {
+	if tuple, ok := r.Left.(*TupleLiteral); ok {
+		var names []string
+		for _, el := range tuple.Elements {
+			if col, ok := el.(*ColumnRef); ok {
+				names = append(names, col.Name)
+			}
+		}
+		return names
+	}
+	if col := r.ColumnName(); col != "" {
+		return []string{col}
+	}
+	return nil
+}
+
+// Value returns the first right-hand side term, or nil.
+func (r Relation) Value() Term {
+	if len(r.Values) == 0 {
+		return nil
+	}
+	return r.Values[0]
+}
+
+// Condition is an IF condition of a lightweight transaction.
+type Condition struct {
+	Relation
+	Or bool // Joined to the previous condition with OR instead of AND
+}
+
+// Selector is an element of a SELECT clause.
+type Selector struct {
+	Span
+	Expr  Term // Star, ColumnRef, FunctionCall or Cast
+	Alias string
+}
+
+// Order is a sort direction.
+type Order string
+
+const (
+	OrderNone Order = ""
+	OrderAsc  Order = "ASC"
+	OrderDesc Order = "DESC"
+)
+
+// Ordering is an ORDER BY or CLUSTERING ORDER BY element.
+type Ordering struct {
+	Span
+	Column    string
+	Direction Order
+	Ann       Term // Vector of ORDER BY col ANN OF [...]
+}
+
+// Using holds the USING clause of a modification statement or batch.
+type Using struct {
+	Span
+	TTL       Term
+	Timestamp Term
+	Timeout   Term
+}
+
+// Assignment is an element of an UPDATE SET clause.
+type Assignment struct {
+	Span
+	Target Term // ColumnRef or IndexExpr (col[key] = value)
+	Value  Term
+}
+
+// ColumnName returns the assigned column name.
+func (a Assignment) ColumnName() string {
+	return Relation{Left: a.Target}.ColumnName()
+}
+
+// ColumnDef is a column definition of CREATE TABLE or ALTER TABLE ADD.
+type ColumnDef struct {
+	Span
+	Name       string
+	Type       string // Canonical type, e.g. "map<text, frozen<address>>"
+	Static     bool
+	PrimaryKey bool // Inline PRIMARY KEY
+}
+
+// FieldDef is a name and type pair (UDT fields and function parameters).
+type FieldDef struct {
+	Span
+	Name string
+	Type string
+}
+
+// PrimaryKey is a PRIMARY KEY (...) clause.
+type PrimaryKey struct {
+	Span
+	PartitionKey  []string
+	ClusteringKey []string
+}
+
+// Option is a name = value table option. Value is a Literal or MapLiteral.
+type Option struct {
+	Span
+	Name  string
+	Value Term
+}
+
+// TableOptions is the WITH clause of a table or materialized view.
+type TableOptions struct {
+	Span
+	CompactStorage  bool
+	ClusteringOrder []Ordering
+	Options         []Option
+}
+
+// Option returns the value of an option by name, or nil.
+func (o *TableOptions) Option(name string) Term {
+	if o == nil {
+		return nil
+	}
+	for _, opt := range o.Options {
+		if opt.Name == name {
+			return opt.Value
+		}
+	}
+	return nil
+}
+
+// Rename is a FROM TO pair of a RENAME clause.
+type Rename struct {
+	Span
+	From string
+	To   string
+}
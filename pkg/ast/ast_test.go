@@ -0,0 +1,571 @@
+package ast
+
+import (
+	"bufio"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/antlr4-go/antlr/v4"
+
+	parser "github.com/tentacle-scylla/scql/gen/parser"
+)
+
+// build parses a single statement and builds its syntax tree.
+// pkg/parse imports this package, so tests drive the generated parser directly.
+func build(t *testing.T, cql string) Statement {
+	t.Helper()
+	stmts := buildAll(cql)
+	if len(stmts) != 1 {
+		t.Fatalf("expected 1 statement in %q, got %d", cql, len(stmts))
+	}
+	return stmts[0]
+}
+
+func buildAll(cql string) []Statement {
+	stmts, _ := buildChecked(cql)
+	return stmts
+}
+
+// buildChecked builds all statements of cql and counts syntax errors.
+func buildChecked(cql string) ([]Statement, int) {
+	lexer := parser.NewCqlLexer(antlr.NewInputStream(cql))
+	p := parser.NewCqlParser(antlr.NewCommonTokenStream(lexer, antlr.TokenDefaultChannel))
+	errs := &errorCounter{DefaultErrorListener: antlr.NewDefaultErrorListener()}
+	lexer.RemoveErrorListeners()
+	lexer.AddErrorListener(errs)
+	p.RemoveErrorListeners()
+	p.AddErrorListener(errs)
+	root := p.Root()
+	if root.Cqls() == nil {
+		return nil, errs.count
+	}
+	var stmts []Statement
+	for _, c := range root.Cqls().AllCql() {
+		stmts = append(stmts, Build(c))
+	}
+	return stmts, errs.count
+}
+
+type errorCounter struct {
+	*antlr.DefaultErrorListener
+	count int
+}
+
+func (e *errorCounter) SyntaxError(antlr.Recognizer, any, int, int, string, antlr.RecognitionException) {
+	e.count++
+}
+
+func as[T Statement](t *testing.T, stmt Statement) T {
+	t.Helper()
+	s, ok := stmt.(T)
+	if !ok {
+		t.Fatalf("expected %T, got %T", *new(T), stmt)
+	}
+	return s
+}
+
+// source returns the text covered by a span.
+func source(input string, n Node) string {
+	s := n.NodeSpan()
+	return input[s.Start:s.End]
+}
+
+func TestSelect(t *testing.T) {
+	input := `SELECT DISTINCT id, "Name" AS n, count(*), ks.fn(a, 1), CAST(b AS text), t.* ` +
+		`FROM app.Users WHERE id = ? AND (c1, c2) > (1, 'x') AND tags CONTAINS 'a' AND v IN (1, 2) ` +
+		`GROUP BY id ORDER BY c1 DESC PER PARTITION LIMIT 2 LIMIT 10 ALLOW FILTERING BYPASS CACHE USING TIMEOUT 5s`
+	s := as[*SelectStmt](t, build(t, input))
+
+	if !s.Distinct || !s.AllowFiltering || !s.BypassCache {
+		t.Errorf("flags not set: %+v", s)
+	}
+	if s.From.Keyspace != "app" || s.From.Name != "users" || source(input, s.From) != "app.Users" {
+		t.Errorf("unexpected from: %+v", s.From)
+	}
+
+	if len(s.Selectors) != 6 {
+		t.Fatalf("expected 6 selectors, got %d", len(s.Selectors))
+	}
+	if col, ok := s.Selectors[1].Expr.(*ColumnRef); !ok || col.Name != "Name" || s.Selectors[1].Alias != "n" {
+		t.Errorf("unexpected quoted selector: %+v", s.Selectors[1])
+	}
+	if fn, ok := s.Selectors[2].Expr.(*FunctionCall); !ok || fn.Name != "count" || !fn.Star {
+		t.Errorf("unexpected count(*): %+v", s.Selectors[2].Expr)
+	}
+	if fn, ok := s.Selectors[3].Expr.(*FunctionCall); !ok || fn.Keyspace != "ks" || len(fn.Args) != 2 {
+		t.Errorf("unexpected qualified call: %+v", s.Selectors[3].Expr)
+	}
+	if cast, ok := s.Selectors[4].Expr.(*Cast); !ok || cast.Type != "text" {
+		t.Errorf("unexpected cast: %+v", s.Selectors[4].Expr)
+	}
+	if star, ok := s.Selectors[5].Expr.(*Star); !ok || star.Qualifier != "t" {
+		t.Errorf("unexpected qualified star: %+v", s.Selectors[5].Expr)
+	}
+
+	if len(s.Where) != 4 {
+		t.Fatalf("expected 4 relations, got %d", len(s.Where))
+	}
+	if r := s.Where[0]; r.ColumnName() != "id" || r.Op != OpEq || source(input, r) != "id = ?" {
+		t.Errorf("unexpected relation: %+v", r)
+	} else if _, ok := r.Value().(*BindMarker); !ok {
+		t.Errorf("expected bind marker, got %T", r.Value())
+	}
+	if r := s.Where[1]; strings.Join(r.Columns(), ",") != "c1,c2" || r.Op != OpGt || source(input, r.Left) != "(c1, c2)" {
+		t.Errorf("unexpected tuple relation: %+v", r)
+	} else if tuple, ok := r.Value().(*TupleLiteral); !ok || len(tuple.Elements) != 2 {
+		t.Errorf("unexpected tuple value: %+v", r.Value())
+	}
+	if r := s.Where[2]; r.Op != OpContains || r.Value().(*Literal).Value != "a" {
+		t.Errorf("unexpected CONTAINS: %+v", r)
+	}
+	if r := s.Where[3]; r.Op != OpIn || len(r.Values) != 2 {
+		t.Errorf("unexpected IN: %+v", r)
+	}
+
+	if len(s.GroupBy) != 1 || s.GroupBy[0].Name != "id" {
+		t.Errorf("unexpected GROUP BY: %+v", s.GroupBy)
+	}
+	if len(s.OrderBy) != 1 || s.OrderBy[0].Column != "c1" || s.OrderBy[0].Direction != OrderDesc {
+		t.Errorf("unexpected ORDER BY: %+v", s.OrderBy)
+	}
+	if lit, ok := s.Limit.(*Literal); !ok || lit.Value != "10" || lit.Kind != LiteralInteger {
+		t.Errorf("unexpected LIMIT: %+v", s.Limit)
+	}
+	if lit, ok := s.PerPartitionLimit.(*Literal); !ok || lit.Value != "2" {
+		t.Errorf("unexpected PER PARTITION LIMIT: %+v", s.PerPartitionLimit)
+	}
+	if lit, ok := s.Timeout.(*Literal); !ok || lit.Kind != LiteralDuration {
+		t.Errorf("unexpected timeout: %+v", s.Timeout)
+	}
+}
+
+func TestSpans(t *testing.T) {
+	input := "SELECT name\nFROM users\nWHERE id = 'é'  AND age > 3"
+	s := as[*SelectStmt](t, build(t, input))
+
+	span := s.NodeSpan()
+	if span.Start != 0 || span.End != len(input) || span.Line != 1 || span.Column != 0 {
+		t.Errorf("unexpected statement span: %+v", span)
+	}
+	r := s.Where[1]
+	if got := source(input, r); got != "age > 3" {
+		t.Errorf("span after multi-byte character covers %q", got)
+	}
+	if r.Line != 3 || r.Column != 20 {
+		t.Errorf("unexpected position %d:%d", r.Line, r.Column)
+	}
+	if got := source(input, s.Where[0].Value()); got != "'é'" {
+		t.Errorf("unexpected literal span %q", got)
+	}
+}
+
+func TestLiterals(t *testing.T) {
+	input := "INSERT INTO t (a, b, c, d, e, f, g, h) VALUES ('it''s', 1.5, true, 0xff, null, " +
+		"123e4567-e89b-12d3-a456-426614174000, [1, 2], {'k': {1, 2}})"
+	s := as[*InsertStmt](t, build(t, input))
+
+	want := []struct {
+		kind  LiteralKind
+		value string
+	}{
+		{LiteralString, "it's"},
+		{LiteralFloat, "1.5"},
+		{LiteralBoolean, "true"},
+		{LiteralHex, "0xff"},
+		{LiteralNull, "NULL"},
+		{LiteralUUID, "123e4567-e89b-12d3-a456-426614174000"},
+	}
+	if len(s.Values) != 8 {
+		t.Fatalf("expected 8 values, got %d", len(s.Values))
+	}
+	for i, w := range want {
+		lit, ok := s.Values[i].(*Literal)
+		if !ok || lit.Kind != w.kind || lit.Value != w.value {
+			t.Errorf("value %d: expected %s %q, got %+v", i, w.kind, w.value, s.Values[i])
+		}
+	}
+	if list, ok := s.Values[6].(*ListLiteral); !ok || len(list.Elements) != 2 {
+		t.Errorf("unexpected list: %+v", s.Values[6])
+	}
+	m, ok := s.Values[7].(*MapLiteral)
+	if !ok || len(m.Entries) != 1 {
+		t.Fatalf("unexpected map: %+v", s.Values[7])
+	}
+	if set, ok := m.Entries[0].Value.(*SetLiteral); !ok || len(set.Elements) != 2 {
+		t.Errorf("unexpected nested set: %+v", m.Entries[0].Value)
+	}
+}
+
+func TestModifications(t *testing.T) {
+	t.Run("insert", func(t *testing.T) {
+		s := as[*InsertStmt](t, build(t, "INSERT INTO ks.t (id, v) VALUES (:id, 1) IF NOT EXISTS USING TTL 60 AND TIMESTAMP 1"))
+		if s.Table.Keyspace != "ks" || len(s.Columns) != 2 || !s.IfNotExists {
+			t.Errorf("unexpected insert: %+v", s)
+		}
+		if m, ok := s.Values[0].(*BindMarker); !ok || m.Name != "id" {
+			t.Errorf("unexpected named marker: %+v", s.Values[0])
+		}
+		if s.Using == nil || s.Using.TTL == nil || s.Using.Timestamp == nil {
+			t.Errorf("unexpected USING: %+v", s.Using)
+		}
+	})
+
+	t.Run("insert json", func(t *testing.T) {
+		s := as[*InsertStmt](t, build(t, `INSERT INTO t JSON '{"id": 1}' DEFAULT UNSET`))
+		if lit, ok := s.JSON.(*Literal); !ok || lit.Value != `{"id": 1}` || s.JSONDefault != "UNSET" {
+			t.Errorf("unexpected JSON insert: %+v", s)
+		}
+	})
+
+	t.Run("update", func(t *testing.T) {
+		s := as[*UpdateStmt](t, build(t, "UPDATE t SET c = c + 1, l = [1] + l, m['k'] = 2 WHERE id = 1 IF v = 1 OR w != 2"))
+		if len(s.Assignments) != 3 {
+			t.Fatalf("expected 3 assignments, got %d", len(s.Assignments))
+		}
+		if bin, ok := s.Assignments[0].Value.(*BinaryExpr); !ok || bin.Op != "+" {
+			t.Errorf("unexpected counter assignment: %+v", s.Assignments[0].Value)
+		}
+		if bin, ok := s.Assignments[1].Value.(*BinaryExpr); !ok {
+			t.Errorf("unexpected prepend: %+v", s.Assignments[1].Value)
+		} else if _, ok := bin.Left.(*ListLiteral); !ok {
+			t.Errorf("expected list on the left, got %T", bin.Left)
+		}
+		if idx, ok := s.Assignments[2].Target.(*IndexExpr); !ok || s.Assignments[2].ColumnName() != "m" {
+			t.Errorf("unexpected map element assignment: %+v", s.Assignments[2].Target)
+		} else if key, ok := idx.Key.(*Literal); !ok || key.Value != "k" {
+			t.Errorf("unexpected key: %+v", idx.Key)
+		}
+		if len(s.Conditions) != 2 || s.Conditions[0].Or || !s.Conditions[1].Or || s.Conditions[1].Op != OpNeq {
+			t.Errorf("unexpected conditions: %+v", s.Conditions)
+		}
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		s := as[*DeleteStmt](t, build(t, "DELETE a, m['k'] FROM t USING TIMESTAMP 5 WHERE id = 1 IF EXISTS"))
+		if len(s.Columns) != 2 || s.Table.Name != "t" || !s.IfExists || s.Using == nil {
+			t.Errorf("unexpected delete: %+v", s)
+		}
+		if _, ok := s.Columns[1].(*IndexExpr); !ok {
+			t.Errorf("expected element deletion, got %T", s.Columns[1])
+		}
+	})
+
+	t.Run("batch", func(t *testing.T) {
+		s := as[*BatchStmt](t, build(t, "BEGIN UNLOGGED BATCH USING TIMESTAMP 1 "+
+			"INSERT INTO t (id) VALUES (1) UPDATE t SET v = 2 WHERE id = 1 DELETE FROM t WHERE id = 2 APPLY BATCH"))
+		if s.Type != BatchUnlogged || s.Using == nil || len(s.Statements) != 3 {
+			t.Fatalf("unexpected batch: %+v", s)
+		}
+		as[*InsertStmt](t, s.Statements[0])
+		as[*UpdateStmt](t, s.Statements[1])
+		as[*DeleteStmt](t, s.Statements[2])
+	})
+
+	t.Run("split batch", func(t *testing.T) {
+		stmts := buildAll("BEGIN BATCH INSERT INTO t (id) VALUES (1); UPDATE t SET v = 2 WHERE id = 1; APPLY BATCH;")
+		if len(stmts) != 3 {
+			t.Fatalf("expected 3 statements, got %d", len(stmts))
+		}
+		if s := as[*InsertStmt](t, stmts[0]); s.Batch == nil || s.Batch.Type != BatchDefault {
+			t.Errorf("expected BEGIN BATCH prefix: %+v", s.Batch)
+		}
+		as[*ApplyBatchStmt](t, stmts[2])
+	})
+}
+
+func TestDDL(t *testing.T) {
+	t.Run("keyspace", func(t *testing.T) {
+		s := as[*CreateKeyspaceStmt](t, build(t, "CREATE KEYSPACE IF NOT EXISTS app WITH replication = "+
+			"{'class': 'NetworkTopologyStrategy', 'dc1': 3} AND durable_writes = false"))
+		if s.Name != "app" || !s.IfNotExists || s.Replication == nil || len(s.Replication.Entries) != 2 {
+			t.Fatalf("unexpected keyspace: %+v", s)
+		}
+		if s.DurableWrites == nil || s.DurableWrites.Value != "false" {
+			t.Errorf("unexpected durable_writes: %+v", s.DurableWrites)
+		}
+	})
+
+	t.Run("table", func(t *testing.T) {
+		s := as[*CreateTableStmt](t, build(t, "CREATE TABLE ks.events (tenant text, day date, ts timeuuid, "+
+			"tags frozen<map<text, list<int>>>, total counter static, PRIMARY KEY ((tenant, day), ts)) "+
+			"WITH CLUSTERING ORDER BY (ts DESC) AND comment = 'x' AND compaction = {'class': 'LeveledCompactionStrategy'}"))
+		if len(s.Columns) != 5 || s.Columns[3].Type != "frozen<map<text, list<int>>>" || !s.Columns[4].Static {
+			t.Errorf("unexpected columns: %+v", s.Columns)
+		}
+		if s.PrimaryKey == nil || strings.Join(s.PrimaryKey.PartitionKey, ",") != "tenant,day" ||
+			strings.Join(s.PrimaryKey.ClusteringKey, ",") != "ts" {
+			t.Errorf("unexpected primary key: %+v", s.PrimaryKey)
+		}
+		if s.Options == nil || len(s.Options.ClusteringOrder) != 1 || s.Options.ClusteringOrder[0].Direction != OrderDesc {
+			t.Fatalf("unexpected options: %+v", s.Options)
+		}
+		if _, ok := s.Options.Option("compaction").(*MapLiteral); !ok {
+			t.Errorf("unexpected compaction: %+v", s.Options.Option("compaction"))
+		}
+	})
+
+	t.Run("alter table", func(t *testing.T) {
+		s := as[*AlterTableStmt](t, build(t, "ALTER TABLE t RENAME a TO b"))
+		if s.Action != AlterTableRename || s.Rename == nil || s.Rename.From != "a" || s.Rename.To != "b" {
+			t.Errorf("unexpected alter: %+v", s)
+		}
+		s = as[*AlterTableStmt](t, build(t, "ALTER TABLE t ADD (x int, y set<text>)"))
+		if s.Action != AlterTableAdd || len(s.Add) != 2 || s.Add[1].Type != "set<text>" {
+			t.Errorf("unexpected alter: %+v", s)
+		}
+	})
+
+	t.Run("index", func(t *testing.T) {
+		s := as[*CreateIndexStmt](t, build(t, "CREATE INDEX IF NOT EXISTS by_tag ON t (KEYS(tags))"))
+		if s.Name != "by_tag" || s.Table.Name != "t" || s.Target.Column != "tags" || s.Target.Kind != "KEYS" {
+			t.Errorf("unexpected index: %+v", s)
+		}
+	})
+
+	t.Run("materialized view", func(t *testing.T) {
+		s := as[*CreateMaterializedViewStmt](t, build(t, "CREATE MATERIALIZED VIEW by_email AS SELECT * FROM users "+
+			"WHERE email IS NOT NULL AND id IS NOT NULL PRIMARY KEY (email, id)"))
+		if s.Name.Name != "by_email" || s.From.Name != "users" || len(s.Where) != 2 || s.Where[0].Op != OpIsNotNull {
+			t.Errorf("unexpected view: %+v", s)
+		}
+	})
+
+	t.Run("type", func(t *testing.T) {
+		s := as[*CreateTypeStmt](t, build(t, "CREATE TYPE address (street text, zip int)"))
+		if len(s.Fields) != 2 || s.Fields[1].Name != "zip" || s.Fields[1].Type != "int" {
+			t.Errorf("unexpected type: %+v", s)
+		}
+	})
+
+	t.Run("function", func(t *testing.T) {
+		s := as[*CreateFunctionStmt](t, build(t, "CREATE OR REPLACE FUNCTION ks.twice (x int) RETURNS NULL ON NULL INPUT "+
+			"RETURNS int LANGUAGE lua AS $$ return x * 2 $$"))
+		if !s.OrReplace || s.Name.Keyspace != "ks" || len(s.Params) != 1 || s.ReturnType != "int" ||
+			s.CalledOnNull || s.Language != "lua" || strings.TrimSpace(s.Body) != "return x * 2" {
+			t.Errorf("unexpected function: %+v", s)
+		}
+	})
+
+	t.Run("aggregate", func(t *testing.T) {
+		s := as[*CreateAggregateStmt](t, build(t, "CREATE AGGREGATE avg_state (int) SFUNC acc STYPE tuple<int, bigint> "+
+			"FINALFUNC fin INITCOND (0, 0)"))
+		if s.ArgType != "int" || s.StateFunc != "acc" || s.StateType != "tuple<int, bigint>" || s.FinalFunc != "fin" ||
+			s.ReduceFunc != "" || s.InitCond != "(0, 0)" {
+			t.Errorf("unexpected aggregate: %+v", s)
+		}
+	})
+}
+
+func TestOtherStatements(t *testing.T) {
+	input := "GRANT SELECT ON KEYSPACE app TO analyst"
+	s := as[*OtherStmt](t, build(t, input))
+	if s.Text != input {
+		t.Errorf("unexpected text %q", s.Text)
+	}
+}
+
+// TestCorpus builds every query of the parser test corpus, checking that no
+// builder panics and that statement spans stay within the input.
+func TestCorpus(t *testing.T) {
+	queries := corpus(t)
+	for _, q := range queries {
+		for _, stmt := range buildAll(q) {
+			if stmt == nil {
+				t.Errorf("nil statement for %q", q)
+				continue
+			}
+			if s := stmt.NodeSpan(); s.Start < 0 || s.End > len(q) || s.Start > s.End {
+				t.Errorf("span %+v out of range for %q", s, q)
+			}
+		}
+	}
+}
+
+// corpus loads the valid queries of gen/parser/tests/queries.
+func corpus(t *testing.T) []string {
+	t.Helper()
+	files, err := filepath.Glob("../../gen/parser/tests/queries/*.cql")
+	if err != nil {
+		t.Fatal(err)
+	}
+	more, _ := filepath.Glob("../../gen/parser/tests/queries/*/*.cql")
+	files = append(files, more...)
+	if len(files) == 0 {
+		t.Fatal("no corpus files found")
+	}
+
+	var queries []string
+	for _, path := range files {
+		f, err := os.Open(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var current strings.Builder
+		skip := false
+		scanner := bufio.NewScanner(f)
+		for scanner.Scan() {
+			line := strings.TrimSpace(scanner.Text())
+			if strings.HasPrefix(line, "--") {
+				// Error check sections hold intentionally invalid queries
+				skip = skip || strings.Contains(strings.ToLower(line), "error check")
+				continue
+			}
+			if line == "" || skip {
+				continue
+			}
+			current.WriteString(line + " ")
+			if strings.HasSuffix(line, ";") {
+				queries = append(queries, strings.TrimSpace(current.String()))
+				current.Reset()
+			}
+		}
+		_ = f.Close()
+	}
+	return queries
+}
+
+func TestPrint(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{
+			`select "Name", count(*) as total from App.accounts where "key" = 'it''s' and c in (1, 2) limit 5`,
+			`SELECT "Name", count(*) AS total FROM app.accounts WHERE "key" = 'it''s' AND c IN (1, 2) LIMIT 5`,
+		},
+		{
+			"update t using ttl 10 set c = c + 1, m['a'] = 2 where id = ? if v = 1 or w != null",
+			"UPDATE t USING TTL 10 SET c = c + 1, m['a'] = 2 WHERE id = ? IF v = 1 OR w != NULL",
+		},
+		{
+			"select * from t where id in ? and c in (?) per partition limit ? limit :n",
+			"SELECT * FROM t WHERE id IN ? AND c IN (?) PER PARTITION LIMIT ? LIMIT :n",
+		},
+		{
+			"delete from t using timestamp :ts where id in :ids",
+			"DELETE FROM t USING TIMESTAMP :ts WHERE id IN :ids",
+		},
+		{
+			"select home.city as c from t where home.zip = 1",
+			"SELECT home.city AS c FROM t WHERE home.zip = 1",
+		},
+		{
+			"update t set home = {city: 'x', \"Zip\": ?}, work.location = {lat: 1.5} where id = 1",
+			"UPDATE t SET home = {city: 'x', \"Zip\": ?}, work.location = {lat: 1.5} WHERE id = 1",
+		},
+		{
+			"create table if not exists ks.t (a int, b frozen<address>, c set<text> static, primary key ((a, b), c)) " +
+				"with clustering order by (c desc) and comment = 'x'",
+			"CREATE TABLE IF NOT EXISTS ks.t (a int, b frozen<address>, c set<text> STATIC, PRIMARY KEY ((a, b), c)) " +
+				"WITH CLUSTERING ORDER BY (c DESC) AND comment = 'x'",
+		},
+		{
+			"create function f (x int) called on null input returns int language lua as 'return x'",
+			"CREATE FUNCTION f (x int) CALLED ON NULL INPUT RETURNS int LANGUAGE lua AS $$return x$$",
+		},
+	}
+	for _, tt := range tests {
+		if got := Print(build(t, tt.input)); got != tt.want {
+			t.Errorf("Print(%q)\n got: %s\nwant: %s", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestPrintModified(t *testing.T) {
+	s := as[*SelectStmt](t, build(t, "SELECT * FROM accounts WHERE id = 1"))
+	s.From.Keyspace = "Archive"
+	s.Limit = &Literal{Kind: LiteralInteger, Value: "100"}
+	s.Where = append(s.Where, Relation{
+		Left:   &ColumnRef{Name: "name"},
+		Op:     OpEq,
+		Values: []Term{&Literal{Kind: LiteralString, Value: "O'Brien"}},
+	})
+	want := `SELECT * FROM "Archive".accounts WHERE id = 1 AND name = 'O''Brien' LIMIT 100`
+	if got := Print(s); got != want {
+		t.Errorf("got %s, want %s", got, want)
+	}
+}
+
+// TestPrintRoundTrip checks that every statement of the parser corpus prints
+// to CQL that parses back to an equivalent tree.
+func TestPrintRoundTrip(t *testing.T) {
+	count := 0
+	for _, q := range corpus(t) {
+		stmts, errs := buildChecked(q)
+		if errs > 0 {
+			continue
+		}
+		for _, stmt := range stmts {
+			count++
+			printed := Print(stmt)
+			reparsed, errs := buildChecked(printed)
+			if errs > 0 || len(reparsed) != 1 {
+				t.Errorf("printed statement does not parse\n input: %s\noutput: %s", q, printed)
+				continue
+			}
+			if !reflect.DeepEqual(withoutSpans(stmt), withoutSpans(reparsed[0])) {
+				t.Errorf("round trip changed the tree\n input: %s\noutput: %s", q, printed)
+				continue
+			}
+			if again := Print(reparsed[0]); again != printed {
+				t.Errorf("printing is not stable\nfirst: %s\nthen:  %s", printed, again)
+			}
+		}
+	}
+	if count < 1500 {
+		t.Errorf("expected the full corpus, only %d statements checked", count)
+	}
+}
+
+// withoutSpans returns a deep copy of a statement with all spans zeroed.
+func withoutSpans(stmt Statement) Statement {
+	v := reflect.New(reflect.TypeOf(stmt).Elem())
+	v.Elem().Set(reflect.ValueOf(stmt).Elem())
+	clearSpans(v)
+	s, _ := v.Interface().(Statement)
+	return s
+}
+
+var spanType = reflect.TypeOf(Span{})
+
+func clearSpans(v reflect.Value) {
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if v.IsNil() {
+			return
+		}
+		if v.Kind() == reflect.Ptr {
+			clearSpans(v.Elem())
+			return
+		}
+		// Interface values are not addressable; copy, clear and store back
+		elem := v.Elem()
+		if elem.Kind() == reflect.Ptr {
+			cp := reflect.New(elem.Elem().Type())
+			cp.Elem().Set(elem.Elem())
+			clearSpans(cp)
+			v.Set(cp)
+		}
+	case reflect.Struct:
+		if v.Type() == spanType {
+			v.Set(reflect.Zero(spanType))
+			return
+		}
+		for i := 0; i < v.NumField(); i++ {
+			clearSpans(v.Field(i))
+		}
+	case reflect.Slice:
+		if v.IsNil() {
+			return
+		}
+		cp := reflect.MakeSlice(v.Type(), v.Len(), v.Len())
+		reflect.Copy(cp, v)
+		for i := 0; i < cp.Len(); i++ {
+			clearSpans(cp.Index(i))
+		}
+		v.Set(cp)
+	}
+}
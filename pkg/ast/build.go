@@ -0,0 +1,1322 @@
+package ast
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	"github.com/antlr4-go/antlr/v4"
+
+	parser "github.com/tentacle-scylla/scql/gen/parser"
+)
+
+// Build converts a parsed CQL statement into its typed syntax tree.
+// It returns nil if ctx is nil. Parts of the tree lost to syntax errors are
+// left empty rather than failing the whole build.
+func Build(ctx parser.ICqlContext) Statement {
+	if ctx == nil || ctx.GetStart() == nil {
+		return nil
+	}
+	b := newBuilder(ctx.GetStart().GetInputStream())
+
+	switch n := ctx.GetChild(0).(type) {
+	case parser.ISelect_Context:
+		return b.selectStmt(n)
+	case parser.IInsertContext:
+		return b.insertStmt(n, n.BeginBatch())
+	case parser.IUpdateContext:
+		return b.updateStmt(n, n.BeginBatch())
+	case parser.IDelete_Context:
+		return b.deleteStmt(n, n.BeginBatch())
+	case parser.IBatchContext:
+		return b.batchStmt(n)
+	case parser.IApplyBatchContext:
+		return &ApplyBatchStmt{Span: b.span(n)}
+	case parser.IUse_Context:
+		return &UseStmt{Span: b.span(n), Keyspace: identifier(n.Keyspace())}
+	case parser.ITruncateContext:
+		return &TruncateStmt{Span: b.span(n), Table: b.qualifiedName(n.Keyspace(), n.Table())}
+	case parser.ICreateKeyspaceContext:
+		return b.createKeyspace(n)
+	case parser.IAlterKeyspaceContext:
+		return b.alterKeyspace(n)
+	case parser.IDropKeyspaceContext:
+		return &DropKeyspaceStmt{Span: b.span(n), IfExists: n.IfExist() != nil, Name: identifier(n.Keyspace())}
+	case parser.ICreateTableContext:
+		return b.createTable(n)
+	case parser.IAlterTableContext:
+		return b.alterTable(n)
+	case parser.IDropTableContext:
+		return &DropTableStmt{Span: b.span(n), IfExists: n.IfExist() != nil, Table: b.qualifiedName(n.Keyspace(), n.Table())}
+	case parser.ICreateIndexContext:
+		return b.createIndex(n)
+	case parser.IDropIndexContext:
+		return b.dropIndex(n)
+	case parser.ICreateMaterializedViewContext:
+		return b.createMaterializedView(n)
+	case parser.IAlterMaterializedViewContext:
+		return &AlterMaterializedViewStmt{
+			Span:    b.span(n),
+			Name:    b.qualifiedName(n.Keyspace(), n.MaterializedView()),
+			Options: b.tableOptions(n.TableOptions()),
+		}
+	case parser.IDropMaterializedViewContext:
+		return &DropMaterializedViewStmt{Span: b.span(n), IfExists: n.IfExist() != nil, Name: b.qualifiedName(n.Keyspace(), n.MaterializedView())}
+	case parser.ICreateTypeContext:
+		return b.createType(n)
+	case parser.IAlterTypeContext:
+		return b.alterType(n)
+	case parser.IDropTypeContext:
+		return &DropTypeStmt{Span: b.span(n), IfExists: n.IfExist() != nil, Name: b.qualifiedName(n.Keyspace(), n.Type_())}
+	case parser.ICreateFunctionContext:
+		return b.createFunction(n)
+	case parser.IDropFunctionContext:
+		return &DropFunctionStmt{Span: b.span(n), IfExists: n.IfExist() != nil, Name: b.qualifiedName(n.Keyspace(), n.Function_())}
+	case parser.ICreateAggregateContext:
+		return b.createAggregate(n)
+	case parser.IDropAggregateContext:
+		return &DropAggregateStmt{
+			Span:     b.span(n),
+			IfExists: n.IfExist() != nil,
+			Name:     b.qualifiedName(n.Keyspace(), n.Aggregate()),
+			ArgType:  dataType(n.DataType()),
+		}
+	case antlr.ParserRuleContext:
+		return &OtherStmt{Span: b.span(n), Text: b.text(n)}
+	}
+	return nil
+}
+
+// builder converts parse tree nodes, mapping ANTLR character indexes to byte offsets.
+type builder struct {
+	input antlr.CharStream
+	// offsets maps character indexes to byte offsets; nil for ASCII input
+	offsets []int
+}
+
+func newBuilder(input antlr.CharStream) *builder {
+	b := &builder{input: input}
+	text := input.GetText(0, input.Size()-1)
+	if len(text) != utf8.RuneCountInString(text) {
+		b.offsets = make([]int, 0, len(text)+1)
+		for i := range text {
+			b.offsets = append(b.offsets, i)
+		}
+		b.offsets = append(b.offsets, len(text))
+	}
+	return b
+}
+
+func (b *builder) offset(index int) int {
+	if b.offsets == nil || index < 0 {
+		return index
+	}
+	if index >= len(b.offsets) {
+		return b.offsets[len(b.offsets)-1]
+	}
+	return b.offsets[index]
+}
+
+// span returns the span of a parse tree node.
+func (b *builder) span(tree antlr.Tree) Span {
+	var start, stop antlr.Token
+	switch n := tree.(type) {
+	case antlr.ParserRuleContext:
+		start, stop = n.GetStart(), n.GetStop()
+	case antlr.TerminalNode:
+		start, stop = n.GetSymbol(), n.GetSymbol()
+	}
+	if start == nil {
+		return Span{}
+	}
+	s := Span{Start: b.offset(start.GetStart()), Line: start.GetLine(), Column: start.GetColumn()}
+	s.End = s.Start
+	if stop != nil && stop.GetStop() >= start.GetStart() {
+		s.End = b.offset(stop.GetStop() + 1)
+	}
+	return s
+}
+
+// spanning returns the span from the start of one node to the end of another.
+func (b *builder) spanning(from, to antlr.Tree) Span {
+	s := b.span(from)
+	if end := b.span(to); end.End > s.End {
+		s.End = end.End
+	}
+	return s
+}
+
+// text returns the source text of a rule, including hidden tokens.
+func (b *builder) text(ctx antlr.ParserRuleContext) string {
+	start, stop := ctx.GetStart(), ctx.GetStop()
+	if start == nil || stop == nil || stop.GetStop() < start.GetStart() {
+		return ""
+	}
+	return b.input.GetText(start.GetStart(), stop.GetStop())
+}
+
+// qualifiedName builds an optionally keyspace-qualified name.
+func (b *builder) qualifiedName(ks parser.IKeyspaceContext, name antlr.ParseTree) QualifiedName {
+	if name == nil || isNil(name) {
+		return QualifiedName{}
+	}
+	q := QualifiedName{Span: b.span(name), Name: identifier(name)}
+	if ks != nil {
+		q.Keyspace = identifier(ks)
+		q.Span = b.spanning(ks, name)
+	}
+	return q
+}
+
+// fromSpec builds the table name of a FROM clause.
+func (b *builder) fromSpec(ctx parser.IFromSpecContext) QualifiedName {
+	if ctx == nil || ctx.FromSpecElement() == nil {
+		return QualifiedName{}
+	}
+	el := ctx.FromSpecElement()
+	children := el.GetChildren()
+	q := QualifiedName{Span: b.span(el)}
+	if len(children) == 0 {
+		return q
+	}
+	q.Name = identifier(children[len(children)-1])
+	if el.DOT() != nil {
+		q.Keyspace = identifier(children[0])
+	}
+	return q
+}
+
+// DML
+
+func (b *builder) selectStmt(ctx parser.ISelect_Context) *SelectStmt {
+	stmt := &SelectStmt{
+		Span:           b.span(ctx),
+		Distinct:       ctx.DistinctSpec() != nil,
+		JSON:           ctx.KwJson() != nil,
+		Selectors:      b.selectors(ctx.SelectElements()),
+		From:           b.fromSpec(ctx.FromSpec()),
+		AllowFiltering: ctx.AllowFilteringSpec() != nil,
+		BypassCache:    ctx.BypassCacheSpec() != nil,
+	}
+	if where := ctx.WhereSpec(); where != nil {
+		stmt.Where = b.relations(where.RelationElements())
+	}
+	if group := ctx.GroupBySpec(); group != nil && group.ColumnList() != nil {
+		for _, col := range group.ColumnList().AllColumn() {
+			stmt.GroupBy = append(stmt.GroupBy, &ColumnRef{Span: b.span(col), Name: identifier(col)})
+		}
+	}
+	if order := ctx.OrderSpec(); order != nil && order.OrderSpecElement() != nil {
+		el := order.OrderSpecElement()
+		ordering := Ordering{Span: b.span(el), Column: identifier(el.OBJECT_NAME())}
+		switch {
+		case el.KwAsc() != nil:
+			ordering.Direction = OrderAsc
+		case el.KwDesc() != nil:
+			ordering.Direction = OrderDesc
+		}
+		if vec := el.VectorLiteral(); vec != nil {
+			ordering.Ann = b.term(vec)
+		}
+		stmt.OrderBy = append(stmt.OrderBy, ordering)
+	}
+	if limit := ctx.PerPartitionLimitSpec(); limit != nil {
+		stmt.PerPartitionLimit = b.integer(limit)
+	}
+	if limit := ctx.LimitSpec(); limit != nil {
+		stmt.Limit = b.integer(limit)
+	}
+	if timeout := ctx.UsingTimeoutSpec(); timeout != nil {
+		stmt.Timeout = b.term(timeout.Constant())
+	}
+	return stmt
+}
+
+func (b *builder) selectors(ctx parser.ISelectElementsContext) []Selector {
+	if ctx == nil {
+		return nil
+	}
+	var selectors []Selector
+	if star := ctx.STAR(); star != nil {
+		s := b.span(star)
+		selectors = append(selectors, Selector{Span: s, Expr: &Star{Span: s}})
+	}
+	for _, el := range ctx.AllSelectElement() {
+		sel := Selector{Span: b.span(el)}
+		switch {
+		case el.STAR() != nil:
+			sel.Expr = &Star{Span: sel.Span, Qualifier: identifier(el.OBJECT_NAME(0))}
+		case el.DOT() != nil:
+			// col.field
+			field := el.OBJECT_NAME(0)
+			sel.Expr = &ColumnRef{Span: b.spanning(el, field), Name: identifier(el.ColumnRef()), Field: identifier(field)}
+		case el.ColumnRef() != nil:
+			sel.Expr = b.term(el.ColumnRef())
+		case el.FunctionCall() != nil:
+			sel.Expr = b.term(el.FunctionCall())
+		case el.CastCall() != nil:
+			sel.Expr = b.term(el.CastCall())
+		case el.QualifiedFunctionCall() != nil:
+			sel.Expr = b.term(el.QualifiedFunctionCall())
+		}
+		if el.KwAs() != nil {
+			names := el.AllOBJECT_NAME()
+			sel.Alias = identifier(names[len(names)-1])
+		}
+		selectors = append(selectors, sel)
+	}
+	return selectors
+}
+
+// insertContext is implemented by insert and batchInsert.
+type insertContext interface {
+	antlr.ParserRuleContext
+	Keyspace() parser.IKeyspaceContext
+	Table() parser.ITableContext
+	InsertColumnSpec() parser.IInsertColumnSpecContext
+	InsertValuesSpec() parser.IInsertValuesSpecContext
+	IfNotExist() parser.IIfNotExistContext
+	UsingTtlTimestamp() parser.IUsingTtlTimestampContext
+}
+
+func (b *builder) insertStmt(ctx insertContext, begin parser.IBeginBatchContext) *InsertStmt {
+	stmt := &InsertStmt{
+		Span:        b.span(ctx),
+		Batch:       b.beginBatch(begin),
+		Table:       b.qualifiedName(ctx.Keyspace(), ctx.Table()),
+		IfNotExists: ctx.IfNotExist() != nil,
+		Using:       b.using(ctx.UsingTtlTimestamp()),
+	}
+	if cols := ctx.InsertColumnSpec(); cols != nil && cols.ColumnList() != nil {
+		for _, col := range cols.ColumnList().AllColumn() {
+			stmt.Columns = append(stmt.Columns, &ColumnRef{Span: b.span(col), Name: identifier(col)})
+		}
+	}
+	if values := ctx.InsertValuesSpec(); values != nil {
+		if list := values.ExpressionList(); list != nil {
+			for _, expr := range list.AllExpression() {
+				stmt.Values = append(stmt.Values, b.term(expr))
+			}
+		}
+		if values.KwJson() != nil {
+			stmt.JSON = b.term(values.Constant())
+			if def := values.JsonDefault(); def != nil {
+				stmt.JSONDefault = "NULL"
+				if def.KwUnset() != nil {
+					stmt.JSONDefault = "UNSET"
+				}
+			}
+		}
+	}
+	return stmt
+}
+
+// updateContext is implemented by update and batchUpdate.
+type updateContext interface {
+	antlr.ParserRuleContext
+	Keyspace() parser.IKeyspaceContext
+	Table() parser.ITableContext
+	UsingTtlTimestamp() parser.IUsingTtlTimestampContext
+	Assignments() parser.IAssignmentsContext
+	WhereSpec() parser.IWhereSpecContext
+	IfExist() parser.IIfExistContext
+	IfSpec() parser.IIfSpecContext
+}
+
+func (b *builder) updateStmt(ctx updateContext, begin parser.IBeginBatchContext) *UpdateStmt {
+	stmt := &UpdateStmt{
+		Span:     b.span(ctx),
+		Batch:    b.beginBatch(begin),
+		Table:    b.qualifiedName(ctx.Keyspace(), ctx.Table()),
+		Using:    b.using(ctx.UsingTtlTimestamp()),
+		IfExists: ctx.IfExist() != nil,
+	}
+	if assignments := ctx.Assignments(); assignments != nil {
+		for _, el := range assignments.AllAssignmentElement() {
+			stmt.Assignments = append(stmt.Assignments, b.assignment(el))
+		}
+	}
+	if where := ctx.WhereSpec(); where != nil {
+		stmt.Where = b.relations(where.RelationElements())
+	}
+	stmt.Conditions, stmt.IfExists = b.ifSpec(ctx.IfSpec(), stmt.IfExists)
+	return stmt
+}
+
+// deleteContext is implemented by delete_ and batchDelete.
+type deleteContext interface {
+	antlr.ParserRuleContext
+	DeleteColumnList() parser.IDeleteColumnListContext
+	FromSpec() parser.IFromSpecContext
+	UsingTimestampSpec() parser.IUsingTimestampSpecContext
+	WhereSpec() parser.IWhereSpecContext
+	IfExist() parser.IIfExistContext
+	IfSpec() parser.IIfSpecContext
+}
+
+func (b *builder) deleteStmt(ctx deleteContext, begin parser.IBeginBatchContext) *DeleteStmt {
+	stmt := &DeleteStmt{
+		Span:     b.span(ctx),
+		Batch:    b.beginBatch(begin),
+		Table:    b.fromSpec(ctx.FromSpec()),
+		Using:    b.using(ctx.UsingTimestampSpec()),
+		IfExists: ctx.IfExist() != nil,
+	}
+	if cols := ctx.DeleteColumnList(); cols != nil {
+		for _, item := range cols.AllDeleteColumnItem() {
+			col := &ColumnRef{Span: b.span(item.OBJECT_NAME()), Name: identifier(item.OBJECT_NAME())}
+			switch {
+			case item.StringLiteral() != nil:
+				stmt.Columns = append(stmt.Columns, &IndexExpr{Span: b.span(item), Target: col, Key: b.term(item.StringLiteral())})
+			case item.DecimalLiteral() != nil:
+				stmt.Columns = append(stmt.Columns, &IndexExpr{Span: b.span(item), Target: col, Key: b.term(item.DecimalLiteral())})
+			case item.QMARK() != nil:
+				stmt.Columns = append(stmt.Columns, &IndexExpr{Span: b.span(item), Target: col, Key: b.term(item.QMARK())})
+			case item.NamedMarker() != nil:
+				stmt.Columns = append(stmt.Columns, &IndexExpr{Span: b.span(item), Target: col, Key: b.term(item.NamedMarker())})
+			default:
+				stmt.Columns = append(stmt.Columns, col)
+			}
+		}
+	}
+	if where := ctx.WhereSpec(); where != nil {
+		stmt.Where = b.relations(where.RelationElements())
+	}
+	stmt.Conditions, stmt.IfExists = b.ifSpec(ctx.IfSpec(), stmt.IfExists)
+	return stmt
+}
+
+func (b *builder) batchStmt(ctx parser.IBatchContext) *BatchStmt {
+	stmt := &BatchStmt{
+		Span:  b.span(ctx),
+		Type:  batchType(ctx.BatchType()),
+		Using: b.using(ctx.UsingTimestampSpec()),
+	}
+	if list := ctx.BatchStatementList(); list != nil {
+		for _, s := range list.AllBatchStatement() {
+			switch {
+			case s.BatchInsert() != nil:
+				stmt.Statements = append(stmt.Statements, b.insertStmt(s.BatchInsert(), nil))
+			case s.BatchUpdate() != nil:
+				stmt.Statements = append(stmt.Statements, b.updateStmt(s.BatchUpdate(), nil))
+			case s.BatchDelete() != nil:
+				stmt.Statements = append(stmt.Statements, b.deleteStmt(s.BatchDelete(), nil))
+			}
+		}
+	}
+	return stmt
+}
+
+func (b *builder) beginBatch(ctx parser.IBeginBatchContext) *BeginBatch {
+	if ctx == nil {
+		return nil
+	}
+	return &BeginBatch{Span: b.span(ctx), Type: batchType(ctx.BatchType()), Using: b.using(ctx.UsingTimestampSpec())}
+}
+
+func batchType(ctx parser.IBatchTypeContext) BatchType {
+	switch {
+	case ctx == nil:
+		return BatchDefault
+	case ctx.KwLogged() != nil:
+		return BatchLogged
+	case ctx.KwUnlogged() != nil:
+		return BatchUnlogged
+	}
+	return BatchCounter
+}
+
+// using builds a USING clause from usingTtlTimestamp or usingTimestampSpec.
+func (b *builder) using(ctx antlr.ParserRuleContext) *Using {
+	if ctx == nil || isNil(ctx) {
+		return nil
+	}
+	u := &Using{Span: b.span(ctx)}
+	for _, child := range ctx.GetChildren() {
+		switch c := child.(type) {
+		case parser.ITtlContext:
+			u.TTL = b.integer(c)
+		case parser.ITimestampContext:
+			u.Timestamp = b.integer(c)
+		case parser.IConstantContext:
+			u.Timeout = b.term(c)
+		}
+	}
+	return u
+}
+
+func (b *builder) assignment(ctx parser.IAssignmentElementContext) Assignment {
+	a := Assignment{Span: b.span(ctx)}
+	var operands []Term
+	op := ""
+	afterEq := false
+	for _, child := range ctx.GetChildren() {
+		if t, ok := child.(antlr.TerminalNode); ok {
+			switch t.GetSymbol().GetTokenType() {
+			case parser.CqlParserOPERATOR_EQ:
+				afterEq = true
+			case parser.CqlParserPLUS, parser.CqlParserMINUS:
+				op = t.GetText()
+			case parser.CqlParserOBJECT_NAME:
+				// col.field = value
+				if col, ok := a.Target.(*ColumnRef); ok {
+					col.Field = identifier(t)
+					col.Span = b.spanning(ctx, t)
+				}
+			}
+			continue
+		}
+		switch c := child.(type) {
+		case parser.IColumnRefContext:
+			if a.Target == nil {
+				a.Target = b.term(c)
+			} else {
+				operands = append(operands, b.term(c))
+			}
+		case parser.IAssignmentIndexKeyContext:
+			a.Target = &IndexExpr{Span: b.spanning(ctx, c), Target: a.Target, Key: b.term(c)}
+		case parser.ISyntaxBracketRsContext:
+			if index, ok := a.Target.(*IndexExpr); ok {
+				index.Span = b.spanning(ctx, c)
+			}
+		case antlr.ParserRuleContext:
+			if afterEq {
+				operands = append(operands, b.term(c))
+			}
+		}
+	}
+	switch {
+	case op != "" && len(operands) == 2:
+		span := operands[0].NodeSpan()
+		span.End = operands[1].NodeSpan().End
+		a.Value = &BinaryExpr{Span: span, Left: operands[0], Op: op, Right: operands[1]}
+	case len(operands) > 0:
+		a.Value = operands[0]
+	}
+	return a
+}
+
+// ifSpec builds the conditions of an IF clause and reports IF EXISTS.
+func (b *builder) ifSpec(ctx parser.IIfSpecContext, ifExists bool) ([]Condition, bool) {
+	if ctx == nil {
+		return nil, ifExists
+	}
+	list := ctx.IfConditionList()
+	if list == nil {
+		// IF NOT EXISTS is accepted by the grammar here but is not meaningful
+		return nil, ifExists
+	}
+	var conditions []Condition
+	or := false
+	for _, child := range list.GetChildren() {
+		switch c := child.(type) {
+		case parser.IKwOrContext:
+			or = true
+		case parser.IKwAndContext:
+			or = false
+		case parser.IIfConditionContext:
+			conditions = append(conditions, Condition{Relation: b.relation(c), Or: or})
+		}
+	}
+	return conditions, ifExists
+}
+
+// Relations
+
+func (b *builder) relations(ctx parser.IRelationElementsContext) []Relation {
+	if ctx == nil {
+		return nil
+	}
+	var relations []Relation
+	for _, el := range ctx.AllRelationElement() {
+		relations = append(relations, b.relation(el))
+	}
+	return relations
+}
+
+// relation builds a relation from relationElement, ifCondition or mvWhereClause.
+// The rules have many alternatives, so the children are read in order: terms
+// before the operator form the left side and terms after it the values.
+func (b *builder) relation(ctx antlr.ParserRuleContext) Relation {
+	r := Relation{Span: b.span(ctx)}
+	var left []Term
+	var tuple *Span
+	var index *IndexExpr
+
+	for _, child := range ctx.GetChildren() {
+		if t, ok := child.(antlr.TerminalNode); ok {
+			tok := t.GetSymbol()
+			switch tok.GetTokenType() {
+			case parser.CqlParserOPERATOR_EQ, parser.CqlParserOPERATOR_NEQ, parser.CqlParserOPERATOR_LT,
+				parser.CqlParserOPERATOR_GT, parser.CqlParserOPERATOR_LTE, parser.CqlParserOPERATOR_GTE:
+				r.Op = Operator(tok.GetText())
+			case parser.CqlParserLR_BRACKET:
+				if r.Op == "" {
+					open := b.span(t)
+					tuple = &open
+				}
+			case parser.CqlParserRR_BRACKET:
+				if r.Op == "" && tuple != nil {
+					tuple.End = b.span(t).End
+				}
+			case parser.CqlParserQMARK:
+				// IN ?
+				r.Values = append(r.Values, b.term(t))
+				r.ListMarker = true
+			case parser.CqlParserOBJECT_NAME:
+				// col.field
+				if n := len(left); n > 0 {
+					if col, ok := left[n-1].(*ColumnRef); ok {
+						col.Field = identifier(t)
+						col.Span = b.spanning(ctx, t)
+					}
+				}
+			}
+			continue
+		}
+
+		switch c := child.(type) {
+		case parser.IRelalationContainsContext, parser.IRelalationContainsKeyContext:
+			inner := b.relation(c.(antlr.ParserRuleContext))
+			inner.Span = r.Span
+			return inner
+		case parser.IKwInContext:
+			r.Op = OpIn
+		case parser.INamedMarkerContext:
+			// IN :name
+			r.Values = append(r.Values, b.term(c))
+			r.ListMarker = true
+		case parser.IKwLikeContext:
+			r.Op = OpLike
+		case parser.IKwContainsContext:
+			r.Op = OpContains
+		case parser.IKwKeyContext:
+			r.Op = OpContainsKey
+		case parser.IKwIsContext:
+			r.Op = OpIsNotNull
+		case parser.ISyntaxBracketLsContext, parser.ISyntaxBracketLcContext:
+			// col[key] in IF conditions
+			if r.Op == "" && len(left) > 0 {
+				index = &IndexExpr{Target: left[len(left)-1]}
+				left = left[:len(left)-1]
+			}
+		case parser.ISyntaxBracketRsContext, parser.ISyntaxBracketRcContext:
+			if index != nil {
+				index.Span = b.spanning(ctx, c)
+				left = append(left, index)
+				index = nil
+			}
+		case parser.IFunctionArgsContext:
+			args := b.functionArgs(c)
+			if r.Op == OpIn {
+				r.Values = append(r.Values, args...)
+			} else {
+				r.Values = append(r.Values, &TupleLiteral{Span: b.span(c), Elements: args})
+			}
+		case parser.ISyntaxBracketLrContext, parser.ISyntaxBracketRrContext, parser.ISyntaxCommaContext,
+			parser.IKwNotContext, parser.IKwNullContext:
+		case antlr.ParserRuleContext:
+			term := b.term(c)
+			switch {
+			case index != nil:
+				index.Key = term
+			case r.Op == "":
+				left = append(left, term)
+			default:
+				r.Values = append(r.Values, term)
+			}
+		}
+	}
+
+	if tuple != nil {
+		r.Left = &TupleLiteral{Span: *tuple, Elements: left}
+	} else if len(left) == 1 {
+		r.Left = left[0]
+	}
+	return r
+}
+
+func (b *builder) functionArgs(ctx parser.IFunctionArgsContext) []Term {
+	if ctx == nil {
+		return nil
+	}
+	var args []Term
+	for _, child := range ctx.GetChildren() {
+		if c, ok := child.(antlr.ParserRuleContext); ok {
+			if _, comma := c.(parser.ISyntaxCommaContext); !comma {
+				args = append(args, b.term(c))
+			}
+		}
+	}
+	return args
+}
+
+// Terms
+
+// term builds a term from any value rule. Wrapper rules with a single child
+// (expression, assignmentSetElement, ifConditionValue, ...) are unwrapped.
+func (b *builder) term(tree antlr.Tree) Term {
+	if tree == nil || isNil(tree) {
+		return nil
+	}
+	switch c := tree.(type) {
+	case parser.IConstantContext:
+		return b.constant(c)
+	case parser.IColumnRefContext:
+		return &ColumnRef{Span: b.span(c), Name: identifier(c)}
+	case parser.IFunctionCallContext:
+		return b.functionCall(c)
+	case parser.IQualifiedFunctionCallContext:
+		names := c.AllOBJECT_NAME()
+		fn := &FunctionCall{Span: b.span(c), Args: b.functionArgs(c.FunctionArgs())}
+		if len(names) == 2 {
+			fn.Keyspace, fn.Name = identifier(names[0]), identifier(names[1])
+		}
+		return fn
+	case parser.ICastCallContext:
+		cast := &Cast{Span: b.span(c), Type: dataType(c.DataType())}
+		if name := c.OBJECT_NAME(); name != nil {
+			cast.Expr = &ColumnRef{Span: b.span(name), Name: identifier(name)}
+		}
+		return cast
+	case parser.IScyllaClusteringBoundContext:
+		return &FunctionCall{Span: b.span(c), Name: "scylla_clustering_bound", Args: b.functionArgs(c.FunctionArgs())}
+	case parser.IAssignmentMapContext:
+		if isUDTLiteral(c) {
+			return b.udtLiteral(c)
+		}
+		m := &MapLiteral{Span: b.span(c)}
+		for _, entry := range c.AllAssignmentMapEntry() {
+			m.Entries = append(m.Entries, MapEntry{
+				Span:  b.span(entry),
+				Key:   b.term(entry.AssignmentMapKey()),
+				Value: b.term(entry.AssignmentMapValue()),
+			})
+		}
+		return m
+	case parser.IOptionHashContext:
+		m := &MapLiteral{Span: b.span(c)}
+		for _, item := range c.AllOptionHashItem() {
+			m.Entries = append(m.Entries, MapEntry{
+				Span:  b.span(item),
+				Key:   b.term(item.OptionHashKey()),
+				Value: b.term(item.OptionHashValue()),
+			})
+		}
+		return m
+	case parser.IAssignmentSetContext:
+		set := &SetLiteral{Span: b.span(c)}
+		for _, el := range c.AllAssignmentSetElement() {
+			set.Elements = append(set.Elements, b.term(el))
+		}
+		return set
+	case parser.IAssignmentListContext:
+		list := &ListLiteral{Span: b.span(c)}
+		for _, el := range c.AllAssignmentListElement() {
+			list.Elements = append(list.Elements, b.term(el))
+		}
+		return list
+	case parser.IVectorLiteralContext:
+		list := &ListLiteral{Span: b.span(c)}
+		for _, child := range c.GetChildren() {
+			switch el := child.(type) {
+			case parser.IFloatLiteralContext, parser.IDecimalLiteralContext:
+				list.Elements = append(list.Elements, b.term(el))
+			}
+		}
+		return list
+	case parser.IAssignmentTupleContext:
+		tuple := &TupleLiteral{Span: b.span(c)}
+		for _, expr := range c.AllExpression() {
+			tuple.Elements = append(tuple.Elements, b.term(expr))
+		}
+		return tuple
+	case parser.INamedMarkerContext:
+		return &BindMarker{Span: b.span(c), Name: identifier(c.OBJECT_NAME())}
+	case parser.IStringLiteralContext, parser.IDecimalLiteralContext, parser.IFloatLiteralContext,
+		parser.IBooleanLiteralContext, parser.IHexadecimalLiteralContext, parser.IDurationLiteralContext,
+		parser.ICodeBlockContext, parser.IKwNullContext:
+		return b.literal(c.(antlr.ParserRuleContext))
+	case antlr.TerminalNode:
+		switch c.GetSymbol().GetTokenType() {
+		case parser.CqlParserOBJECT_NAME:
+			return &ColumnRef{Span: b.span(c), Name: identifier(c)}
+		case parser.CqlParserQMARK:
+			return &BindMarker{Span: b.span(c)}
+		}
+		return b.literal(c)
+	case antlr.ParserRuleContext:
+		if c.GetChildCount() == 1 {
+			return b.term(c.GetChild(0))
+		}
+	}
+	return nil
+}
+
+// isUDTLiteral reports whether a map literal is a user-defined type literal,
+// whose keys are field names: {city: 'Paris'}.
+func isUDTLiteral(ctx parser.IAssignmentMapContext) bool {
+	entries := ctx.AllAssignmentMapEntry()
+	if len(entries) == 0 {
+		return false
+	}
+	key := entries[0].AssignmentMapKey()
+	return key != nil && key.OBJECT_NAME() != nil
+}
+
+func (b *builder) udtLiteral(ctx parser.IAssignmentMapContext) *UDTLiteral {
+	udt := &UDTLiteral{Span: b.span(ctx)}
+	for _, entry := range ctx.AllAssignmentMapEntry() {
+		udt.Fields = append(udt.Fields, FieldValue{
+			Span:  b.span(entry),
+			Name:  identifier(entry.AssignmentMapKey()),
+			Value: b.term(entry.AssignmentMapValue()),
+		})
+	}
+	return udt
+}
+
+// integerContext is a rule taking an integer or a bind marker: LIMIT,
+// PER PARTITION LIMIT, TTL and TIMESTAMP.
+type integerContext interface {
+	DecimalLiteral() parser.IDecimalLiteralContext
+	QMARK() antlr.TerminalNode
+	NamedMarker() parser.INamedMarkerContext
+}
+
+func (b *builder) integer(ctx integerContext) Term {
+	switch {
+	case ctx.QMARK() != nil:
+		return b.term(ctx.QMARK())
+	case ctx.NamedMarker() != nil:
+		return b.term(ctx.NamedMarker())
+	}
+	return b.term(ctx.DecimalLiteral())
+}
+
+func (b *builder) constant(ctx parser.IConstantContext) Term {
+	switch {
+	case ctx.QMARK() != nil:
+		return &BindMarker{Span: b.span(ctx)}
+	case ctx.NamedMarker() != nil:
+		return &BindMarker{Span: b.span(ctx), Name: identifier(ctx.NamedMarker().OBJECT_NAME())}
+	case ctx.KwEmpty() != nil:
+		return &Literal{Span: b.span(ctx), Kind: LiteralEmpty, Value: "EMPTY"}
+	case ctx.UUID() != nil:
+		return &Literal{Span: b.span(ctx), Kind: LiteralUUID, Value: ctx.GetText()}
+	}
+	return b.literal(ctx)
+}
+
+// literal builds a literal from a rule or token; the kind follows its token type.
+func (b *builder) literal(tree antlr.ParseTree) *Literal {
+	lit := &Literal{Span: b.span(tree), Value: tree.GetText()}
+	tok := firstToken(tree)
+	if tok == nil {
+		return lit
+	}
+	switch tok.GetTokenType() {
+	case parser.CqlParserSTRING_LITERAL:
+		lit.Kind, lit.Value = LiteralString, unquoteString(lit.Value)
+	case parser.CqlParserCODE_BLOCK:
+		lit.Kind, lit.Value = LiteralCodeBlock, lit.Value[2:len(lit.Value)-2]
+	case parser.CqlParserDECIMAL_LITERAL:
+		lit.Kind = LiteralInteger
+	case parser.CqlParserFLOAT_LITERAL, parser.CqlParserREAL_LITERAL:
+		lit.Kind = LiteralFloat
+		if !strings.ContainsAny(lit.Value, ".eE") {
+			lit.Kind = LiteralInteger
+		}
+	case parser.CqlParserHEXADECIMAL_LITERAL:
+		lit.Kind = LiteralHex
+	case parser.CqlParserDURATION_LITERAL:
+		lit.Kind = LiteralDuration
+	case parser.CqlParserK_TRUE, parser.CqlParserK_FALSE:
+		lit.Kind, lit.Value = LiteralBoolean, strings.ToLower(lit.Value)
+	case parser.CqlParserK_NULL:
+		lit.Kind, lit.Value = LiteralNull, "NULL"
+	case parser.CqlParserUUID:
+		lit.Kind = LiteralUUID
+	}
+	return lit
+}
+
+func (b *builder) functionCall(ctx parser.IFunctionCallContext) *FunctionCall {
+	fn := &FunctionCall{Span: b.span(ctx), Star: ctx.STAR() != nil}
+	switch {
+	case ctx.K_UUID() != nil:
+		fn.Name = "uuid"
+	case ctx.KwWritetime() != nil, ctx.KwTtl() != nil:
+		fn.Name = strings.ToLower(ctx.GetChild(0).(antlr.ParseTree).GetText())
+		if name := ctx.OBJECT_NAME(); name != nil {
+			fn.Args = []Term{&ColumnRef{Span: b.span(name), Name: identifier(name)}}
+		}
+	case ctx.KwToken() != nil:
+		fn.Name = "token"
+		fn.Args = b.functionArgs(ctx.FunctionArgs())
+	default:
+		fn.Name = identifier(ctx.OBJECT_NAME())
+		fn.Args = b.functionArgs(ctx.FunctionArgs())
+	}
+	return fn
+}
+
+// DDL
+
+func (b *builder) createKeyspace(ctx parser.ICreateKeyspaceContext) *CreateKeyspaceStmt {
+	stmt := &CreateKeyspaceStmt{
+		Span:        b.span(ctx),
+		IfNotExists: ctx.IfNotExist() != nil,
+		Name:        identifier(ctx.Keyspace()),
+		Replication: b.replication(ctx.SyntaxBracketLc(), ctx.ReplicationList(), ctx.SyntaxBracketRc()),
+	}
+	if dw := ctx.DurableWrites(); dw != nil && dw.BooleanLiteral() != nil {
+		stmt.DurableWrites = b.literal(dw.BooleanLiteral())
+	}
+	if tablets := ctx.TabletsSpec(); tablets != nil {
+		m := &MapLiteral{Span: b.spanning(tablets.SyntaxBracketLc(), tablets.SyntaxBracketRc())}
+		if opts := tablets.TabletsOptions(); opts != nil {
+			for _, opt := range opts.AllTabletsOption() {
+				entry := MapEntry{Span: b.span(opt), Key: b.term(opt.StringLiteral(0))}
+				switch {
+				case opt.StringLiteral(1) != nil:
+					entry.Value = b.term(opt.StringLiteral(1))
+				case opt.BooleanLiteral() != nil:
+					entry.Value = b.term(opt.BooleanLiteral())
+				case opt.DecimalLiteral() != nil:
+					entry.Value = b.term(opt.DecimalLiteral())
+				}
+				m.Entries = append(m.Entries, entry)
+			}
+		}
+		stmt.Tablets = m
+	}
+	return stmt
+}
+
+func (b *builder) alterKeyspace(ctx parser.IAlterKeyspaceContext) *AlterKeyspaceStmt {
+	stmt := &AlterKeyspaceStmt{
+		Span:        b.span(ctx),
+		Name:        identifier(ctx.Keyspace()),
+		Replication: b.replication(ctx.SyntaxBracketLc(), ctx.ReplicationList(), ctx.SyntaxBracketRc()),
+	}
+	if dw := ctx.DurableWrites(); dw != nil && dw.BooleanLiteral() != nil {
+		stmt.DurableWrites = b.literal(dw.BooleanLiteral())
+	}
+	return stmt
+}
+
+func (b *builder) replication(open antlr.Tree, list parser.IReplicationListContext, close antlr.Tree) *MapLiteral {
+	if list == nil {
+		return nil
+	}
+	m := &MapLiteral{Span: b.span(list)}
+	if open != nil && close != nil && !isNil(open) && !isNil(close) {
+		m.Span = b.spanning(open, close)
+	}
+	for _, item := range list.AllReplicationListItem() {
+		entry := MapEntry{Span: b.span(item), Key: b.term(item.STRING_LITERAL(0))}
+		if v := item.STRING_LITERAL(1); v != nil {
+			entry.Value = b.term(v)
+		} else if v := item.DECIMAL_LITERAL(); v != nil {
+			entry.Value = b.term(v)
+		}
+		m.Entries = append(m.Entries, entry)
+	}
+	return m
+}
+
+func (b *builder) createTable(ctx parser.ICreateTableContext) *CreateTableStmt {
+	stmt := &CreateTableStmt{
+		Span:        b.span(ctx),
+		IfNotExists: ctx.IfNotExist() != nil,
+		Table:       b.qualifiedName(ctx.Keyspace(), ctx.Table()),
+	}
+	if defs := ctx.ColumnDefinitionList(); defs != nil {
+		for _, def := range defs.AllColumnDefinition() {
+			stmt.Columns = append(stmt.Columns, b.columnDef(def))
+		}
+		stmt.PrimaryKey = b.primaryKey(defs.PrimaryKeyElement())
+	}
+	if with := ctx.WithElement(); with != nil {
+		stmt.Options = b.tableOptions(with.TableOptions())
+		stmt.Options.Span = b.span(with)
+	}
+	return stmt
+}
+
+func (b *builder) columnDef(ctx parser.IColumnDefinitionContext) ColumnDef {
+	return ColumnDef{
+		Span:       b.span(ctx),
+		Name:       identifier(ctx.Column()),
+		Type:       dataType(ctx.DataType()),
+		Static:     ctx.StaticColumn() != nil,
+		PrimaryKey: ctx.PrimaryKeyColumn() != nil,
+	}
+}
+
+func (b *builder) primaryKey(ctx parser.IPrimaryKeyElementContext) *PrimaryKey {
+	if ctx == nil || ctx.PrimaryKeyDefinition() == nil {
+		return nil
+	}
+	pk := &PrimaryKey{Span: b.span(ctx)}
+	def := ctx.PrimaryKeyDefinition()
+	var clustering parser.IClusteringKeyListContext
+	switch {
+	case def.SinglePrimaryKey() != nil:
+		pk.PartitionKey = []string{identifier(def.SinglePrimaryKey().Column())}
+	case def.CompoundKey() != nil:
+		if key := def.CompoundKey().PartitionKey(); key != nil {
+			pk.PartitionKey = []string{identifier(key.Column())}
+		}
+		clustering = def.CompoundKey().ClusteringKeyList()
+	case def.CompositeKey() != nil:
+		if list := def.CompositeKey().PartitionKeyList(); list != nil {
+			for _, key := range list.AllPartitionKey() {
+				pk.PartitionKey = append(pk.PartitionKey, identifier(key.Column()))
+			}
+		}
+		clustering = def.CompositeKey().ClusteringKeyList()
+	}
+	if clustering != nil {
+		for _, key := range clustering.AllClusteringKey() {
+			pk.ClusteringKey = append(pk.ClusteringKey, identifier(key.Column()))
+		}
+	}
+	return pk
+}
+
+// tableOptions flattens the recursive tableOptions rule.
+func (b *builder) tableOptions(ctx parser.ITableOptionsContext) *TableOptions {
+	if ctx == nil {
+		return nil
+	}
+	opts := &TableOptions{Span: b.span(ctx)}
+	for ctx != nil {
+		if ctx.KwCompact() != nil {
+			opts.CompactStorage = true
+		}
+		if order := ctx.ClusteringOrder(); order != nil {
+			opts.ClusteringOrder = append(opts.ClusteringOrder, b.clusteringOrder(order)...)
+		}
+		for _, item := range ctx.AllTableOptionItem() {
+			opt := Option{Span: b.span(item), Name: strings.ToLower(item.TableOptionName().GetText())}
+			if v := item.TableOptionValue(); v != nil {
+				opt.Value = b.term(v)
+			} else {
+				opt.Value = b.term(item.OptionHash())
+			}
+			opts.Options = append(opts.Options, opt)
+		}
+		ctx = ctx.TableOptions()
+	}
+	return opts
+}
+
+func (b *builder) clusteringOrder(ctx parser.IClusteringOrderContext) []Ordering {
+	var orders []Ordering
+	for _, child := range ctx.GetChildren() {
+		switch c := child.(type) {
+		case parser.IColumnContext:
+			orders = append(orders, Ordering{Span: b.span(c), Column: identifier(c)})
+		case parser.IOrderDirectionContext:
+			if n := len(orders); n > 0 {
+				orders[n-1].Span.End = b.span(c).End
+				orders[n-1].Direction = OrderAsc
+				if c.KwDesc() != nil {
+					orders[n-1].Direction = OrderDesc
+				}
+			}
+		}
+	}
+	return orders
+}
+
+func (b *builder) alterTable(ctx parser.IAlterTableContext) *AlterTableStmt {
+	stmt := &AlterTableStmt{Span: b.span(ctx), Table: b.qualifiedName(ctx.Keyspace(), ctx.Table())}
+	op := ctx.AlterTableOperation()
+	if op == nil {
+		return stmt
+	}
+	switch {
+	case op.AlterTableAdd() != nil:
+		stmt.Action = AlterTableAdd
+		add := op.AlterTableAdd()
+		if add.Column() != nil {
+			stmt.Add = append(stmt.Add, ColumnDef{
+				Span:   b.span(add),
+				Name:   identifier(add.Column()),
+				Type:   dataType(add.DataType()),
+				Static: add.StaticColumn() != nil,
+			})
+		}
+		for _, def := range add.AllColumnDefinition() {
+			stmt.Add = append(stmt.Add, b.columnDef(def))
+		}
+	case op.KwAlter() != nil:
+		stmt.Action = AlterTableAlter
+		if op.Column() != nil && op.DataType() != nil {
+			stmt.Alter = &FieldDef{Span: b.spanning(op.Column(), op.DataType()), Name: identifier(op.Column()), Type: dataType(op.DataType())}
+		}
+	case op.AlterTableDropColumns() != nil:
+		stmt.Action = AlterTableDrop
+		if list := op.AlterTableDropColumns().AlterTableDropColumnList(); list != nil {
+			for _, col := range list.AllColumn() {
+				stmt.Drop = append(stmt.Drop, identifier(col))
+			}
+		}
+	case op.AlterTableDropCompactStorage() != nil:
+		stmt.Action = AlterTableDropCompactStorage
+	case op.AlterTableRename() != nil:
+		stmt.Action = AlterTableRename
+		if cols := op.AlterTableRename().AllColumn(); len(cols) == 2 {
+			stmt.Rename = &Rename{Span: b.span(op.AlterTableRename()), From: identifier(cols[0]), To: identifier(cols[1])}
+		}
+	case op.AlterTableWith() != nil:
+		stmt.Action = AlterTableWith
+		stmt.Options = b.tableOptions(op.AlterTableWith().TableOptions())
+	}
+	return stmt
+}
+
+func (b *builder) createIndex(ctx parser.ICreateIndexContext) *CreateIndexStmt {
+	stmt := &CreateIndexStmt{
+		Span:        b.span(ctx),
+		Custom:      ctx.KwCustom() != nil,
+		IfNotExists: ctx.IfNotExist() != nil,
+		Table:       b.qualifiedName(ctx.Keyspace(), ctx.Table()),
+	}
+	if name := ctx.OBJECT_NAME(); name != nil {
+		stmt.Name = identifier(name)
+	}
+	if spec := ctx.IndexColumnSpec(); spec != nil {
+		stmt.Target = IndexTarget{Span: b.span(spec)}
+		switch {
+		case spec.IndexKeysSpec() != nil:
+			stmt.Target.Kind, stmt.Target.Column = "KEYS", identifier(spec.IndexKeysSpec().OBJECT_NAME())
+		case spec.IndexEntriesSSpec() != nil:
+			stmt.Target.Kind, stmt.Target.Column = "ENTRIES", identifier(spec.IndexEntriesSSpec().OBJECT_NAME())
+		case spec.IndexFullSpec() != nil:
+			stmt.Target.Kind, stmt.Target.Column = "FULL", identifier(spec.IndexFullSpec().OBJECT_NAME())
+		default:
+			stmt.Target.Column = identifier(spec.Column())
+		}
+	}
+	if using := ctx.IndexUsing(); using != nil {
+		if lit := using.StringLiteral(); lit != nil {
+			stmt.Using = unquoteString(lit.GetText())
+		}
+		if opts := using.IndexOptions(); opts != nil && opts.OptionHash() != nil {
+			if m, ok := b.term(opts.OptionHash()).(*MapLiteral); ok {
+				stmt.Options = m
+			}
+		}
+	}
+	return stmt
+}
+
+func (b *builder) dropIndex(ctx parser.IDropIndexContext) *DropIndexStmt {
+	stmt := &DropIndexStmt{Span: b.span(ctx), IfExists: ctx.IfExist() != nil}
+	if name := ctx.IndexName(); name != nil {
+		stmt.Name = b.qualifiedName(ctx.Keyspace(), name)
+		if lit := name.StringLiteral(); lit != nil {
+			stmt.Name.Name = unquoteString(lit.GetText())
+		}
+	}
+	return stmt
+}
+
+func (b *builder) createMaterializedView(ctx parser.ICreateMaterializedViewContext) *CreateMaterializedViewStmt {
+	stmt := &CreateMaterializedViewStmt{
+		Span:        b.span(ctx),
+		IfNotExists: ctx.IfNotExist() != nil,
+		Name:        b.qualifiedName(ctx.Keyspace(), ctx.MaterializedView()),
+		Selectors:   b.selectors(ctx.SelectElements()),
+		From:        b.fromSpec(ctx.FromSpec()),
+		PrimaryKey:  b.primaryKey(ctx.PrimaryKeyElement()),
+	}
+	if where := ctx.MvWhereSpec(); where != nil {
+		for _, clause := range where.AllMvWhereClause() {
+			stmt.Where = append(stmt.Where, b.relation(clause))
+		}
+	}
+	if opts := ctx.MaterializedViewOptions(); opts != nil {
+		stmt.Options = b.tableOptions(opts.TableOptions())
+		if order := opts.ClusteringOrder(); order != nil {
+			if stmt.Options == nil {
+				stmt.Options = &TableOptions{}
+			}
+			stmt.Options.ClusteringOrder = append(stmt.Options.ClusteringOrder, b.clusteringOrder(order)...)
+		}
+		stmt.Options.Span = b.span(opts)
+	}
+	return stmt
+}
+
+func (b *builder) createType(ctx parser.ICreateTypeContext) *CreateTypeStmt {
+	stmt := &CreateTypeStmt{
+		Span:        b.span(ctx),
+		IfNotExists: ctx.IfNotExist() != nil,
+		Name:        b.qualifiedName(ctx.Keyspace(), ctx.Type_()),
+	}
+	if members := ctx.TypeMemberColumnList(); members != nil {
+		stmt.Fields = b.fieldDefs(members.AllColumn(), members.AllDataType())
+	}
+	return stmt
+}
+
+func (b *builder) alterType(ctx parser.IAlterTypeContext) *AlterTypeStmt {
+	stmt := &AlterTypeStmt{Span: b.span(ctx), Name: b.qualifiedName(ctx.Keyspace(), ctx.Type_())}
+	op := ctx.AlterTypeOperation()
+	if op == nil {
+		return stmt
+	}
+	switch {
+	case op.AlterTypeAdd() != nil:
+		stmt.Action = AlterTypeAdd
+		stmt.Fields = b.fieldDefs(op.AlterTypeAdd().AllColumn(), op.AlterTypeAdd().AllDataType())
+	case op.AlterTypeAlterType() != nil:
+		stmt.Action = AlterTypeAlter
+		alter := op.AlterTypeAlterType()
+		stmt.Fields = b.fieldDefs([]parser.IColumnContext{alter.Column()}, []parser.IDataTypeContext{alter.DataType()})
+	case op.AlterTypeRename() != nil:
+		stmt.Action = AlterTypeRename
+		if list := op.AlterTypeRename().AlterTypeRenameList(); list != nil {
+			for _, item := range list.AllAlterTypeRenameItem() {
+				if cols := item.AllColumn(); len(cols) == 2 {
+					stmt.Renames = append(stmt.Renames, Rename{Span: b.span(item), From: identifier(cols[0]), To: identifier(cols[1])})
+				}
+			}
+		}
+	}
+	return stmt
+}
+
+// fieldDefs pairs the names and types of a name type, name type list.
+func (b *builder) fieldDefs(names []parser.IColumnContext, dataTypes []parser.IDataTypeContext) []FieldDef {
+	var fields []FieldDef
+	for i, name := range names {
+		if name == nil || i >= len(dataTypes) || dataTypes[i] == nil {
+			continue
+		}
+		fields = append(fields, FieldDef{Span: b.spanning(name, dataTypes[i]), Name: identifier(name), Type: dataType(dataTypes[i])})
+	}
+	return fields
+}
+
+func (b *builder) createFunction(ctx parser.ICreateFunctionContext) *CreateFunctionStmt {
+	stmt := &CreateFunctionStmt{
+		Span:        b.span(ctx),
+		OrReplace:   ctx.OrReplace() != nil,
+		IfNotExists: ctx.IfNotExist() != nil,
+		Name:        b.qualifiedName(ctx.Keyspace(), ctx.Function_()),
+		ReturnType:  dataType(ctx.DataType()),
+	}
+	if params := ctx.ParamList(); params != nil {
+		for _, p := range params.AllParam() {
+			stmt.Params = append(stmt.Params, FieldDef{Span: b.span(p), Name: identifier(p.ParamName()), Type: dataType(p.DataType())})
+		}
+	}
+	if mode := ctx.ReturnMode(); mode != nil {
+		stmt.CalledOnNull = mode.KwCalled() != nil
+	}
+	if lang := ctx.Language(); lang != nil {
+		stmt.Language = strings.ToLower(lang.GetText())
+	}
+	if body := ctx.CodeBlock(); body != nil {
+		stmt.Body = b.literal(body).Value
+	}
+	return stmt
+}
+
+func (b *builder) createAggregate(ctx parser.ICreateAggregateContext) *CreateAggregateStmt {
+	stmt := &CreateAggregateStmt{
+		Span:        b.span(ctx),
+		OrReplace:   ctx.OrReplace() != nil,
+		IfNotExists: ctx.IfNotExist() != nil,
+		Name:        b.qualifiedName(ctx.Keyspace(), ctx.Aggregate()),
+		ArgType:     dataType(ctx.DataType(0)),
+		StateType:   dataType(ctx.DataType(1)),
+	}
+	funcs := ctx.AllFunction_()
+	if len(funcs) > 0 {
+		stmt.StateFunc = identifier(funcs[0])
+		next := 1
+		if ctx.KwReducefunc() != nil && next < len(funcs) {
+			stmt.ReduceFunc = identifier(funcs[next])
+			next++
+		}
+		if ctx.KwFinalfunc() != nil && next < len(funcs) {
+			stmt.FinalFunc = identifier(funcs[next])
+		}
+	}
+	if init := ctx.InitCondDefinition(); init != nil {
+		stmt.InitCond = b.text(init)
+	}
+	return stmt
+}
+
+// Helpers
+
+// identifier returns the normalized name of an identifier node.
+func identifier(tree antlr.Tree) string {
+	node, ok := tree.(antlr.ParseTree)
+	if !ok || isNil(node) {
+		return ""
+	}
+	name := node.GetText()
+	if len(name) >= 2 && strings.HasPrefix(name, `"`) && strings.HasSuffix(name, `"`) {
+		return strings.ReplaceAll(name[1:len(name)-1], `""`, `"`)
+	}
+	return strings.ToLower(name)
+}
+
+// dataType renders a data type in canonical form (e.g., "map<text, frozen<address>>").
+func dataType(ctx parser.IDataTypeContext) string {
+	if ctx == nil || ctx.DataTypeName() == nil {
+		return ""
+	}
+	name := identifier(ctx.DataTypeName())
+	def := ctx.DataTypeDefinition()
+	if def == nil {
+		return name
+	}
+	args := make([]string, 0, len(def.AllDataTypeArg()))
+	for _, arg := range def.AllDataTypeArg() {
+		if arg.DataType() != nil {
+			args = append(args, dataType(arg.DataType()))
+		} else {
+			args = append(args, arg.GetText())
+		}
+	}
+	return name + "<" + strings.Join(args, ", ") + ">"
+}
+
+// unquoteString strips the quotes of a string literal and unescapes doubled quotes.
+func unquoteString(s string) string {
+	if len(s) >= 2 && strings.HasPrefix(s, "'") && strings.HasSuffix(s, "'") {
+		return strings.ReplaceAll(s[1:len(s)-1], "''", "'")
+	}
+	return s
+}
+
+// firstToken returns the first token of a parse tree node.
+func firstToken(tree antlr.Tree) antlr.Token {
+	switch n := tree.(type) {
+	case antlr.TerminalNode:
+		return n.GetSymbol()
+	case antlr.ParserRuleContext:
+		return n.GetStart()
+	}
+	return nil
+}
+
+// isNil reports whether a parse tree interface holds a nil pointer.
+func isNil(tree antlr.Tree) bool {
+	switch n := tree.(type) {
+	case antlr.ParserRuleContext:
+		return n == nil || n.GetStart() == nil
+	case antlr.TerminalNode:
+		return n == nil || n.GetSymbol() == nil
+	}
+	return tree == nil
+}
@@ -0,0 +1,796 @@
+package ast
+
+import (
+	"strings"
+)
+
+// Print renders a node as CQL on a single line, with uppercase keywords and
+// without a trailing semicolon. Identifiers are quoted only when needed and
+// string literals are escaped, so a printed statement parses back to an
+// equivalent tree. Nodes built by hand may be modified freely before
+// printing, e.g. to add a LIMIT or change a keyspace.
+func Print(node Node) string {
+	var p printer
+	p.node(node)
+	return p.String()
+}
+
+type printer struct {
+	strings.Builder
+}
+
+func (p *printer) node(node Node) {
+	switch n := node.(type) {
+	case nil:
+	case Statement:
+		p.statement(n)
+	case Term:
+		p.term(n)
+	case Relation:
+		p.relation(n)
+	case *Relation:
+		p.relation(*n)
+	case Condition:
+		p.relation(n.Relation)
+	case Selector:
+		p.selector(n)
+	case Assignment:
+		p.assignment(n)
+	case QualifiedName:
+		p.name(n)
+	case *Using:
+		p.using(n)
+	case *TableOptions:
+		p.tableOptions(n)
+	case ColumnDef:
+		p.columnDef(n)
+	case *PrimaryKey:
+		p.primaryKey(n)
+	}
+}
+
+// Statements
+
+func (p *printer) statement(stmt Statement) {
+	switch s := stmt.(type) {
+	case *SelectStmt:
+		p.selectStmt(s)
+	case *InsertStmt:
+		p.insertStmt(s)
+	case *UpdateStmt:
+		p.updateStmt(s)
+	case *DeleteStmt:
+		p.deleteStmt(s)
+	case *BatchStmt:
+		p.WriteString("BEGIN ")
+		p.batchType(s.Type)
+		p.WriteString("BATCH")
+		p.using(s.Using)
+		for _, child := range s.Statements {
+			p.WriteString(" ")
+			p.statement(child)
+		}
+		p.WriteString(" APPLY BATCH")
+	case *ApplyBatchStmt:
+		p.WriteString("APPLY BATCH")
+	case *UseStmt:
+		p.WriteString("USE " + QuoteIdentifier(s.Keyspace))
+	case *TruncateStmt:
+		p.WriteString("TRUNCATE ")
+		p.name(s.Table)
+	case *CreateKeyspaceStmt:
+		p.WriteString("CREATE KEYSPACE ")
+		p.ifNotExists(s.IfNotExists)
+		p.WriteString(QuoteIdentifier(s.Name))
+		p.keyspaceOptions(s.Replication, s.DurableWrites, s.Tablets)
+	case *AlterKeyspaceStmt:
+		p.WriteString("ALTER KEYSPACE " + QuoteIdentifier(s.Name))
+		p.keyspaceOptions(s.Replication, s.DurableWrites, nil)
+	case *DropKeyspaceStmt:
+		p.WriteString("DROP KEYSPACE ")
+		p.ifExists(s.IfExists)
+		p.WriteString(QuoteIdentifier(s.Name))
+	case *CreateTableStmt:
+		p.createTable(s)
+	case *AlterTableStmt:
+		p.alterTable(s)
+	case *DropTableStmt:
+		p.WriteString("DROP TABLE ")
+		p.ifExists(s.IfExists)
+		p.name(s.Table)
+	case *CreateIndexStmt:
+		p.createIndex(s)
+	case *DropIndexStmt:
+		p.WriteString("DROP INDEX ")
+		p.ifExists(s.IfExists)
+		p.name(s.Name)
+	case *CreateMaterializedViewStmt:
+		p.createMaterializedView(s)
+	case *AlterMaterializedViewStmt:
+		p.WriteString("ALTER MATERIALIZED VIEW ")
+		p.name(s.Name)
+		p.with(s.Options)
+	case *DropMaterializedViewStmt:
+		p.WriteString("DROP MATERIALIZED VIEW ")
+		p.ifExists(s.IfExists)
+		p.name(s.Name)
+	case *CreateTypeStmt:
+		p.WriteString("CREATE TYPE ")
+		p.ifNotExists(s.IfNotExists)
+		p.name(s.Name)
+		p.WriteString(" (")
+		p.fieldDefs(s.Fields)
+		p.WriteString(")")
+	case *AlterTypeStmt:
+		p.alterType(s)
+	case *DropTypeStmt:
+		p.WriteString("DROP TYPE ")
+		p.ifExists(s.IfExists)
+		p.name(s.Name)
+	case *CreateFunctionStmt:
+		p.createFunction(s)
+	case *DropFunctionStmt:
+		p.WriteString("DROP FUNCTION ")
+		p.ifExists(s.IfExists)
+		p.functionName(s.Name)
+	case *CreateAggregateStmt:
+		p.createAggregate(s)
+	case *DropAggregateStmt:
+		p.WriteString("DROP AGGREGATE ")
+		p.ifExists(s.IfExists)
+		p.functionName(s.Name)
+		if s.ArgType != "" {
+			p.WriteString(" (" + printType(s.ArgType) + ")")
+		}
+	case *OtherStmt:
+		p.WriteString(s.Text)
+	}
+}
+
+func (p *printer) selectStmt(s *SelectStmt) {
+	p.WriteString("SELECT ")
+	if s.Distinct {
+		p.WriteString("DISTINCT ")
+	}
+	if s.JSON {
+		p.WriteString("JSON ")
+	}
+	p.selectors(s.Selectors)
+	p.WriteString(" FROM ")
+	p.name(s.From)
+	p.where(s.Where)
+	if len(s.GroupBy) > 0 {
+		p.WriteString(" GROUP BY ")
+		for i, col := range s.GroupBy {
+			p.comma(i)
+			p.term(col)
+		}
+	}
+	if len(s.OrderBy) > 0 {
+		p.WriteString(" ORDER BY ")
+		p.orderings(s.OrderBy)
+	}
+	if s.PerPartitionLimit != nil {
+		p.WriteString(" PER PARTITION LIMIT ")
+		p.term(s.PerPartitionLimit)
+	}
+	if s.Limit != nil {
+		p.WriteString(" LIMIT ")
+		p.term(s.Limit)
+	}
+	if s.AllowFiltering {
+		p.WriteString(" ALLOW FILTERING")
+	}
+	if s.BypassCache {
+		p.WriteString(" BYPASS CACHE")
+	}
+	if s.Timeout != nil {
+		p.WriteString(" USING TIMEOUT ")
+		p.term(s.Timeout)
+	}
+}
+
+func (p *printer) insertStmt(s *InsertStmt) {
+	p.beginBatch(s.Batch)
+	p.WriteString("INSERT INTO ")
+	p.name(s.Table)
+	if s.JSON != nil {
+		p.WriteString(" JSON ")
+		p.term(s.JSON)
+		if s.JSONDefault != "" {
+			p.WriteString(" DEFAULT " + s.JSONDefault)
+		}
+	} else {
+		if len(s.Columns) > 0 {
+			p.WriteString(" (")
+			for i, col := range s.Columns {
+				p.comma(i)
+				p.term(col)
+			}
+			p.WriteString(")")
+		}
+		p.WriteString(" VALUES (")
+		p.terms(s.Values)
+		p.WriteString(")")
+	}
+	if s.IfNotExists {
+		p.WriteString(" IF NOT EXISTS")
+	}
+	p.using(s.Using)
+}
+
+func (p *printer) updateStmt(s *UpdateStmt) {
+	p.beginBatch(s.Batch)
+	p.WriteString("UPDATE ")
+	p.name(s.Table)
+	p.using(s.Using)
+	p.WriteString(" SET ")
+	for i, a := range s.Assignments {
+		p.comma(i)
+		p.assignment(a)
+	}
+	p.where(s.Where)
+	p.conditions(s.IfExists, s.Conditions)
+}
+
+func (p *printer) deleteStmt(s *DeleteStmt) {
+	p.beginBatch(s.Batch)
+	p.WriteString("DELETE ")
+	if len(s.Columns) > 0 {
+		p.terms(s.Columns)
+		p.WriteString(" ")
+	}
+	p.WriteString("FROM ")
+	p.name(s.Table)
+	p.using(s.Using)
+	p.where(s.Where)
+	p.conditions(s.IfExists, s.Conditions)
+}
+
+func (p *printer) beginBatch(b *BeginBatch) {
+	if b == nil {
+		return
+	}
+	p.WriteString("BEGIN ")
+	p.batchType(b.Type)
+	p.WriteString("BATCH")
+	p.using(b.Using)
+	p.WriteString(" ")
+}
+
+func (p *printer) batchType(t BatchType) {
+	if t != BatchDefault {
+		p.WriteString(string(t) + " ")
+	}
+}
+
+func (p *printer) using(u *Using) {
+	if u == nil || (u.TTL == nil && u.Timestamp == nil && u.Timeout == nil) {
+		return
+	}
+	p.WriteString(" USING ")
+	sep := ""
+	if u.TTL != nil {
+		p.WriteString("TTL ")
+		p.term(u.TTL)
+		sep = " AND "
+	}
+	if u.Timestamp != nil {
+		p.WriteString(sep + "TIMESTAMP ")
+		p.term(u.Timestamp)
+		sep = " AND "
+	}
+	if u.Timeout != nil {
+		p.WriteString(sep + "TIMEOUT ")
+		p.term(u.Timeout)
+	}
+}
+
+func (p *printer) where(relations []Relation) {
+	if len(relations) == 0 {
+		return
+	}
+	p.WriteString(" WHERE ")
+	for i, r := range relations {
+		if i > 0 {
+			p.WriteString(" AND ")
+		}
+		p.relation(r)
+	}
+}
+
+func (p *printer) conditions(ifExists bool, conditions []Condition) {
+	if ifExists {
+		p.WriteString(" IF EXISTS")
+		return
+	}
+	for i, c := range conditions {
+		switch {
+		case i == 0:
+			p.WriteString(" IF ")
+		case c.Or:
+			p.WriteString(" OR ")
+		default:
+			p.WriteString(" AND ")
+		}
+		p.relation(c.Relation)
+	}
+}
+
+func (p *printer) relation(r Relation) {
+	p.term(r.Left)
+	switch r.Op {
+	case "":
+	case OpIsNotNull:
+		p.WriteString(" IS NOT NULL")
+	case OpIn:
+		if r.ListMarker {
+			p.WriteString(" IN ")
+			p.terms(r.Values)
+		} else {
+			p.WriteString(" IN (")
+			p.terms(r.Values)
+			p.WriteString(")")
+		}
+	default:
+		p.WriteString(" " + string(r.Op) + " ")
+		p.terms(r.Values)
+	}
+}
+
+func (p *printer) assignment(a Assignment) {
+	p.term(a.Target)
+	p.WriteString(" = ")
+	p.term(a.Value)
+}
+
+func (p *printer) selectors(selectors []Selector) {
+	for i, sel := range selectors {
+		p.comma(i)
+		p.selector(sel)
+	}
+}
+
+func (p *printer) selector(sel Selector) {
+	p.term(sel.Expr)
+	if sel.Alias != "" {
+		p.WriteString(" AS " + QuoteIdentifier(sel.Alias))
+	}
+}
+
+func (p *printer) orderings(orderings []Ordering) {
+	for i, o := range orderings {
+		p.comma(i)
+		p.WriteString(QuoteIdentifier(o.Column))
+		if o.Ann != nil {
+			p.WriteString(" ANN OF ")
+			p.term(o.Ann)
+		} else if o.Direction != OrderNone {
+			p.WriteString(" " + string(o.Direction))
+		}
+	}
+}
+
+// DDL
+
+func (p *printer) keyspaceOptions(replication *MapLiteral, durableWrites *Literal, tablets *MapLiteral) {
+	p.WriteString(" WITH replication = ")
+	if replication != nil {
+		p.term(replication)
+	} else {
+		p.WriteString("{}")
+	}
+	if durableWrites != nil {
+		p.WriteString(" AND durable_writes = ")
+		p.term(durableWrites)
+	}
+	if tablets != nil {
+		p.WriteString(" AND tablets = ")
+		p.term(tablets)
+	}
+}
+
+func (p *printer) createTable(s *CreateTableStmt) {
+	p.WriteString("CREATE TABLE ")
+	p.ifNotExists(s.IfNotExists)
+	p.name(s.Table)
+	p.WriteString(" (")
+	for i, col := range s.Columns {
+		p.comma(i)
+		p.columnDef(col)
+	}
+	if s.PrimaryKey != nil {
+		p.WriteString(", ")
+		p.primaryKey(s.PrimaryKey)
+	}
+	p.WriteString(")")
+	p.with(s.Options)
+}
+
+func (p *printer) columnDef(col ColumnDef) {
+	p.WriteString(QuoteIdentifier(col.Name) + " " + printType(col.Type))
+	if col.Static {
+		p.WriteString(" STATIC")
+	}
+	if col.PrimaryKey {
+		p.WriteString(" PRIMARY KEY")
+	}
+}
+
+func (p *printer) primaryKey(pk *PrimaryKey) {
+	p.WriteString("PRIMARY KEY (")
+	if len(pk.PartitionKey) > 1 {
+		p.WriteString("(" + quoteAll(pk.PartitionKey) + ")")
+	} else {
+		p.WriteString(quoteAll(pk.PartitionKey))
+	}
+	if len(pk.ClusteringKey) > 0 {
+		p.WriteString(", " + quoteAll(pk.ClusteringKey))
+	}
+	p.WriteString(")")
+}
+
+// with prints a WITH clause if the options are not empty.
+func (p *printer) with(opts *TableOptions) {
+	if opts == nil || (!opts.CompactStorage && len(opts.ClusteringOrder) == 0 && len(opts.Options) == 0) {
+		return
+	}
+	p.WriteString(" WITH ")
+	p.tableOptions(opts)
+}
+
+func (p *printer) tableOptions(opts *TableOptions) {
+	if opts == nil {
+		return
+	}
+	sep := ""
+	if opts.CompactStorage {
+		p.WriteString("COMPACT STORAGE")
+		sep = " AND "
+	}
+	if len(opts.ClusteringOrder) > 0 {
+		p.WriteString(sep + "CLUSTERING ORDER BY (")
+		p.orderings(opts.ClusteringOrder)
+		p.WriteString(")")
+		sep = " AND "
+	}
+	for _, opt := range opts.Options {
+		p.WriteString(sep + opt.Name + " = ")
+		p.term(opt.Value)
+		sep = " AND "
+	}
+}
+
+func (p *printer) alterTable(s *AlterTableStmt) {
+	p.WriteString("ALTER TABLE ")
+	p.name(s.Table)
+	switch s.Action {
+	case AlterTableAdd:
+		p.WriteString(" ADD ")
+		if len(s.Add) == 1 && !s.Add[0].PrimaryKey {
+			p.columnDef(s.Add[0])
+			return
+		}
+		p.WriteString("(")
+		for i, col := range s.Add {
+			p.comma(i)
+			p.columnDef(col)
+		}
+		p.WriteString(")")
+	case AlterTableAlter:
+		if s.Alter != nil {
+			p.WriteString(" ALTER " + QuoteIdentifier(s.Alter.Name) + " TYPE " + printType(s.Alter.Type))
+		}
+	case AlterTableDrop:
+		p.WriteString(" DROP " + quoteAll(s.Drop))
+	case AlterTableDropCompactStorage:
+		p.WriteString(" DROP COMPACT STORAGE")
+	case AlterTableRename:
+		if s.Rename != nil {
+			p.WriteString(" RENAME " + QuoteIdentifier(s.Rename.From) + " TO " + QuoteIdentifier(s.Rename.To))
+		}
+	case AlterTableWith:
+		p.with(s.Options)
+	}
+}
+
+func (p *printer) createIndex(s *CreateIndexStmt) {
+	p.WriteString("CREATE ")
+	if s.Custom {
+		p.WriteString("CUSTOM ")
+	}
+	p.WriteString("INDEX ")
+	p.ifNotExists(s.IfNotExists)
+	if s.Name != "" {
+		p.WriteString(QuoteIdentifier(s.Name) + " ")
+	}
+	p.WriteString("ON ")
+	p.name(s.Table)
+	p.WriteString(" (")
+	if s.Target.Kind != "" {
+		p.WriteString(s.Target.Kind + "(" + QuoteIdentifier(s.Target.Column) + ")")
+	} else {
+		p.WriteString(QuoteIdentifier(s.Target.Column))
+	}
+	p.WriteString(")")
+	if s.Using != "" {
+		p.WriteString(" USING " + QuoteString(s.Using))
+		if s.Options != nil {
+			p.WriteString(" WITH OPTIONS = ")
+			p.term(s.Options)
+		}
+	}
+}
+
+func (p *printer) createMaterializedView(s *CreateMaterializedViewStmt) {
+	p.WriteString("CREATE MATERIALIZED VIEW ")
+	p.ifNotExists(s.IfNotExists)
+	p.name(s.Name)
+	p.WriteString(" AS SELECT ")
+	p.selectors(s.Selectors)
+	p.WriteString(" FROM ")
+	p.name(s.From)
+	p.where(s.Where)
+	if s.PrimaryKey != nil {
+		p.WriteString(" ")
+		p.primaryKey(s.PrimaryKey)
+	}
+	p.with(s.Options)
+}
+
+func (p *printer) alterType(s *AlterTypeStmt) {
+	p.WriteString("ALTER TYPE ")
+	p.name(s.Name)
+	switch s.Action {
+	case AlterTypeAdd:
+		p.WriteString(" ADD ")
+		p.fieldDefs(s.Fields)
+	case AlterTypeAlter:
+		for _, f := range s.Fields {
+			p.WriteString(" ALTER " + QuoteIdentifier(f.Name) + " TYPE " + printType(f.Type))
+		}
+	case AlterTypeRename:
+		p.WriteString(" RENAME ")
+		for i, r := range s.Renames {
+			if i > 0 {
+				p.WriteString(" AND ")
+			}
+			p.WriteString(QuoteIdentifier(r.From) + " TO " + QuoteIdentifier(r.To))
+		}
+	}
+}
+
+func (p *printer) fieldDefs(fields []FieldDef) {
+	for i, f := range fields {
+		p.comma(i)
+		p.WriteString(QuoteIdentifier(f.Name) + " " + printType(f.Type))
+	}
+}
+
+func (p *printer) createFunction(s *CreateFunctionStmt) {
+	p.WriteString("CREATE ")
+	if s.OrReplace {
+		p.WriteString("OR REPLACE ")
+	}
+	p.WriteString("FUNCTION ")
+	p.ifNotExists(s.IfNotExists)
+	p.functionName(s.Name)
+	p.WriteString(" (")
+	p.fieldDefs(s.Params)
+	p.WriteString(")")
+	if s.CalledOnNull {
+		p.WriteString(" CALLED ON NULL INPUT")
+	} else {
+		p.WriteString(" RETURNS NULL ON NULL INPUT")
+	}
+	p.WriteString(" RETURNS " + printType(s.ReturnType))
+	p.WriteString(" LANGUAGE " + quoteName(s.Language) + " AS ")
+	p.term(&Literal{Kind: LiteralCodeBlock, Value: s.Body})
+}
+
+func (p *printer) createAggregate(s *CreateAggregateStmt) {
+	p.WriteString("CREATE ")
+	if s.OrReplace {
+		p.WriteString("OR REPLACE ")
+	}
+	p.WriteString("AGGREGATE ")
+	p.ifNotExists(s.IfNotExists)
+	p.functionName(s.Name)
+	p.WriteString(" (" + printType(s.ArgType) + ")")
+	p.WriteString(" SFUNC " + quoteName(s.StateFunc))
+	p.WriteString(" STYPE " + printType(s.StateType))
+	if s.ReduceFunc != "" {
+		p.WriteString(" REDUCEFUNC " + quoteName(s.ReduceFunc))
+	}
+	if s.FinalFunc != "" {
+		p.WriteString(" FINALFUNC " + quoteName(s.FinalFunc))
+	}
+	if s.InitCond != "" {
+		p.WriteString(" INITCOND " + s.InitCond)
+	}
+}
+
+func (p *printer) ifNotExists(set bool) {
+	if set {
+		p.WriteString("IF NOT EXISTS ")
+	}
+}
+
+func (p *printer) ifExists(set bool) {
+	if set {
+		p.WriteString("IF EXISTS ")
+	}
+}
+
+// Terms
+
+func (p *printer) term(term Term) {
+	switch t := term.(type) {
+	case *Literal:
+		p.literal(t)
+	case *BindMarker:
+		if t.Name != "" {
+			p.WriteString(":" + QuoteIdentifier(t.Name))
+		} else {
+			p.WriteString("?")
+		}
+	case *ColumnRef:
+		p.WriteString(QuoteIdentifier(t.Name))
+		if t.Field != "" {
+			p.WriteString("." + QuoteIdentifier(t.Field))
+		}
+	case *Star:
+		if t.Qualifier != "" {
+			p.WriteString(QuoteIdentifier(t.Qualifier) + ".")
+		}
+		p.WriteString("*")
+	case *FunctionCall:
+		p.functionCall(t)
+	case *Cast:
+		p.WriteString("CAST(")
+		p.term(t.Expr)
+		p.WriteString(" AS " + printType(t.Type) + ")")
+	case *ListLiteral:
+		p.WriteString("[")
+		p.terms(t.Elements)
+		p.WriteString("]")
+	case *SetLiteral:
+		p.WriteString("{")
+		p.terms(t.Elements)
+		p.WriteString("}")
+	case *MapLiteral:
+		p.WriteString("{")
+		for i, e := range t.Entries {
+			p.comma(i)
+			p.term(e.Key)
+			p.WriteString(": ")
+			p.term(e.Value)
+		}
+		p.WriteString("}")
+	case *UDTLiteral:
+		p.WriteString("{")
+		for i, f := range t.Fields {
+			p.comma(i)
+			p.WriteString(QuoteIdentifier(f.Name) + ": ")
+			p.term(f.Value)
+		}
+		p.WriteString("}")
+	case *TupleLiteral:
+		p.WriteString("(")
+		p.terms(t.Elements)
+		p.WriteString(")")
+	case *BinaryExpr:
+		p.term(t.Left)
+		p.WriteString(" " + t.Op + " ")
+		p.term(t.Right)
+	case *IndexExpr:
+		p.term(t.Target)
+		p.WriteString("[")
+		p.term(t.Key)
+		p.WriteString("]")
+	}
+}
+
+func (p *printer) terms(terms []Term) {
+	for i, t := range terms {
+		p.comma(i)
+		p.term(t)
+	}
+}
+
+func (p *printer) literal(lit *Literal) {
+	switch lit.Kind {
+	case LiteralString:
+		p.WriteString(QuoteString(lit.Value))
+	case LiteralCodeBlock:
+		if strings.Contains(lit.Value, "$$") {
+			p.WriteString(QuoteString(lit.Value))
+		} else {
+			p.WriteString("$$" + lit.Value + "$$")
+		}
+	case LiteralNull:
+		p.WriteString("NULL")
+	case LiteralEmpty:
+		p.WriteString("EMPTY")
+	default:
+		p.WriteString(lit.Value)
+	}
+}
+
+func (p *printer) functionCall(fn *FunctionCall) {
+	switch {
+	case fn.Keyspace != "":
+		p.WriteString(quoteName(fn.Keyspace) + "." + quoteName(fn.Name))
+	case fn.Name == "scylla_clustering_bound":
+		p.WriteString("SCYLLA_CLUSTERING_BOUND")
+	case fn.Name == "token" || fn.Name == "writetime" || fn.Name == "ttl":
+		p.WriteString(strings.ToUpper(fn.Name))
+	case fn.Name == "uuid" && len(fn.Args) == 0:
+		p.WriteString("uuid")
+	default:
+		p.WriteString(quoteName(fn.Name))
+	}
+	p.WriteString("(")
+	if fn.Star {
+		p.WriteString("*")
+	} else {
+		p.terms(fn.Args)
+	}
+	p.WriteString(")")
+}
+
+// name prints a qualified name.
+func (p *printer) name(n QualifiedName) {
+	if n.Keyspace != "" {
+		p.WriteString(QuoteIdentifier(n.Keyspace) + ".")
+	}
+	p.WriteString(QuoteIdentifier(n.Name))
+}
+
+// functionName prints a qualified function or aggregate name.
+func (p *printer) functionName(n QualifiedName) {
+	if n.Keyspace != "" {
+		p.WriteString(QuoteIdentifier(n.Keyspace) + ".")
+	}
+	p.WriteString(quoteName(n.Name))
+}
+
+func (p *printer) comma(i int) {
+	if i > 0 {
+		p.WriteString(", ")
+	}
+}
+
+func quoteAll(names []string) string {
+	quoted := make([]string, len(names))
+	for i, name := range names {
+		quoted[i] = QuoteIdentifier(name)
+	}
+	return strings.Join(quoted, ", ")
+}
+
+// printType renders a canonical type, quoting user-defined type names that need it.
+func printType(t string) string {
+	var sb strings.Builder
+	word := func(w string) {
+		if w == "" {
+			return
+		}
+		if lexerKeywords[strings.ToUpper(w)] || (w[0] >= '0' && w[0] <= '9') {
+			sb.WriteString(w)
+		} else {
+			sb.WriteString(quoteName(w))
+		}
+	}
+	start := 0
+	for i, r := range t {
+		switch r {
+		case '<', '>', ',', ' ', '.':
+			word(t[start:i])
+			sb.WriteRune(r)
+			start = i + 1
+		}
+	}
+	word(t[start:])
+	return sb.String()
+}
@@ -0,0 +1,61 @@
+package ast
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/tentacle-scylla/scql/gen/cqldata"
+	parser "github.com/tentacle-scylla/scql/gen/parser"
+)
+
+// unquotedIdentifier matches identifiers that can be written without quotes
+var unquotedIdentifier = regexp.MustCompile(`^[a-z][a-z0-9_]*$`)
+
+// keywords holds every word the lexer or ScyllaDB treats as a keyword;
+// lexerKeywords only the words the lexer turns into keyword tokens
+var keywords, lexerKeywords = buildKeywordSets()
+
+func buildKeywordSets() (all, lexer map[string]bool) {
+	all = make(map[string]bool)
+	lexer = make(map[string]bool)
+	for _, kw := range cqldata.GenAllKeywords {
+		all[kw] = true
+	}
+	parser.CqlLexerInit()
+	for _, name := range parser.CqlLexerLexerStaticData.SymbolicNames {
+		if strings.HasPrefix(name, "K_") {
+			all[strings.TrimPrefix(name, "K_")] = true
+			lexer[strings.TrimPrefix(name, "K_")] = true
+		}
+	}
+	return all, lexer
+}
+
+// IsKeyword reports whether word is a CQL keyword (case-insensitive)
+func IsKeyword(word string) bool {
+	return keywords[strings.ToUpper(word)]
+}
+
+// QuoteIdentifier returns name as a CQL identifier, quoting it only when needed.
+// Names with uppercase or special characters and keywords are double-quoted.
+func QuoteIdentifier(name string) string {
+	if unquotedIdentifier.MatchString(name) && !IsKeyword(name) {
+		return name
+	}
+	return `"` + strings.ReplaceAll(name, `"`, `""`) + `"`
+}
+
+// QuoteString returns s as a single-quoted CQL string literal
+func QuoteString(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", "''") + "'"
+}
+
+// quoteName quotes a function or type name. Unlike columns, these are only
+// quoted if the lexer would not read them as a name, so now() and
+// frozen<list<int>> print unquoted.
+func quoteName(name string) string {
+	if unquotedIdentifier.MatchString(name) && !lexerKeywords[strings.ToUpper(name)] {
+		return name
+	}
+	return QuoteIdentifier(name)
+}
@@ -0,0 +1,330 @@
+package ast
+
+// DML statements
+
+// SelectStmt is a SELECT statement.
+type SelectStmt struct {
+	Span
+	Distinct          bool
+	JSON              bool
+	Selectors         []Selector
+	From              QualifiedName
+	Where             []Relation
+	GroupBy           []*ColumnRef
+	OrderBy           []Ordering
+	PerPartitionLimit Term
+	Limit             Term
+	AllowFiltering    bool
+	BypassCache       bool
+	Timeout           Term
+}
+
+// BeginBatch is a BEGIN BATCH prefix on a single modification statement,
+// used by scripts that split a batch over several statements.
+type BeginBatch struct {
+	Span
+	Type  BatchType
+	Using *Using
+}
+
+// InsertStmt is an INSERT statement.
+type InsertStmt struct {
+	Span
+	Batch       *BeginBatch
+	Table       QualifiedName
+	Columns     []*ColumnRef
+	Values      []Term
+	JSON        Term   // INSERT ... JSON value
+	JSONDefault string // "NULL" or "UNSET" for DEFAULT NULL/UNSET
+	IfNotExists bool
+	Using       *Using
+}
+
+// UpdateStmt is an UPDATE statement.
+type UpdateStmt struct {
+	Span
+	Batch       *BeginBatch
+	Table       QualifiedName
+	Using       *Using
+	Assignments []Assignment
+	Where       []Relation
+	IfExists    bool
+	Conditions  []Condition
+}
+
+// DeleteStmt is a DELETE statement.
+type DeleteStmt struct {
+	Span
+	Batch      *BeginBatch
+	Columns    []Term // ColumnRef or IndexExpr (col[key])
+	Table      QualifiedName
+	Using      *Using
+	Where      []Relation
+	IfExists   bool
+	Conditions []Condition
+}
+
+// BatchType is the type of a batch.
+type BatchType string
+
+const (
+	BatchDefault  BatchType = ""
+	BatchLogged   BatchType = "LOGGED"
+	BatchUnlogged BatchType = "UNLOGGED"
+	BatchCounter  BatchType = "COUNTER"
+)
+
+// BatchStmt is a BEGIN BATCH ... APPLY BATCH statement.
+type BatchStmt struct {
+	Span
+	Type       BatchType
+	Using      *Using
+	Statements []Statement // InsertStmt, UpdateStmt and DeleteStmt
+}
+
+// ApplyBatchStmt is a standalone APPLY BATCH closing a split batch.
+type ApplyBatchStmt struct {
+	Span
+}
+
+// UseStmt is a USE statement.
+type UseStmt struct {
+	Span
+	Keyspace string
+}
+
+// TruncateStmt is a TRUNCATE statement.
+type TruncateStmt struct {
+	Span
+	Table QualifiedName
+}
+
+// DDL statements
+
+// CreateKeyspaceStmt is a CREATE KEYSPACE statement.
+type CreateKeyspaceStmt struct {
+	Span
+	IfNotExists   bool
+	Name          string
+	Replication   *MapLiteral
+	DurableWrites *Literal
+	Tablets       *MapLiteral // ScyllaDB tablets options
+}
+
+// AlterKeyspaceStmt is an ALTER KEYSPACE statement.
+type AlterKeyspaceStmt struct {
+	Span
+	Name          string
+	Replication   *MapLiteral
+	DurableWrites *Literal
+}
+
+// DropKeyspaceStmt is a DROP KEYSPACE statement.
+type DropKeyspaceStmt struct {
+	Span
+	IfExists bool
+	Name     string
+}
+
+// CreateTableStmt is a CREATE TABLE statement.
+type CreateTableStmt struct {
+	Span
+	IfNotExists bool
+	Table       QualifiedName
+	Columns     []ColumnDef
+	PrimaryKey  *PrimaryKey // nil if declared inline on a column
+	Options     *TableOptions
+}
+
+// AlterTableAction identifies the operation of an ALTER TABLE statement.
+type AlterTableAction string
+
+const (
+	AlterTableAdd                AlterTableAction = "ADD"
+	AlterTableAlter              AlterTableAction = "ALTER"
+	AlterTableDrop               AlterTableAction = "DROP"
+	AlterTableDropCompactStorage AlterTableAction = "DROP COMPACT STORAGE"
+	AlterTableRename             AlterTableAction = "RENAME"
+	AlterTableWith               AlterTableAction = "WITH"
+)
+
+// AlterTableStmt is an ALTER TABLE statement.
+type AlterTableStmt struct {
+	Span
+	Table   QualifiedName
+	Action  AlterTableAction
+	Add     []ColumnDef   // ADD
+	Alter   *FieldDef     // ALTER column TYPE type
+	Drop    []string      // DROP
+	Rename  *Rename       // RENAME
+	Options *TableOptions // WITH
+}
+
+// DropTableStmt is a DROP TABLE statement.
+type DropTableStmt struct {
+	Span
+	IfExists bool
+	Table    QualifiedName
+}
+
+// IndexTarget is the indexed column of CREATE INDEX.
+type IndexTarget struct {
+	Span
+	Column string
+	Kind   string // "", "KEYS", "ENTRIES" or "FULL"
+}
+
+// CreateIndexStmt is a CREATE INDEX statement.
+type CreateIndexStmt struct {
+	Span
+	Custom      bool
+	IfNotExists bool
+	Name        string // Empty if not named
+	Table       QualifiedName
+	Target      IndexTarget
+	Using       string      // Custom index class
+	Options     *MapLiteral // WITH OPTIONS = {...}
+}
+
+// DropIndexStmt is a DROP INDEX statement.
+type DropIndexStmt struct {
+	Span
+	IfExists bool
+	Name     QualifiedName
+}
+
+// CreateMaterializedViewStmt is a CREATE MATERIALIZED VIEW statement.
+type CreateMaterializedViewStmt struct {
+	Span
+	IfNotExists bool
+	Name        QualifiedName
+	Selectors   []Selector
+	From        QualifiedName
+	Where       []Relation
+	PrimaryKey  *PrimaryKey
+	Options     *TableOptions
+}
+
+// AlterMaterializedViewStmt is an ALTER MATERIALIZED VIEW statement.
+type AlterMaterializedViewStmt struct {
+	Span
+	Name    QualifiedName
+	Options *TableOptions
+}
+
+// DropMaterializedViewStmt is a DROP MATERIALIZED VIEW statement.
+type DropMaterializedViewStmt struct {
+	Span
+	IfExists bool
+	Name     QualifiedName
+}
+
+// CreateTypeStmt is a CREATE TYPE statement.
+type CreateTypeStmt struct {
+	Span
+	IfNotExists bool
+	Name        QualifiedName
+	Fields      []FieldDef
+}
+
+// AlterTypeAction identifies the operation of an ALTER TYPE statement.
+type AlterTypeAction string
+
+const (
+	AlterTypeAdd    AlterTypeAction = "ADD"
+	AlterTypeAlter  AlterTypeAction = "ALTER"
+	AlterTypeRename AlterTypeAction = "RENAME"
+)
+
+// AlterTypeStmt is an ALTER TYPE statement.
+type AlterTypeStmt struct {
+	Span
+	Name    QualifiedName
+	Action  AlterTypeAction
+	Fields  []FieldDef // ADD, or the single field of ALTER
+	Renames []Rename   // RENAME
+}
+
+// DropTypeStmt is a DROP TYPE statement.
+type DropTypeStmt struct {
+	Span
+	IfExists bool
+	Name     QualifiedName
+}
+
+// CreateFunctionStmt is a CREATE FUNCTION statement.
+type CreateFunctionStmt struct {
+	Span
+	OrReplace    bool
+	IfNotExists  bool
+	Name         QualifiedName
+	Params       []FieldDef
+	CalledOnNull bool // CALLED ON NULL INPUT; false for RETURNS NULL ON NULL INPUT
+	ReturnType   string
+	Language     string
+	Body         string
+}
+
+// DropFunctionStmt is a DROP FUNCTION statement.
+type DropFunctionStmt struct {
+	Span
+	IfExists bool
+	Name     QualifiedName
+}
+
+// CreateAggregateStmt is a CREATE AGGREGATE statement.
+type CreateAggregateStmt struct {
+	Span
+	OrReplace   bool
+	IfNotExists bool
+	Name        QualifiedName
+	ArgType     string
+	StateFunc   string
+	StateType   string
+	ReduceFunc  string
+	FinalFunc   string
+	InitCond    string // Source text of the INITCOND value
+}
+
+// DropAggregateStmt is a DROP AGGREGATE statement.
+type DropAggregateStmt struct {
+	Span
+	IfExists bool
+	Name     QualifiedName
+	ArgType  string // Optional argument type
+}
+
+// OtherStmt is a statement without a typed representation (roles, permissions,
+// service levels, DESCRIBE, ...). It keeps the statement text.
+type OtherStmt struct {
+	Span
+	Text string
+}
+
+func (*SelectStmt) statementNode()                 {}
+func (*InsertStmt) statementNode()                 {}
+func (*UpdateStmt) statementNode()                 {}
+func (*DeleteStmt) statementNode()                 {}
+func (*BatchStmt) statementNode()                  {}
+func (*ApplyBatchStmt) statementNode()             {}
+func (*UseStmt) statementNode()                    {}
+func (*TruncateStmt) statementNode()               {}
+func (*CreateKeyspaceStmt) statementNode()         {}
+func (*AlterKeyspaceStmt) statementNode()          {}
+func (*DropKeyspaceStmt) statementNode()           {}
+func (*CreateTableStmt) statementNode()            {}
+func (*AlterTableStmt) statementNode()             {}
+func (*DropTableStmt) statementNode()              {}
+func (*CreateIndexStmt) statementNode()            {}
+func (*DropIndexStmt) statementNode()              {}
+func (*CreateMaterializedViewStmt) statementNode() {}
+func (*AlterMaterializedViewStmt) statementNode()  {}
+func (*DropMaterializedViewStmt) statementNode()   {}
+func (*CreateTypeStmt) statementNode()             {}
+func (*AlterTypeStmt) statementNode()              {}
+func (*DropTypeStmt) statementNode()               {}
+func (*CreateFunctionStmt) statementNode()         {}
+func (*DropFunctionStmt) statementNode()           {}
+func (*CreateAggregateStmt) statementNode()        {}
+func (*DropAggregateStmt) statementNode()          {}
+func (*OtherStmt) statementNode()                  {}
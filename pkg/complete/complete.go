@@ -118,11 +118,12 @@ func getCompletionsForContext(ctx *DetectedContext, s *schema.Schema, defaultKs
 		}
 
 	case ContextAfterDot:
+		// After "column." or "column.field." - suggest the fields of a UDT
 		// After "keyspace." - suggest tables
-		// After "table." - suggest columns (less common in CQL)
-		if s != nil && ctx.Keyspace != "" {
-			// Try as keyspace - get tables
-			items = append(items, getTableCompletions(s, ctx.Keyspace, "", registry)...)
+		if fields := getFieldCompletions(s, ctx.Keyspace, ctx.Table, defaultKs, ctx.Column); len(fields) > 0 {
+			items = append(items, fields...)
+		} else if s != nil && s.GetKeyspace(strings.ToLower(ctx.Column)) != nil {
+			items = append(items, getTableCompletions(s, strings.ToLower(ctx.Column), "", registry)...)
 		}
 
 	case ContextAfterSelectTable:
@@ -534,6 +535,60 @@ func getColumnCompletions(s *schema.Schema, keyspace, table, defaultKs string, r
 	return items
 }
 
+// getFieldCompletions returns completions for the fields of a UDT column,
+// or of a UDT field for a path like "home.location".
+func getFieldCompletions(s *schema.Schema, keyspace, table, defaultKs, column string) []CompletionItem {
+	if s == nil || table == "" || column == "" {
+		return nil
+	}
+
+	ksName := keyspace
+	if ksName == "" {
+		ksName = defaultKs
+	}
+	ks := s.GetKeyspace(ksName)
+	tbl := ks.GetTable(table)
+	if tbl == nil {
+		return nil
+	}
+
+	path := strings.Split(column, ".")
+	var udt *schema.UserType
+	for _, col := range tbl.AllColumns() {
+		if strings.EqualFold(col.Name, path[0]) {
+			udt = ks.UserTypeOf(col.Type)
+			break
+		}
+	}
+	for _, field := range path[1:] {
+		if udt == nil {
+			return nil
+		}
+		var next *schema.UserType
+		for _, name := range udt.FieldNames() {
+			if strings.EqualFold(name, field) {
+				next = ks.UserTypeOf(udt.Fields[name])
+				break
+			}
+		}
+		udt = next
+	}
+	if udt == nil {
+		return nil
+	}
+
+	var items []CompletionItem
+	for i, name := range udt.FieldNames() {
+		items = append(items, CompletionItem{
+			Label:        name,
+			Kind:         KindField,
+			Detail:       udt.Fields[name],
+			SortPriority: i,
+		})
+	}
+	return items
+}
+
 // filterWithANTLR filters keyword completions using ANTLR validation.
 // Only specific keywords are filtered - snippets, columns, tables, etc. are kept as-is.
 func filterWithANTLR(query string, position int, items []CompletionItem, prefix string) []CompletionItem {
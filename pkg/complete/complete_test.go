@@ -4,6 +4,7 @@ import (
 	"os"
 	"testing"
 
+	"github.com/tentacle-scylla/scql/internal/fixture"
 	"github.com/tentacle-scylla/scql/pkg/schema"
 	"gopkg.in/yaml.v3"
 )
@@ -35,6 +36,7 @@ type FixtureTable struct {
 // FixtureKeyspace represents a keyspace in the fixture schema
 type FixtureKeyspace struct {
 	Name   string         `yaml:"name"`
+	Types  []fixture.Type `yaml:"types"`
 	Tables []FixtureTable `yaml:"tables"`
 }
 
@@ -94,6 +96,7 @@ func buildSchema(fs *FixtureSchema) *schema.Schema {
 	s := schema.NewSchema()
 	for _, fks := range fs.Keyspaces {
 		ks := s.AddKeyspace(fks.Name)
+		fixture.AddTypes(ks, fks.Types)
 		for _, ftbl := range fks.Tables {
 			tbl := ks.AddTable(ftbl.Name)
 
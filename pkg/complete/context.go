@@ -39,9 +39,13 @@ func DetectContext(query string, position int) *DetectedContext {
 	fullNormalized := normalizeForAnalysis(query)
 	ctx.Keyspace, ctx.Table = extractTableContext(fullNormalized)
 
-	// Extract column context for ContextAfterOperator
-	if ctx.Type == ContextAfterOperator {
+	// Extract column context for ContextAfterOperator, and the keyspace or
+	// UDT column before the dot for ContextAfterDot
+	switch ctx.Type {
+	case ContextAfterOperator:
 		ctx.Column = extractColumnBeforeOperator(normalized)
+	case ContextAfterDot:
+		ctx.Column = extractIdentifierBeforeDot(normalized, prefix)
 	}
 
 	return ctx
@@ -492,6 +496,17 @@ func parseTableRef(ref string) (keyspace string, table string) {
 	return "", strings.ToLower(ref)
 }
 
+// extractIdentifierBeforeDot extracts the identifier before the dot the
+// prefix follows. For "WHERE ADDR.CI" with prefix "CI" returns "ADDR".
+func extractIdentifierBeforeDot(normalized, prefix string) string {
+	before := strings.TrimSuffix(normalized, strings.ToUpper(prefix))
+	before = strings.TrimSuffix(before, ".")
+	if idx := strings.LastIndexAny(before, " ,()"); idx != -1 {
+		before = before[idx+1:]
+	}
+	return strings.Trim(before, "\"")
+}
+
 // extractColumnBeforeOperator extracts the column name before an operator.
 // For "WHERE build_hour =" returns "build_hour"
 func extractColumnBeforeOperator(normalized string) string {
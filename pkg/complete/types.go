@@ -11,6 +11,7 @@ const (
 	KindTable    CompletionKind = "table"
 	KindView     CompletionKind = "view" // Materialized view
 	KindColumn   CompletionKind = "column"
+	KindField    CompletionKind = "field" // Field of a user-defined type
 	KindFunction CompletionKind = "function"
 	KindType     CompletionKind = "type"
 	KindKeyspace CompletionKind = "keyspace"
@@ -171,7 +172,8 @@ type DetectedContext struct {
 	// Table is the current table context (if any)
 	Table string
 
-	// Column is the current column context (e.g., in WHERE col = |)
+	// Column is the current column context (e.g., in WHERE col = |), or the
+	// identifier before the dot in ident.|
 	Column string
 
 	// TokenStart is the start position of the current token
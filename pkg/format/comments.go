@@ -0,0 +1,277 @@
+package format
+
+import (
+	"strings"
+
+	"github.com/antlr4-go/antlr/v4"
+
+	parser "github.com/tentacle-scylla/scql/gen/parser"
+)
+
+// comment is a comment token of the input, attached to the nearest token.
+type comment struct {
+	text string
+	line bool // -- or // comment, which runs to the end of the line
+}
+
+// tokenComments holds the comments attached to one token of the input.
+type tokenComments struct {
+	leading  []comment // On earlier lines, before the token
+	trailing []comment // After the token, on the same line
+}
+
+// attachComments attaches every comment of the token stream to the nearest
+// default-channel token. A comment on the same line as the previous token
+// trails it; otherwise it leads the next token. Comments after the last token
+// trail it. The returned map is keyed by position among default tokens; if
+// the statement has no tokens at all, the comments are returned as orphans.
+func attachComments(tokens *antlr.CommonTokenStream) (attached map[int]*tokenComments, orphans []comment) {
+	tokens.Fill()
+	attached = make(map[int]*tokenComments)
+	get := func(i int) *tokenComments {
+		if attached[i] == nil {
+			attached[i] = &tokenComments{}
+		}
+		return attached[i]
+	}
+
+	var pending []comment
+	count := 0
+	var prev antlr.Token
+	for _, tok := range tokens.GetAllTokens() {
+		if tok.GetTokenType() == antlr.TokenEOF {
+			break
+		}
+		if tok.GetChannel() != antlr.TokenDefaultChannel {
+			c, ok := commentOf(tok)
+			if !ok {
+				continue
+			}
+			if prev != nil && len(pending) == 0 && tok.GetLine() == lastLine(prev) {
+				get(count - 1).trailing = append(get(count-1).trailing, c)
+			} else {
+				pending = append(pending, c)
+			}
+			continue
+		}
+		if len(pending) > 0 {
+			get(count).leading = append(get(count).leading, pending...)
+			pending = nil
+		}
+		prev = tok
+		count++
+	}
+	if len(pending) > 0 {
+		if count == 0 {
+			return attached, pending
+		}
+		get(count - 1).trailing = append(get(count-1).trailing, pending...)
+	}
+	return attached, nil
+}
+
+func commentOf(tok antlr.Token) (comment, bool) {
+	switch tok.GetTokenType() {
+	case parser.CqlLexerLINE_COMMENT:
+		return comment{text: strings.TrimRight(tok.GetText(), "\r\n"), line: true}, true
+	case parser.CqlLexerCOMMENT_INPUT, parser.CqlLexerSPEC_MYSQL_COMMENT:
+		return comment{text: tok.GetText()}, true
+	}
+	return comment{}, false
+}
+
+// lastLine returns the line on which a token ends.
+func lastLine(tok antlr.Token) int {
+	return tok.GetLine() + strings.Count(tok.GetText(), "\n")
+}
+
+// render returns the comment text for the given style. Compact output stays on
+// one line, so line comments are rewritten as block comments when possible.
+func (c comment) render(style Style) (text string, line bool) {
+	if style != Compact || !c.line {
+		return c.text, c.line
+	}
+	body := c.text
+	for _, prefix := range []string{"--", "//", "#"} {
+		if strings.HasPrefix(body, prefix) {
+			body = body[len(prefix):]
+			break
+		}
+	}
+	body = strings.TrimSpace(body)
+	if strings.Contains(body, "*/") {
+		return c.text, true
+	}
+	if body == "" {
+		return "/* */", false
+	}
+	return "/* " + body + " */", false
+}
+
+// insertComments re-inserts the comments of the input into formatted output.
+// The output is lexed and its tokens matched one by one against the input; if
+// the formatter reordered or rewrote tokens, insertComments reports false.
+func (f *formatter) insertComments(output string) (string, bool) {
+	attached, orphans := attachComments(f.tokens)
+	if len(orphans) > 0 {
+		var parts []string
+		for _, c := range orphans {
+			text, _ := c.render(f.opts.Style)
+			parts = append(parts, text)
+		}
+		sep := "\n"
+		if f.opts.Style == Compact {
+			sep = " "
+		}
+		return strings.Join(parts, sep), true
+	}
+	if len(attached) == 0 {
+		return output, true
+	}
+
+	var inputTokens []antlr.Token
+	for _, tok := range f.tokens.GetAllTokens() {
+		if tok.GetChannel() == antlr.TokenDefaultChannel && tok.GetTokenType() != antlr.TokenEOF {
+			inputTokens = append(inputTokens, tok)
+		}
+	}
+	outputTokens, offsets := lexDefault(output)
+	// The formatter terminates statements that lack a semicolon
+	if len(outputTokens) == len(inputTokens)+1 && outputTokens[len(outputTokens)-1].GetText() == ";" {
+		outputTokens = outputTokens[:len(inputTokens)]
+	}
+	if len(outputTokens) != len(inputTokens) {
+		return "", false
+	}
+	for i, tok := range outputTokens {
+		if !strings.EqualFold(tok.GetText(), inputTokens[i].GetText()) {
+			return "", false
+		}
+	}
+
+	var sb strings.Builder
+	last := 0
+	for i, tok := range outputTokens {
+		c := attached[i]
+		if c == nil {
+			continue
+		}
+		start, end := offsets(tok.GetStart()), offsets(tok.GetStop()+1)
+		sb.WriteString(output[last:start])
+		f.writeLeading(&sb, output, start, c.leading)
+		sb.WriteString(output[start:end])
+		last = end + f.writeTrailing(&sb, output, end, c.trailing)
+	}
+	sb.WriteString(output[last:])
+	return sb.String(), true
+}
+
+// appendComments appends all comments after the output, for output that
+// could not be matched against the input.
+func (f *formatter) appendComments(output string) string {
+	attached, orphans := attachComments(f.tokens)
+	all := orphans
+	for i := 0; len(attached) > 0; i++ {
+		if c := attached[i]; c != nil {
+			all = append(all, c.leading...)
+			all = append(all, c.trailing...)
+			delete(attached, i)
+		}
+	}
+	for _, c := range all {
+		text, line := c.render(f.opts.Style)
+		if f.opts.Style == Compact && !line {
+			output += " " + text
+		} else {
+			output += "\n" + text
+		}
+	}
+	return output
+}
+
+// writeLeading writes comments that precede the token at offset start.
+func (f *formatter) writeLeading(sb *strings.Builder, output string, start int, comments []comment) {
+	indent, atLineStart := lineIndent(output, start)
+	for _, c := range comments {
+		text, line := c.render(f.opts.Style)
+		switch {
+		case f.opts.Style == Compact && !line:
+			sb.WriteString(text + " ")
+		case atLineStart:
+			// Keep the comment on its own line, indented like the token
+			sb.WriteString(text + "\n" + indent)
+		case line:
+			sb.WriteString("\n" + indent + text + "\n" + indent)
+			atLineStart = true
+		default:
+			sb.WriteString(text + " ")
+		}
+	}
+}
+
+// writeTrailing writes comments that follow the token ending at offset end.
+// It returns the number of output bytes consumed, so that a space separating
+// the token from the next one does not start the line after a line comment.
+func (f *formatter) writeTrailing(sb *strings.Builder, output string, end int, comments []comment) int {
+	skip := 0
+	atLineStart := false // After a line comment, on the next line
+	for i, c := range comments {
+		text, line := c.render(f.opts.Style)
+		if !atLineStart {
+			sb.WriteString(" ")
+		}
+		sb.WriteString(text)
+		atLineStart = false
+		if !line {
+			continue
+		}
+		// A line comment must end the line
+		if i < len(comments)-1 || (end < len(output) && output[end] != '\n') {
+			indent, _ := lineIndent(output, end)
+			sb.WriteString("\n" + indent)
+			atLineStart = true
+			if i == len(comments)-1 && end < len(output) && output[end] == ' ' {
+				skip = 1
+			}
+		}
+	}
+	return skip
+}
+
+// lineIndent returns the indentation of the line containing offset and
+// whether only indentation precedes offset on that line.
+func lineIndent(s string, offset int) (string, bool) {
+	lineStart := strings.LastIndexByte(s[:offset], '\n') + 1
+	line := s[lineStart:]
+	indent := line[:len(line)-len(strings.TrimLeft(line, " \t"))]
+	return indent, strings.TrimSpace(s[lineStart:offset]) == ""
+}
+
+// lexDefault lexes s and returns its default-channel tokens with a function
+// mapping character indexes to byte offsets.
+func lexDefault(s string) ([]antlr.Token, func(int) int) {
+	lexer := parser.NewCqlLexer(antlr.NewInputStream(s))
+	lexer.RemoveErrorListeners()
+	var tokens []antlr.Token
+	for {
+		tok := lexer.NextToken()
+		if tok.GetTokenType() == antlr.TokenEOF {
+			break
+		}
+		if tok.GetChannel() == antlr.TokenDefaultChannel {
+			tokens = append(tokens, tok)
+		}
+	}
+
+	offsets := make([]int, 0, len(s)+1)
+	for i := range s {
+		offsets = append(offsets, i)
+	}
+	offsets = append(offsets, len(s))
+	return tokens, func(index int) int {
+		if index >= len(offsets) {
+			return len(s)
+		}
+		return offsets[index]
+	}
+}
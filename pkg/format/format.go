@@ -90,6 +90,21 @@ type formatter struct {
 }
 
 func (f *formatter) format() string {
+	output := f.formatStatement()
+	if withComments, ok := f.insertComments(output); ok {
+		return withComments
+	}
+	// The AST-aware layout reordered tokens, so comments cannot be placed;
+	// the token-based layout keeps the input order
+	output = f.formatTokenBased()
+	if withComments, ok := f.insertComments(output); ok {
+		return withComments
+	}
+	return f.appendComments(output)
+}
+
+// formatStatement formats the statement without its comments
+func (f *formatter) formatStatement() string {
 	f.output.Reset()
 	f.indent = 0
 
@@ -454,3 +454,134 @@ func TestFormatDeleteWithColumns(t *testing.T) {
 		t.Error("Should contain DELETE with columns")
 	}
 }
+
+func TestQuoteIdentifier(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{"plain", "events", "events"},
+		{"underscore", "user_id", "user_id"},
+		{"mixed case", "UserId", `"UserId"`},
+		{"keyword", "key", `"key"`},
+		{"lexer keyword", "value", `"value"`},
+		{"leading digit", "1col", `"1col"`},
+		{"embedded quote", `a"b`, `"a""b"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := QuoteIdentifier(tt.input); got != tt.want {
+				t.Errorf("QuoteIdentifier(%q) = %s, want %s", tt.input, got, tt.want)
+			}
+		})
+	}
+
+	if got := QuoteString("it's"); got != "'it''s'" {
+		t.Errorf("QuoteString = %s, want 'it''s'", got)
+	}
+}
+
+func TestFormatKeepsComments(t *testing.T) {
+	input := `-- Users table
+CREATE TABLE users (
+    id uuid, -- primary id
+    /* display name */
+    name text,
+    PRIMARY KEY (id)
+) WITH comment = 'x'; // done`
+
+	output, err := PrettyString(input)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `-- Users table
+CREATE TABLE users (
+    id uuid, -- primary id
+    /* display name */
+    name text,
+    PRIMARY KEY (id)
+) WITH comment = 'x'; // done`
+	if output != want {
+		t.Errorf("unexpected output:\n%s\nwant:\n%s", output, want)
+	}
+
+	compact, err := CompactString(input)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(compact, "\n") || !strings.Contains(compact, "/* primary id */") {
+		t.Errorf("unexpected compact output: %s", compact)
+	}
+}
+
+func TestFormatTrailingLineComments(t *testing.T) {
+	input := "SELECT a FROM t; // end\n-- final"
+	output, err := PrettyString(input)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "SELECT a\nFROM t; // end\n-- final"; output != want {
+		t.Errorf("unexpected output:\n%q\nwant:\n%q", output, want)
+	}
+}
+
+// TestFormatNeverLosesComments puts a comment after every token of each
+// statement and checks that both styles keep all of them and stay valid CQL.
+func TestFormatNeverLosesComments(t *testing.T) {
+	statements := []string{
+		"CREATE TABLE IF NOT EXISTS ks.t (a int, b text STATIC, c frozen<map<text, int>>, PRIMARY KEY ((a, b), c)) WITH CLUSTERING ORDER BY (c DESC) AND comment = 'x';",
+		"CREATE TYPE ks.address (street text, zip int);",
+		"CREATE KEYSPACE ks WITH replication = {'class': 'SimpleStrategy', 'replication_factor': 1} AND durable_writes = true;",
+		"ALTER KEYSPACE ks WITH replication = {'class': 'NetworkTopologyStrategy', 'dc1': 3};",
+		"CREATE MATERIALIZED VIEW v AS SELECT a, b FROM t WHERE a IS NOT NULL AND b IS NOT NULL PRIMARY KEY (b, a) WITH comment = 'v';",
+		"CREATE INDEX i ON ks.t (KEYS(m));",
+		"SELECT DISTINCT a, count(*) FROM t WHERE a = 1 AND b IN (1, 2) GROUP BY a ORDER BY b DESC PER PARTITION LIMIT 1 LIMIT 10 ALLOW FILTERING;",
+		"INSERT INTO t (a, b) VALUES (1, 'x') IF NOT EXISTS USING TTL 10;",
+		"UPDATE t USING TTL 5 SET c = c + 1, m['k'] = 2 WHERE a = 1 IF b = 2;",
+		"DELETE a, m['k'] FROM t USING TIMESTAMP 1 WHERE a = 1 IF EXISTS;",
+		"BEGIN UNLOGGED BATCH INSERT INTO t (a) VALUES (1) UPDATE t SET b = 2 WHERE a = 1 APPLY BATCH;",
+		"DROP TABLE IF EXISTS ks.t;",
+		"GRANT SELECT ON KEYSPACE ks TO analyst;",
+	}
+
+	for _, stmt := range statements {
+		tokens, offsets := lexDefault(stmt)
+		var sb strings.Builder
+		var want []string
+		last := 0
+		for i, tok := range tokens {
+			end := offsets(tok.GetStop() + 1)
+			sb.WriteString(stmt[last:end])
+			last = end
+			marker := "c" + strings.Repeat("x", i)
+			want = append(want, marker)
+			if i%2 == 0 {
+				sb.WriteString(" /* " + marker + " */")
+			} else {
+				sb.WriteString(" -- " + marker + "\n")
+			}
+		}
+		input := sb.String()
+
+		for _, opts := range []Options{DefaultOptions(), CompactOptions()} {
+			output, err := String(input, opts)
+			if err != nil {
+				t.Fatalf("cannot format %q: %v", input, err)
+			}
+			for _, marker := range want {
+				if !strings.Contains(output, " "+marker+" ") && !strings.HasSuffix(output, " "+marker) &&
+					!strings.Contains(output, " "+marker+"\n") {
+					t.Errorf("comment %s lost\ninput:\n%s\noutput:\n%s", marker, input, output)
+					break
+				}
+			}
+			for _, r := range parse.Multiple(output) {
+				if r.HasErrors() {
+					t.Errorf("formatted output is invalid: %v\n%s", r.Errors, output)
+				}
+			}
+		}
+	}
+}
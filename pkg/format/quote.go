@@ -0,0 +1,19 @@
+package format
+
+import "github.com/tentacle-scylla/scql/pkg/ast"
+
+// IsKeyword reports whether word is a CQL keyword (case-insensitive)
+func IsKeyword(word string) bool {
+	return ast.IsKeyword(word)
+}
+
+// QuoteIdentifier returns name as a CQL identifier, quoting it only when needed.
+// Names with uppercase or special characters and keywords are double-quoted.
+func QuoteIdentifier(name string) string {
+	return ast.QuoteIdentifier(name)
+}
+
+// QuoteString returns s as a single-quoted CQL string literal
+func QuoteString(s string) string {
+	return ast.QuoteString(s)
+}
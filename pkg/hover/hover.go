@@ -225,7 +225,7 @@ func resolveIdentifierHover(token *Token, ctx *HoverContext) *HoverInfo {
 		if ks != "" {
 			if col := findColumn(ctx.Schema, ks, queryCtx.tableName, token.Text); col != nil {
 				return &HoverInfo{
-					Content: formatColumnHover(col, queryCtx.tableName),
+					Content: formatColumnHover(col, queryCtx.tableName, ctx.Schema.GetKeyspace(ks)),
 					Range:   &Range{Start: token.Start, End: token.End},
 					Kind:    HoverColumn,
 					Name:    col.Name,
@@ -426,8 +426,9 @@ func formatTypeHover(info *TypeInfo) string {
 	return sb.String()
 }
 
-// formatColumnHover formats hover content for a column.
-func formatColumnHover(col *schema.Column, tableName string) string {
+// formatColumnHover formats hover content for a column, with the definitions
+// of the user-defined types of ks that its type references.
+func formatColumnHover(col *schema.Column, tableName string, ks *schema.Keyspace) string {
 	var sb strings.Builder
 	sb.WriteString(fmt.Sprintf("**%s**: `%s`\n\n", col.Name, col.Type))
 
@@ -447,6 +448,15 @@ func formatColumnHover(col *schema.Column, tableName string) string {
 	}
 
 	sb.WriteString(fmt.Sprintf("Table: %s", tableName))
+
+	if udts := ks.ReferencedTypes(col.Type); len(udts) > 0 {
+		sb.WriteString("\n\n```cql")
+		for _, udt := range udts {
+			sb.WriteString("\n")
+			sb.WriteString(udt.DDL())
+		}
+		sb.WriteString("\n```")
+	}
 	return sb.String()
 }
 
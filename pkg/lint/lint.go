@@ -11,7 +11,8 @@ func Check(input string) types.Errors {
 	return result.Errors
 }
 
-// CheckMultiple validates multiple CQL statements and returns all errors
+// CheckMultiple validates multiple CQL statements and returns all errors.
+// Error positions are relative to the whole input, not to each statement.
 func CheckMultiple(input string) types.Errors {
 	results := parse.Multiple(input)
 	var allErrors types.Errors
@@ -32,6 +33,12 @@ type Result struct {
 	Type    types.StatementType
 	Errors  types.Errors
 	IsValid bool
+
+	// Offset, Line and Column locate Input in the script passed to
+	// AnalyzeMultiple; see parse.Result
+	Offset int
+	Line   int
+	Column int
 }
 
 // Analyze performs detailed analysis on a CQL statement
@@ -42,10 +49,12 @@ func Analyze(input string) *Result {
 		Type:    parseResult.Type,
 		Errors:  parseResult.Errors,
 		IsValid: parseResult.IsValid(),
+		Line:    1,
 	}
 }
 
-// AnalyzeMultiple performs detailed analysis on multiple CQL statements
+// AnalyzeMultiple performs detailed analysis on multiple CQL statements.
+// Error positions are relative to the whole input.
 func AnalyzeMultiple(input string) []*Result {
 	parseResults := parse.Multiple(input)
 	var results []*Result
@@ -55,6 +64,9 @@ func AnalyzeMultiple(input string) []*Result {
 			Type:    pr.Type,
 			Errors:  pr.Errors,
 			IsValid: pr.IsValid(),
+			Offset:  pr.Offset,
+			Line:    pr.Line,
+			Column:  pr.Column,
 		})
 	}
 	return results
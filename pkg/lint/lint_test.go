@@ -57,6 +57,24 @@ func TestCheckMultiple(t *testing.T) {
 	}
 }
 
+func TestCheckMultiplePositions(t *testing.T) {
+	input := "/* first; */\nSELECT * FROM users;\n\nSELECT *\n  FORM users;\n"
+
+	errors := CheckMultiple(input)
+	if len(errors) == 0 {
+		t.Fatal("expected errors")
+	}
+	// FORM is on line 5 of the input, not line 2 of its statement
+	if err := errors.First(); err.Line != 5 || err.Column != 2 {
+		t.Errorf("position = %s, want 5:2", err.Position())
+	}
+
+	results := AnalyzeMultiple(input)
+	if len(results) != 2 || results[1].Line != 4 || results[1].Offset != 35 {
+		t.Errorf("unexpected statement locations: %+v", results)
+	}
+}
+
 func TestIsValid(t *testing.T) {
 	if !IsValid("SELECT * FROM users;") {
 		t.Error("valid query should return true")
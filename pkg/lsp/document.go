@@ -0,0 +1,174 @@
+package lsp
+
+import (
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/tentacle-scylla/scql/pkg/ast"
+	"github.com/tentacle-scylla/scql/pkg/parse"
+)
+
+// Position encodings negotiated with the client
+const (
+	encodingUTF8  = "utf-8"
+	encodingUTF16 = "utf-16"
+)
+
+// document is an open text document.
+type document struct {
+	uri        string
+	text       string
+	lineStarts []int // Byte offset of the start of each line
+
+	statements []statement // Computed on first use
+}
+
+// statement is a statement of a document with the keyspace in effect for it.
+type statement struct {
+	parse.ScriptStatement
+	keyspace string // Set by the last USE before the statement, if any
+}
+
+func newDocument(uri, text string) *document {
+	d := &document{uri: uri, text: text, lineStarts: []int{0}}
+	for i := 0; i < len(text); i++ {
+		if text[i] == '\n' {
+			d.lineStarts = append(d.lineStarts, i+1)
+		}
+	}
+	return d
+}
+
+// line returns the text of a zero-based line, without its line break.
+func (d *document) line(n int) string {
+	start := d.lineStarts[n]
+	end := len(d.text)
+	if n+1 < len(d.lineStarts) {
+		end = d.lineStarts[n+1]
+	}
+	return strings.TrimRight(d.text[start:end], "\r\n")
+}
+
+// offset converts a position to a byte offset, clamping it to the document.
+func (d *document) offset(p Position, encoding string) int {
+	if p.Line < 0 {
+		return 0
+	}
+	if p.Line >= len(d.lineStarts) {
+		return len(d.text)
+	}
+	line := d.line(p.Line)
+	units := 0
+	for i, r := range line {
+		if units >= p.Character {
+			return d.lineStarts[p.Line] + i
+		}
+		units += runeWidth(r, encoding)
+	}
+	return d.lineStarts[p.Line] + len(line)
+}
+
+// position converts a byte offset to a position.
+func (d *document) position(offset int, encoding string) Position {
+	if offset > len(d.text) {
+		offset = len(d.text)
+	}
+	n := sort.SearchInts(d.lineStarts, offset+1) - 1
+	character := 0
+	for _, r := range d.text[d.lineStarts[n]:offset] {
+		character += runeWidth(r, encoding)
+	}
+	return Position{Line: n, Character: character}
+}
+
+// runeOffset converts a 1-based line and 0-based column in characters, as in
+// types.Error, to a byte offset.
+func (d *document) runeOffset(line, column int) int {
+	n := line - 1
+	if n < 0 {
+		return 0
+	}
+	if n >= len(d.lineStarts) {
+		return len(d.text)
+	}
+	offset := d.lineStarts[n]
+	for ; column > 0 && offset < len(d.text) && d.text[offset] != '\n'; column-- {
+		_, size := utf8.DecodeRuneInString(d.text[offset:])
+		offset += size
+	}
+	return offset
+}
+
+// end returns the position of the end of the document.
+func (d *document) end(encoding string) Position {
+	return d.position(len(d.text), encoding)
+}
+
+// scriptStatements splits the document into statements.
+func (d *document) scriptStatements() []statement {
+	if d.statements != nil {
+		return d.statements
+	}
+	d.statements = []statement{}
+	keyspace := ""
+	for _, s := range parse.SplitScript(d.text) {
+		d.statements = append(d.statements, statement{ScriptStatement: s, keyspace: keyspace})
+		if ks, ok := useKeyspace(s.Text); ok {
+			keyspace = ks
+		}
+	}
+	return d.statements
+}
+
+// statementAt returns the statement containing offset, and the offset relative
+// to it. A cursor after a terminated statement is in a new, empty statement;
+// a cursor after an unterminated one extends it.
+func (d *document) statementAt(offset int) (statement, int) {
+	var found *statement
+	for i, s := range d.scriptStatements() {
+		if s.Offset > offset {
+			break
+		}
+		found = &d.statements[i]
+	}
+	if found == nil {
+		return statement{}, 0
+	}
+	stmt := *found
+	if end := stmt.Offset + len(stmt.Text); offset > end {
+		if stmt.Terminated {
+			next := statement{keyspace: stmt.keyspace}
+			if ks, ok := useKeyspace(stmt.Text); ok {
+				next.keyspace = ks
+			}
+			return next, 0
+		}
+		// Include the whitespace typed after the statement
+		stmt.Text = d.text[stmt.Offset:offset]
+	}
+	return stmt, offset - stmt.Offset
+}
+
+// useKeyspace returns the keyspace of a USE statement.
+func useKeyspace(text string) (string, bool) {
+	if !strings.Contains(strings.ToUpper(text), "USE") {
+		return "", false
+	}
+	use, ok := parse.Parse(text).AST().(*ast.UseStmt)
+	if !ok {
+		return "", false
+	}
+	return use.Keyspace, true
+}
+
+// runeWidth returns the width of r in the units of the encoding.
+func runeWidth(r rune, encoding string) int {
+	if encoding == encodingUTF8 {
+		return utf8.RuneLen(r)
+	}
+	if r >= 0x10000 {
+		return 2
+	}
+	return 1
+}
@@ -0,0 +1,428 @@
+package lsp
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/tentacle-scylla/scql/pkg/analyze"
+	"github.com/tentacle-scylla/scql/pkg/complete"
+	"github.com/tentacle-scylla/scql/pkg/format"
+	"github.com/tentacle-scylla/scql/pkg/hover"
+	"github.com/tentacle-scylla/scql/pkg/lint"
+	"github.com/tentacle-scylla/scql/pkg/tokenize"
+)
+
+// keyspace returns the default keyspace for a statement.
+func (s *Server) keyspace(stmt statement) string {
+	if stmt.keyspace != "" {
+		return stmt.keyspace
+	}
+	return s.config.Keyspace
+}
+
+// Completion
+
+// completionKinds maps completion kinds to LSP CompletionItemKind values.
+var completionKinds = map[complete.CompletionKind]int{
+	complete.KindKeyword:  14, // Keyword
+	complete.KindTable:    7,  // Class
+	complete.KindView:     8,  // Interface
+	complete.KindColumn:   5,  // Field
+	complete.KindField:    10, // Property
+	complete.KindFunction: 3,  // Function
+	complete.KindType:     25, // TypeParameter
+	complete.KindKeyspace: 9,  // Module
+	complete.KindOperator: 24, // Operator
+	complete.KindSnippet:  15, // Snippet
+}
+
+func (s *Server) completion(params json.RawMessage) (any, error) {
+	var p textDocumentPositionParams
+	if err := json.Unmarshal(params, &p); err != nil {
+		return nil, err
+	}
+	d, err := s.document(p.TextDocument.URI)
+	if err != nil {
+		return nil, err
+	}
+
+	stmt, pos := d.statementAt(d.offset(p.Position, s.encoding))
+	result := complete.GetCompletionsResult(&complete.CompletionContext{
+		Query:           stmt.Text,
+		Position:        pos,
+		Schema:          s.schema,
+		DefaultKeyspace: s.keyspace(stmt),
+	})
+
+	list := completionList{Items: make([]completionItem, 0, len(result.Items))}
+	for i, item := range result.Items {
+		ci := completionItem{
+			Label:      item.Label,
+			Kind:       completionKinds[item.Kind],
+			Detail:     item.Detail,
+			InsertText: item.GetInsertText(),
+			FilterText: item.FilterText,
+			// Items are already sorted by priority
+			SortText: fmt.Sprintf("%05d", i),
+		}
+		if item.Documentation != "" {
+			ci.Documentation = &markupContent{Kind: "markdown", Value: item.Documentation}
+		}
+		list.Items = append(list.Items, ci)
+	}
+	return &list, nil
+}
+
+// Hover
+
+func (s *Server) hover(params json.RawMessage) (any, error) {
+	var p textDocumentPositionParams
+	if err := json.Unmarshal(params, &p); err != nil {
+		return nil, err
+	}
+	d, err := s.document(p.TextDocument.URI)
+	if err != nil {
+		return nil, err
+	}
+
+	stmt, pos := d.statementAt(d.offset(p.Position, s.encoding))
+	info := hover.GetHoverInfo(&hover.HoverContext{
+		Query:           stmt.Text,
+		Position:        pos,
+		Schema:          s.schema,
+		DefaultKeyspace: s.keyspace(stmt),
+	})
+	if info == nil || info.Content == "" {
+		return nil, nil
+	}
+
+	result := &hoverResult{Contents: markupContent{Kind: "markdown", Value: info.Content}}
+	if info.Range != nil {
+		result.Range = &Range{
+			Start: d.position(stmt.Offset+info.Range.Start, s.encoding),
+			End:   d.position(stmt.Offset+info.Range.End, s.encoding),
+		}
+	}
+	return result, nil
+}
+
+// Diagnostics
+
+// publishDiagnostics sends the syntax errors and analysis results of a document.
+func (s *Server) publishDiagnostics(d *document) {
+	s.notify("textDocument/publishDiagnostics", &publishDiagnosticsParams{URI: d.uri, Diagnostics: s.diagnostics(d)})
+}
+
+// diagnostics returns syntax errors for every statement, and schema errors
+// and warnings from analyze for the valid ones.
+func (s *Server) diagnostics(d *document) []Diagnostic {
+	diags := []Diagnostic{}
+	stmts := d.scriptStatements()
+	for i, r := range lint.AnalyzeMultiple(d.text) {
+		if !r.IsValid {
+			for _, e := range r.Errors {
+				diags = append(diags, Diagnostic{
+					Range:    d.wordRange(d.runeOffset(e.Line, e.Column), s.encoding),
+					Severity: severityError,
+					Source:   "scql",
+					Message:  withSuggestion(e.DisplayMessage(), e.Suggestion),
+				})
+			}
+			continue
+		}
+
+		opts := analyze.DefaultOptions()
+		opts.Schema = s.schema
+		if i < len(stmts) {
+			opts.DefaultKeyspace = s.keyspace(stmts[i])
+		}
+		result := analyze.Analyze(r.Input, opts)
+		rangeOf := func(p *analyze.Position) Range {
+			if p == nil {
+				return d.firstLineRange(r.Offset, s.encoding)
+			}
+			line, column := p.Line+r.Line-1, p.Column
+			if p.Line == 1 {
+				column += r.Column
+			}
+			return d.wordRange(d.runeOffset(line, column), s.encoding)
+		}
+		for _, e := range result.SchemaErrors {
+			diags = append(diags, Diagnostic{
+				Range:    rangeOf(e.Position),
+				Severity: severityError,
+				Code:     string(e.Type),
+				Source:   "scql",
+				Message:  withSuggestion(e.Message, e.Suggestion),
+			})
+		}
+		for _, w := range result.Warnings {
+			diags = append(diags, Diagnostic{
+				Range:    rangeOf(w.Position),
+				Severity: warningSeverity(w.Severity),
+				Code:     string(w.Type),
+				Source:   "scql",
+				Message:  withSuggestion(w.Message, w.Suggestion),
+			})
+		}
+	}
+	return diags
+}
+
+func warningSeverity(severity analyze.Severity) int {
+	switch severity {
+	case analyze.SeverityError:
+		return severityError
+	case analyze.SeverityInfo:
+		return severityInformation
+	default:
+		return severityWarning
+	}
+}
+
+func withSuggestion(msg, suggestion string) string {
+	if suggestion == "" {
+		return msg
+	}
+	return fmt.Sprintf("%s (suggestion: %s)", msg, suggestion)
+}
+
+// wordRange returns the range of the word starting at offset, or of the
+// single character there.
+func (d *document) wordRange(offset int, encoding string) Range {
+	end := offset
+	for end < len(d.text) && isWordByte(d.text[end]) {
+		end++
+	}
+	if end == offset && end < len(d.text) && d.text[end] != '\n' {
+		_, size := utf8.DecodeRuneInString(d.text[end:])
+		end += size
+	}
+	return Range{Start: d.position(offset, encoding), End: d.position(end, encoding)}
+}
+
+// firstLineRange returns the range from offset to the end of its line.
+func (d *document) firstLineRange(offset int, encoding string) Range {
+	end := offset + len(strings.TrimRight(lineFrom(d.text, offset), "\r"))
+	return Range{Start: d.position(offset, encoding), End: d.position(end, encoding)}
+}
+
+// lineFrom returns the text from offset to the end of its line.
+func lineFrom(s string, offset int) string {
+	if i := strings.IndexByte(s[offset:], '\n'); i >= 0 {
+		return s[offset : offset+i]
+	}
+	return s[offset:]
+}
+
+func isWordByte(c byte) bool {
+	return c == '_' || ('0' <= c && c <= '9') || ('a' <= c && c <= 'z') || ('A' <= c && c <= 'Z') || c >= utf8.RuneSelf
+}
+
+// Formatting
+
+// formatOptions converts LSP formatting options to formatter options.
+func formatOptions(o formattingOptions) format.Options {
+	opts := format.DefaultOptions()
+	switch {
+	case !o.InsertSpaces:
+		opts.IndentString = "\t"
+	case o.TabSize > 0:
+		opts.IndentString = strings.Repeat(" ", o.TabSize)
+	}
+	return opts
+}
+
+// formatStatements formats statements, separated by blank lines like
+// scql format. It fails if any statement is invalid.
+func formatStatements(stmts []statement, opts format.Options) (string, error) {
+	var outputs []string
+	for _, stmt := range stmts {
+		out, err := format.String(stmt.Text, opts)
+		if err != nil {
+			return "", err
+		}
+		outputs = append(outputs, out)
+	}
+	return strings.Join(outputs, "\n\n"), nil
+}
+
+func (s *Server) formatting(params json.RawMessage) (any, error) {
+	var p documentFormattingParams
+	if err := json.Unmarshal(params, &p); err != nil {
+		return nil, err
+	}
+	d, err := s.document(p.TextDocument.URI)
+	if err != nil {
+		return nil, err
+	}
+
+	stmts := d.scriptStatements()
+	if len(stmts) == 0 {
+		return []TextEdit{}, nil
+	}
+	output, err := formatStatements(stmts, formatOptions(p.Options))
+	if err != nil {
+		// Invalid CQL is reported by diagnostics; leave the document as is
+		return nil, nil
+	}
+	output += "\n"
+	if output == d.text {
+		return []TextEdit{}, nil
+	}
+	return []TextEdit{{
+		Range:   Range{Start: Position{}, End: d.end(s.encoding)},
+		NewText: output,
+	}}, nil
+}
+
+func (s *Server) rangeFormatting(params json.RawMessage) (any, error) {
+	var p documentRangeFormattingParams
+	if err := json.Unmarshal(params, &p); err != nil {
+		return nil, err
+	}
+	d, err := s.document(p.TextDocument.URI)
+	if err != nil {
+		return nil, err
+	}
+
+	// Format every statement the range touches
+	start, end := d.offset(p.Range.Start, s.encoding), d.offset(p.Range.End, s.encoding)
+	var selected []statement
+	for _, stmt := range d.scriptStatements() {
+		stmtEnd := stmt.Offset + len(stmt.Text)
+		if stmt.Offset <= end && stmtEnd >= start {
+			selected = append(selected, stmt)
+		}
+	}
+	if len(selected) == 0 {
+		return []TextEdit{}, nil
+	}
+	output, err := formatStatements(selected, formatOptions(p.Options))
+	if err != nil {
+		return nil, nil
+	}
+
+	first, last := selected[0], selected[len(selected)-1]
+	from, to := first.Offset, last.Offset+len(last.Text)
+	if output == d.text[from:to] {
+		return []TextEdit{}, nil
+	}
+	return []TextEdit{{
+		Range:   Range{Start: d.position(from, s.encoding), End: d.position(to, s.encoding)},
+		NewText: output,
+	}}, nil
+}
+
+// Semantic tokens
+
+// semanticTokenTypes and semanticTokenModifiers are the legend of the
+// semantic tokens sent to the client.
+var (
+	semanticTokenTypes = []string{
+		"keyword", "function", "type", "string", "number", "comment",
+		"variable", "operator", "property", "parameter",
+	}
+	semanticTokenModifiers = []string{"partitionKey", "clusteringKey"}
+)
+
+// semanticToken maps token types to an index in semanticTokenTypes and a
+// modifier bit set. Punctuation is not highlighted.
+var semanticToken = map[tokenize.TokenType]struct{ kind, modifiers int }{
+	tokenize.TokenKeyword:       {0, 0},
+	tokenize.TokenFunction:      {1, 0},
+	tokenize.TokenType_:         {2, 0},
+	tokenize.TokenString:        {3, 0},
+	tokenize.TokenNumber:        {4, 0},
+	tokenize.TokenComment:       {5, 0},
+	tokenize.TokenIdentifier:    {6, 0},
+	tokenize.TokenOperator:      {7, 0},
+	tokenize.TokenColumn:        {8, 0},
+	tokenize.TokenPartitionKey:  {8, 1 << 0},
+	tokenize.TokenClusteringKey: {8, 1 << 1},
+	tokenize.TokenPlaceholder:   {9, 0},
+}
+
+func (s *Server) semanticTokensFull(params json.RawMessage) (any, error) {
+	var p semanticTokensParams
+	if err := json.Unmarshal(params, &p); err != nil {
+		return nil, err
+	}
+	d, err := s.document(p.TextDocument.URI)
+	if err != nil {
+		return nil, err
+	}
+
+	data := []int{}
+	prev := Position{}
+	for _, stmt := range d.scriptStatements() {
+		// Token offsets are character indexes into the statement
+		offsets := make([]int, 0, len(stmt.Text)+1)
+		for i := range stmt.Text {
+			offsets = append(offsets, i)
+		}
+		offsets = append(offsets, len(stmt.Text))
+
+		for _, tok := range tokenize.Tokenize(stmt.Text, s.tokenContext(stmt)) {
+			st, ok := semanticToken[tok.Type]
+			if !ok || tok.Start >= len(offsets) || tok.End >= len(offsets) {
+				continue
+			}
+			start := stmt.Offset + offsets[tok.Start]
+			text := stmt.Text[offsets[tok.Start]:offsets[tok.End]]
+			// Tokens may not span lines, so multi-line strings are split
+			for i, part := range strings.Split(text, "\n") {
+				if i > 0 {
+					start += len("\n")
+				}
+				pos := d.position(start, s.encoding)
+				length := 0
+				for _, r := range strings.TrimRight(part, "\r") {
+					length += runeWidth(r, s.encoding)
+				}
+				start += len(part)
+				if length == 0 {
+					continue
+				}
+				deltaStart := pos.Character
+				if pos.Line == prev.Line {
+					deltaStart -= prev.Character
+				}
+				data = append(data, pos.Line-prev.Line, deltaStart, length, st.kind, st.modifiers)
+				prev = pos
+			}
+		}
+	}
+	return &semanticTokens{Data: data}, nil
+}
+
+// tokenContext returns the key columns of the table a statement uses, so that
+// they are highlighted as such.
+func (s *Server) tokenContext(stmt statement) *tokenize.Context {
+	if s.schema == nil {
+		return nil
+	}
+	refs, _, _ := analyze.ExtractReferences(stmt.Text)
+	if refs == nil || refs.Table == "" {
+		return nil
+	}
+	ksName := refs.Keyspace
+	if ksName == "" {
+		ksName = s.keyspace(stmt)
+	}
+	ks := s.schema.GetKeyspace(ksName)
+	if ks == nil {
+		return nil
+	}
+	table := ks.GetTable(refs.Table)
+	if table == nil {
+		return nil
+	}
+	return &tokenize.Context{
+		PartitionKeys:  table.PartitionKey,
+		ClusteringKeys: table.ClusteringKey,
+		Columns:        table.ColumnOrder,
+	}
+}
@@ -0,0 +1,378 @@
+package lsp
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// client scripts a session: requests and notifications are buffered, then
+// run against a server in one go.
+type client struct {
+	in     bytes.Buffer
+	nextID int
+}
+
+func (c *client) send(v any) {
+	body, err := json.Marshal(v)
+	if err != nil {
+		panic(err)
+	}
+	fmt.Fprintf(&c.in, "Content-Length: %d\r\n\r\n%s", len(body), body)
+}
+
+func (c *client) request(method string, params any) int {
+	c.nextID++
+	c.send(map[string]any{"jsonrpc": "2.0", "id": c.nextID, "method": method, "params": params})
+	return c.nextID
+}
+
+func (c *client) notify(method string, params any) {
+	c.send(map[string]any{"jsonrpc": "2.0", "method": method, "params": params})
+}
+
+// initialize sends initialize and initialized with the given options.
+func (c *client) initialize(root string, options any) {
+	c.request("initialize", map[string]any{
+		"rootUri":               "file://" + filepath.ToSlash(root),
+		"initializationOptions": options,
+		"capabilities":          map[string]any{},
+	})
+	c.notify("initialized", map[string]any{})
+}
+
+func (c *client) open(uri, text string) {
+	c.notify("textDocument/didOpen", map[string]any{
+		"textDocument": map[string]any{"uri": uri, "languageId": "cql", "version": 1, "text": text},
+	})
+}
+
+// output is a message sent by the server.
+type output struct {
+	ID     *int            `json:"id"`
+	Method string          `json:"method"`
+	Params json.RawMessage `json:"params"`
+	Result json.RawMessage `json:"result"`
+	Error  *responseError  `json:"error"`
+}
+
+// run ends the session with shutdown and exit, runs it and returns the
+// server's messages.
+func (c *client) run(t *testing.T) []output {
+	t.Helper()
+	c.request("shutdown", nil)
+	c.notify("exit", nil)
+
+	var out bytes.Buffer
+	if err := NewServer(&c.in, &out).Run(); err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	return readOutput(t, &out)
+}
+
+func readOutput(t *testing.T, r io.Reader) []output {
+	t.Helper()
+	br := bufio.NewReader(r)
+	var msgs []output
+	for {
+		header, err := br.ReadString('\n')
+		if err == io.EOF {
+			return msgs
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		length, err := strconv.Atoi(strings.TrimSpace(strings.TrimPrefix(header, "Content-Length:")))
+		if err != nil {
+			t.Fatalf("bad header %q", header)
+		}
+		if _, err := br.ReadString('\n'); err != nil {
+			t.Fatal(err)
+		}
+		body := make([]byte, length)
+		if _, err := io.ReadFull(br, body); err != nil {
+			t.Fatal(err)
+		}
+		var msg output
+		if err := json.Unmarshal(body, &msg); err != nil {
+			t.Fatalf("bad message %s: %v", body, err)
+		}
+		msgs = append(msgs, msg)
+	}
+}
+
+// result decodes the result of request id, failing on errors.
+func result(t *testing.T, msgs []output, id int, v any) {
+	t.Helper()
+	for _, m := range msgs {
+		if m.ID != nil && *m.ID == id {
+			if m.Error != nil {
+				t.Fatalf("request %d failed: %+v", id, m.Error)
+			}
+			if err := json.Unmarshal(m.Result, v); err != nil {
+				t.Fatalf("request %d: %v", id, err)
+			}
+			return
+		}
+	}
+	t.Fatalf("no response to request %d", id)
+}
+
+// lastDiagnostics returns the last diagnostics published for uri.
+func lastDiagnostics(t *testing.T, msgs []output, uri string) []Diagnostic {
+	t.Helper()
+	var diags []Diagnostic
+	found := false
+	for _, m := range msgs {
+		if m.Method != "textDocument/publishDiagnostics" {
+			continue
+		}
+		var p publishDiagnosticsParams
+		if err := json.Unmarshal(m.Params, &p); err != nil {
+			t.Fatal(err)
+		}
+		if p.URI == uri {
+			diags, found = p.Diagnostics, true
+		}
+	}
+	if !found {
+		t.Fatalf("no diagnostics published for %s", uri)
+	}
+	return diags
+}
+
+// workspace creates a workspace with a DDL schema file.
+func workspace(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	ddl := `CREATE KEYSPACE app WITH replication = {'class': 'SimpleStrategy', 'replication_factor': 1};
+CREATE TABLE app.accounts (id int, day date, email text, PRIMARY KEY (id, day));`
+	if err := os.WriteFile(filepath.Join(dir, "schema.cql"), []byte(ddl), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return dir
+}
+
+const uri = "file:///queries.cql"
+
+func TestLifecycle(t *testing.T) {
+	var c client
+	early := c.request("textDocument/hover", map[string]any{})
+	c.initialize(t.TempDir(), nil)
+	unknown := c.request("textDocument/unknown", map[string]any{})
+	msgs := c.run(t)
+
+	var init initializeResult
+	result(t, msgs, early+1, &init)
+	caps := init.Capabilities
+	if caps.PositionEncoding != encodingUTF16 || caps.TextDocumentSync != textDocumentSyncFull ||
+		!caps.HoverProvider || !caps.DocumentFormattingProvider || !caps.DocumentRangeFormattingProvider ||
+		!caps.SemanticTokensProvider.Full {
+		t.Errorf("unexpected capabilities: %+v", caps)
+	}
+
+	codes := map[int]int{}
+	for _, m := range msgs {
+		if m.ID != nil && m.Error != nil {
+			codes[*m.ID] = m.Error.Code
+		}
+	}
+	if codes[early] != codeServerNotInitialized || codes[unknown] != codeMethodNotFound {
+		t.Errorf("error codes = %v", codes)
+	}
+
+	// Exit without shutdown is an error
+	var in bytes.Buffer
+	fmt.Fprintf(&in, "Content-Length: 33\r\n\r\n{\"jsonrpc\":\"2.0\",\"method\":\"exit\"}")
+	if err := NewServer(&in, io.Discard).Run(); err == nil {
+		t.Error("expected an error for exit without shutdown")
+	}
+}
+
+func TestDiagnostics(t *testing.T) {
+	var c client
+	c.initialize(workspace(t), map[string]any{"schema": "schema.cql"})
+	c.open(uri, "USE app;\n\nSELECT email FROM accounts WHERE id = 1;\nSELECT * FORM accounts;\nSELECT * FROM missing WHERE id = 1;\n")
+	msgs := c.run(t)
+
+	diags := lastDiagnostics(t, msgs, uri)
+	if len(diags) != 2 {
+		t.Fatalf("got %d diagnostics, want 2: %+v", len(diags), diags)
+	}
+
+	syntax := diags[0]
+	want := Range{Start: Position{Line: 3, Character: 9}, End: Position{Line: 3, Character: 13}}
+	if syntax.Severity != severityError || syntax.Range != want || !strings.Contains(syntax.Message, "FORM") {
+		t.Errorf("syntax diagnostic = %+v", syntax)
+	}
+
+	unknown := diags[1]
+	if unknown.Code != "unknown_table" || unknown.Range.Start.Line != 4 || unknown.Range.Start.Character != 0 {
+		t.Errorf("schema diagnostic = %+v", unknown)
+	}
+}
+
+func TestConfigurationChange(t *testing.T) {
+	dir := workspace(t)
+	var c client
+	c.initialize(dir, nil)
+	c.open(uri, "SELECT * FROM app.missing WHERE id = 1;")
+	c.notify("workspace/didChangeConfiguration", map[string]any{
+		"settings": map[string]any{"scql": map[string]any{"schema": filepath.Join(dir, "schema.cql")}},
+	})
+	msgs := c.run(t)
+
+	diags := lastDiagnostics(t, msgs, uri)
+	if len(diags) != 1 || diags[0].Code != "unknown_table" {
+		t.Errorf("diagnostics after loading the schema = %+v", diags)
+	}
+}
+
+func TestCompletionAndHover(t *testing.T) {
+	var c client
+	c.initialize(workspace(t), map[string]any{"schema": "schema.cql", "keyspace": "app"})
+	c.open(uri, "SELECT * FROM accounts;\nSELECT email FROM ")
+	complete := c.request("textDocument/completion", map[string]any{
+		"textDocument": map[string]any{"uri": uri},
+		"position":     Position{Line: 1, Character: 18},
+	})
+	hover := c.request("textDocument/hover", map[string]any{
+		"textDocument": map[string]any{"uri": uri},
+		"position":     Position{Line: 0, Character: 16},
+	})
+	msgs := c.run(t)
+
+	var list completionList
+	result(t, msgs, complete, &list)
+	found := false
+	for _, item := range list.Items {
+		if item.Label == "accounts" {
+			found = item.Kind == completionKinds["table"]
+		}
+	}
+	if !found {
+		t.Errorf("expected table accounts in completions: %+v", list.Items)
+	}
+
+	var h hoverResult
+	result(t, msgs, hover, &h)
+	if !strings.Contains(h.Contents.Value, "accounts") || h.Range == nil ||
+		*h.Range != (Range{Start: Position{Line: 0, Character: 14}, End: Position{Line: 0, Character: 22}}) {
+		t.Errorf("unexpected hover: %+v", h)
+	}
+}
+
+func TestFormatting(t *testing.T) {
+	var c client
+	c.initialize(t.TempDir(), nil)
+	c.open(uri, "SELECT id FROM t;\n-- second\nSELECT  name FROM t WHERE id=1;\n")
+	full := c.request("textDocument/formatting", map[string]any{
+		"textDocument": map[string]any{"uri": uri},
+		"options":      map[string]any{"tabSize": 4, "insertSpaces": true},
+	})
+	partial := c.request("textDocument/rangeFormatting", map[string]any{
+		"textDocument": map[string]any{"uri": uri},
+		"range":        Range{Start: Position{Line: 2, Character: 0}, End: Position{Line: 2, Character: 5}},
+		"options":      map[string]any{"tabSize": 2, "insertSpaces": true},
+	})
+	msgs := c.run(t)
+
+	var edits []TextEdit
+	result(t, msgs, full, &edits)
+	want := "SELECT id\nFROM t;\n\n-- second\nSELECT name\nFROM t\nWHERE id = 1;\n"
+	if len(edits) != 1 || edits[0].NewText != want || edits[0].Range.End != (Position{Line: 3}) {
+		t.Errorf("formatting edits = %+v", edits)
+	}
+
+	result(t, msgs, partial, &edits)
+	want = "-- second\nSELECT name\nFROM t\nWHERE id = 1;"
+	wantRange := Range{Start: Position{Line: 1}, End: Position{Line: 2, Character: 31}}
+	if len(edits) != 1 || edits[0].NewText != want || edits[0].Range != wantRange {
+		t.Errorf("range formatting edits = %+v", edits)
+	}
+}
+
+func TestSemanticTokens(t *testing.T) {
+	var c client
+	c.initialize(workspace(t), map[string]any{"schema": "schema.cql"})
+	c.open(uri, "SELECT email FROM app.accounts\nWHERE id = 'é\n😀';")
+	id := c.request("textDocument/semanticTokens/full", map[string]any{
+		"textDocument": map[string]any{"uri": uri},
+	})
+	msgs := c.run(t)
+
+	var tokens semanticTokens
+	result(t, msgs, id, &tokens)
+	if len(tokens.Data)%5 != 0 {
+		t.Fatalf("data length %d is not a multiple of 5", len(tokens.Data))
+	}
+
+	// Decode to absolute positions
+	type token struct{ line, char, length, kind, modifiers int }
+	var got []token
+	line, char := 0, 0
+	for i := 0; i < len(tokens.Data); i += 5 {
+		d := tokens.Data[i : i+5]
+		if d[0] > 0 {
+			char = 0
+		}
+		line += d[0]
+		char += d[1]
+		got = append(got, token{line, char, d[2], d[3], d[4]})
+	}
+	want := []token{
+		{0, 0, 6, 0, 0},  // SELECT
+		{0, 7, 5, 8, 0},  // email
+		{0, 13, 4, 0, 0}, // FROM
+		{0, 18, 3, 6, 0}, // app
+		{0, 22, 8, 6, 0}, // accounts
+		{1, 0, 5, 0, 0},  // WHERE
+		{1, 6, 2, 8, 1},  // id, partition key
+		{1, 9, 1, 7, 0},  // =
+		{1, 11, 2, 3, 0}, // 'é
+		{2, 0, 3, 3, 0},  // 😀' (a surrogate pair)
+	}
+	if fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Errorf("tokens =\n%v\nwant\n%v", got, want)
+	}
+}
+
+func TestDocumentPositions(t *testing.T) {
+	d := newDocument(uri, "ab\n😀c\r\nd")
+	tests := []struct {
+		offset int
+		utf16  Position
+		utf8   Position
+	}{
+		{0, Position{0, 0}, Position{0, 0}},
+		{3, Position{1, 0}, Position{1, 0}},
+		{7, Position{1, 2}, Position{1, 4}},
+		{8, Position{1, 3}, Position{1, 5}},
+		{10, Position{2, 0}, Position{2, 0}},
+	}
+	for _, tt := range tests {
+		if got := d.position(tt.offset, encodingUTF16); got != tt.utf16 {
+			t.Errorf("position(%d, utf-16) = %+v, want %+v", tt.offset, got, tt.utf16)
+		}
+		if got := d.position(tt.offset, encodingUTF8); got != tt.utf8 {
+			t.Errorf("position(%d, utf-8) = %+v, want %+v", tt.offset, got, tt.utf8)
+		}
+		if got := d.offset(tt.utf16, encodingUTF16); got != tt.offset {
+			t.Errorf("offset(%+v, utf-16) = %d, want %d", tt.utf16, got, tt.offset)
+		}
+	}
+	// Positions past the end of a line clamp to it
+	if got := d.offset(Position{Line: 1, Character: 99}, encodingUTF16); got != 8 {
+		t.Errorf("clamped offset = %d, want 8", got)
+	}
+	if got := d.runeOffset(2, 1); got != 7 {
+		t.Errorf("runeOffset(2, 1) = %d, want 7", got)
+	}
+}
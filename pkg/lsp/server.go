@@ -0,0 +1,361 @@
+// Package lsp implements a Language Server Protocol server for CQL.
+// It exposes completion, hover, diagnostics, formatting and semantic
+// highlighting over JSON-RPC, using the other scql packages.
+package lsp
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/tentacle-scylla/scql/pkg/schema"
+)
+
+// Config is the workspace configuration of the server. It is read from the
+// initialization options and from workspace/didChangeConfiguration, either
+// at the top level or under an "scql" key.
+type Config struct {
+	// Schema is the path of a schema file: .json, a CQL DDL script, or a
+	// directory of system_schema dumps. Relative paths are resolved against
+	// the workspace root.
+	Schema string `json:"schema"`
+
+	// Keyspace is the default keyspace for statements without one
+	Keyspace string `json:"keyspace"`
+}
+
+// Server is a CQL language server reading requests from one stream and
+// writing responses and notifications to another.
+type Server struct {
+	in  *bufio.Reader
+	out io.Writer
+	mu  sync.Mutex // Serializes writes
+
+	initialized bool
+	shutdown    bool
+	encoding    string
+	root        string // Workspace root directory
+
+	config Config
+	schema *schema.Schema
+	docs   map[string]*document
+}
+
+// NewServer creates a server reading LSP messages from in and writing to out.
+func NewServer(in io.Reader, out io.Writer) *Server {
+	return &Server{
+		in:       bufio.NewReader(in),
+		out:      out,
+		encoding: encodingUTF16,
+		docs:     make(map[string]*document),
+	}
+}
+
+// errExitWithoutShutdown is returned by Run when the client exits without
+// requesting a shutdown first.
+var errExitWithoutShutdown = errors.New("lsp: exit without shutdown")
+
+// Run serves requests until the client sends exit or closes the input.
+func (s *Server) Run() error {
+	for {
+		msg, err := s.read()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			var syntaxErr *json.SyntaxError
+			if errors.As(err, &syntaxErr) {
+				s.reply(nil, nil, &responseError{Code: codeParseError, Message: err.Error()})
+				continue
+			}
+			return err
+		}
+		if msg.Method == "exit" {
+			if !s.shutdown {
+				return errExitWithoutShutdown
+			}
+			return nil
+		}
+		s.handle(msg)
+	}
+}
+
+// read reads one message: headers, a blank line, then a JSON body of
+// Content-Length bytes.
+func (s *Server) read() (*message, error) {
+	length := -1
+	for {
+		line, err := s.in.ReadString('\n')
+		if err != nil {
+			if err == io.EOF && line == "" && length < 0 {
+				return nil, io.EOF
+			}
+			return nil, err
+		}
+		line = strings.TrimRight(line, "\r\n")
+		if line == "" {
+			break
+		}
+		name, value, ok := strings.Cut(line, ":")
+		if ok && strings.EqualFold(strings.TrimSpace(name), "Content-Length") {
+			if length, err = strconv.Atoi(strings.TrimSpace(value)); err != nil {
+				return nil, fmt.Errorf("lsp: invalid Content-Length %q", value)
+			}
+		}
+	}
+	if length < 0 {
+		return nil, errors.New("lsp: missing Content-Length header")
+	}
+
+	body := make([]byte, length)
+	if _, err := io.ReadFull(s.in, body); err != nil {
+		return nil, err
+	}
+	var msg message
+	if err := json.Unmarshal(body, &msg); err != nil {
+		return nil, err
+	}
+	return &msg, nil
+}
+
+// write sends one message with its Content-Length header.
+func (s *Server) write(v any) {
+	body, err := json.Marshal(v)
+	if err != nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, _ = fmt.Fprintf(s.out, "Content-Length: %d\r\n\r\n%s", len(body), body)
+}
+
+func (s *Server) reply(id *json.RawMessage, result any, err *responseError) {
+	if err != nil {
+		result = nil
+	}
+	s.write(&response{JSONRPC: "2.0", ID: id, Result: result, Error: err})
+}
+
+func (s *Server) notify(method string, params any) {
+	s.write(&notification{JSONRPC: "2.0", Method: method, Params: params})
+}
+
+// handle dispatches a request or notification.
+func (s *Server) handle(msg *message) {
+	isRequest := msg.ID != nil
+	if !s.initialized && msg.Method != "initialize" {
+		if isRequest {
+			s.reply(msg.ID, nil, &responseError{Code: codeServerNotInitialized, Message: "server not initialized"})
+		}
+		return
+	}
+
+	handler, ok := handlers[msg.Method]
+	if !ok {
+		if isRequest {
+			s.reply(msg.ID, nil, &responseError{Code: codeMethodNotFound, Message: "method not found: " + msg.Method})
+		}
+		return
+	}
+	result, err := handler(s, msg.Params)
+	if !isRequest {
+		return
+	}
+	if err != nil {
+		s.reply(msg.ID, nil, &responseError{Code: codeInvalidParams, Message: err.Error()})
+		return
+	}
+	s.reply(msg.ID, result, nil)
+}
+
+// handlers maps methods to their handlers. Handlers of notifications return
+// no result.
+var handlers = map[string]func(s *Server, params json.RawMessage) (any, error){
+	"initialize":                          (*Server).initialize,
+	"initialized":                         ignore,
+	"shutdown":                            (*Server).shutdownRequest,
+	"textDocument/didOpen":                (*Server).didOpen,
+	"textDocument/didChange":              (*Server).didChange,
+	"textDocument/didClose":               (*Server).didClose,
+	"textDocument/didSave":                ignore,
+	"workspace/didChangeConfiguration":    (*Server).didChangeConfiguration,
+	"textDocument/completion":             (*Server).completion,
+	"textDocument/hover":                  (*Server).hover,
+	"textDocument/formatting":             (*Server).formatting,
+	"textDocument/rangeFormatting":        (*Server).rangeFormatting,
+	"textDocument/semanticTokens/full":    (*Server).semanticTokensFull,
+	"$/cancelRequest":                     ignore,
+	"$/setTrace":                          ignore,
+	"workspace/didChangeWorkspaceFolders": ignore,
+}
+
+func ignore(*Server, json.RawMessage) (any, error) {
+	return nil, nil
+}
+
+// Lifecycle
+
+func (s *Server) initialize(params json.RawMessage) (any, error) {
+	var p initializeParams
+	if err := json.Unmarshal(params, &p); err != nil {
+		return nil, err
+	}
+	for _, enc := range p.Capabilities.General.PositionEncodings {
+		if enc == encodingUTF8 {
+			s.encoding = encodingUTF8
+			break
+		}
+	}
+	switch {
+	case p.RootURI != "":
+		s.root = uriToPath(p.RootURI)
+	case len(p.WorkspaceFolders) > 0:
+		s.root = uriToPath(p.WorkspaceFolders[0].URI)
+	default:
+		s.root = p.RootPath
+	}
+	s.initialized = true
+	s.configure(p.InitializationOptions)
+
+	return &initializeResult{
+		Capabilities: serverCapabilities{
+			PositionEncoding:                s.encoding,
+			TextDocumentSync:                textDocumentSyncFull,
+			CompletionProvider:              completionOptions{TriggerCharacters: []string{".", " ", "("}},
+			HoverProvider:                   true,
+			DocumentFormattingProvider:      true,
+			DocumentRangeFormattingProvider: true,
+			SemanticTokensProvider: semanticTokensOptions{
+				Legend: semanticTokensLegend{TokenTypes: semanticTokenTypes, TokenModifiers: semanticTokenModifiers},
+				Full:   true,
+			},
+		},
+		ServerInfo: serverInfo{Name: "scql"},
+	}, nil
+}
+
+func (s *Server) shutdownRequest(json.RawMessage) (any, error) {
+	s.shutdown = true
+	return nil, nil
+}
+
+// Configuration
+
+func (s *Server) didChangeConfiguration(params json.RawMessage) (any, error) {
+	var p didChangeConfigurationParams
+	if err := json.Unmarshal(params, &p); err != nil {
+		return nil, err
+	}
+	s.configure(p.Settings)
+	for _, d := range s.docs {
+		s.publishDiagnostics(d)
+	}
+	return nil, nil
+}
+
+// configure applies settings and reloads the schema if its path changed.
+func (s *Server) configure(settings json.RawMessage) {
+	if len(settings) == 0 || string(settings) == "null" {
+		return
+	}
+	var nested struct {
+		SCQL *Config `json:"scql"`
+	}
+	config := s.config
+	if err := json.Unmarshal(settings, &nested); err == nil && nested.SCQL != nil {
+		config = *nested.SCQL
+	} else if err := json.Unmarshal(settings, &config); err != nil {
+		s.notify("window/showMessage", &showMessageParams{Type: messageError, Message: "scql: invalid settings: " + err.Error()})
+		return
+	}
+
+	reload := config.Schema != s.config.Schema
+	s.config = config
+	if reload {
+		s.loadSchema()
+	}
+}
+
+// loadSchema loads the configured schema. A DDL script with errors still
+// yields the statements that could be applied.
+func (s *Server) loadSchema() {
+	s.schema = nil
+	if s.config.Schema == "" {
+		return
+	}
+	path := s.config.Schema
+	if !filepath.IsAbs(path) && s.root != "" {
+		path = filepath.Join(s.root, path)
+	}
+	sch, err := schema.Load(path)
+	s.schema = sch
+	if err != nil {
+		s.notify("window/showMessage", &showMessageParams{
+			Type:    messageWarning,
+			Message: fmt.Sprintf("scql: loading schema %s: %v", s.config.Schema, err),
+		})
+	}
+}
+
+// Document synchronization
+
+func (s *Server) didOpen(params json.RawMessage) (any, error) {
+	var p didOpenParams
+	if err := json.Unmarshal(params, &p); err != nil {
+		return nil, err
+	}
+	d := newDocument(p.TextDocument.URI, p.TextDocument.Text)
+	s.docs[d.uri] = d
+	s.publishDiagnostics(d)
+	return nil, nil
+}
+
+func (s *Server) didChange(params json.RawMessage) (any, error) {
+	var p didChangeParams
+	if err := json.Unmarshal(params, &p); err != nil {
+		return nil, err
+	}
+	if len(p.ContentChanges) == 0 {
+		return nil, nil
+	}
+	// Full synchronization: the last change holds the whole document
+	d := newDocument(p.TextDocument.URI, p.ContentChanges[len(p.ContentChanges)-1].Text)
+	s.docs[d.uri] = d
+	s.publishDiagnostics(d)
+	return nil, nil
+}
+
+func (s *Server) didClose(params json.RawMessage) (any, error) {
+	var p didCloseParams
+	if err := json.Unmarshal(params, &p); err != nil {
+		return nil, err
+	}
+	delete(s.docs, p.TextDocument.URI)
+	s.notify("textDocument/publishDiagnostics", &publishDiagnosticsParams{URI: p.TextDocument.URI, Diagnostics: []Diagnostic{}})
+	return nil, nil
+}
+
+// document returns an open document.
+func (s *Server) document(uri string) (*document, error) {
+	d, ok := s.docs[uri]
+	if !ok {
+		return nil, fmt.Errorf("document not open: %s", uri)
+	}
+	return d, nil
+}
+
+// uriToPath converts a file URI to a path. Other strings are returned as is.
+func uriToPath(uri string) string {
+	u, err := url.Parse(uri)
+	if err != nil || u.Scheme != "file" {
+		return uri
+	}
+	return filepath.FromSlash(u.Path)
+}
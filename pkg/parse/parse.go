@@ -7,6 +7,7 @@ import (
 	"github.com/antlr4-go/antlr/v4"
 
 	parser "github.com/tentacle-scylla/scql/gen/parser"
+	"github.com/tentacle-scylla/scql/pkg/ast"
 	"github.com/tentacle-scylla/scql/pkg/types"
 )
 
@@ -29,6 +30,13 @@ type Result struct {
 
 	// Tokens provides access to the token stream for formatting
 	Tokens *antlr.CommonTokenStream
+
+	// Offset is the byte offset of Input in the script it was split from
+	Offset int
+
+	// Line (1-based) and Column (0-based) of the start of Input in the script
+	Line   int
+	Column int
 }
 
 // HasErrors returns true if there were any parsing errors
@@ -41,6 +49,24 @@ func (r *Result) IsValid() bool {
 	return !r.HasErrors() && r.Cql != nil
 }
 
+// AST returns the typed syntax tree of the statement, or nil if parsing failed.
+// Spans are byte offsets into Input.
+func (r *Result) AST() ast.Statement {
+	if r.Cql == nil {
+		return nil
+	}
+	return ast.Build(r.Cql)
+}
+
+// ScriptPosition converts a line and column in Input to the corresponding
+// position in the script Input was split from.
+func (r *Result) ScriptPosition(line, column int) (int, int) {
+	if line == 1 {
+		column += r.Column
+	}
+	return line + r.Line - 1, column
+}
+
 // errorCollector implements antlr.ErrorListener to collect parsing errors
 type errorCollector struct {
 	*antlr.DefaultErrorListener
@@ -152,6 +178,7 @@ func Parse(input string) *Result {
 
 	result := &Result{
 		Input: input,
+		Line:  1,
 	}
 
 	// Create lexer
@@ -191,80 +218,6 @@ func Parse(input string) *Result {
 	return result
 }
 
-// Multiple parses multiple CQL statements separated by semicolons
-func Multiple(input string) []*Result {
-	var results []*Result
-
-	// Split by semicolons but be careful about strings
-	statements := splitStatements(input)
-
-	for _, stmt := range statements {
-		stmt = strings.TrimSpace(stmt)
-		if stmt == "" {
-			continue
-		}
-
-		// Ensure statement ends with semicolon for parsing
-		if !strings.HasSuffix(stmt, ";") {
-			stmt += ";"
-		}
-
-		results = append(results, Parse(stmt))
-	}
-
-	return results
-}
-
-// splitStatements splits CQL input into individual statements
-// It handles quoted strings properly to avoid splitting on semicolons inside strings
-func splitStatements(input string) []string {
-	var statements []string
-	var current strings.Builder
-	inString := false
-	stringChar := rune(0)
-
-	for i, ch := range input {
-		if !inString {
-			if ch == '\'' || ch == '"' {
-				inString = true
-				stringChar = ch
-				current.WriteRune(ch)
-			} else if ch == ';' {
-				stmt := strings.TrimSpace(current.String())
-				if stmt != "" {
-					statements = append(statements, stmt+";")
-				}
-				current.Reset()
-			} else if ch == '-' && i+1 < len(input) && input[i+1] == '-' {
-				// Skip line comments
-				for i < len(input) && input[i] != '\n' {
-					i++
-				}
-			} else {
-				current.WriteRune(ch)
-			}
-		} else {
-			current.WriteRune(ch)
-			if ch == stringChar {
-				// Check for escaped quote
-				if i+1 < len(input) && rune(input[i+1]) == stringChar {
-					continue
-				}
-				inString = false
-				stringChar = 0
-			}
-		}
-	}
-
-	// Handle any remaining content
-	stmt := strings.TrimSpace(current.String())
-	if stmt != "" {
-		statements = append(statements, stmt)
-	}
-
-	return statements
-}
-
 // IsValid returns true if the CQL input is syntactically valid
 func IsValid(input string) bool {
 	return !Parse(input).HasErrors()
@@ -288,7 +241,7 @@ func detectStatementType(ctx parser.ICqlContext) types.StatementType {
 	if ctx.Delete_() != nil {
 		return types.StatementDelete
 	}
-	if ctx.ApplyBatch() != nil {
+	if ctx.ApplyBatch() != nil || ctx.Batch() != nil {
 		return types.StatementBatch
 	}
 	if ctx.CreateKeyspace() != nil {
@@ -390,6 +343,9 @@ func detectStatementType(ctx parser.ICqlContext) types.StatementType {
 	if ctx.PruneMaterializedView() != nil {
 		return types.StatementPruneMaterializedView
 	}
+	if ctx.DescribeStatement() != nil {
+		return types.StatementDescribe
+	}
 
 	return types.StatementUnknown
 }
@@ -4,6 +4,7 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/tentacle-scylla/scql/pkg/ast"
 	"github.com/tentacle-scylla/scql/pkg/types"
 )
 
@@ -56,6 +57,18 @@ func TestParse(t *testing.T) {
 			wantType:  types.StatementCreateKeyspace,
 			wantValid: true,
 		},
+		{
+			name:      "batch",
+			input:     "BEGIN BATCH INSERT INTO a (id) VALUES (1) DELETE FROM b WHERE id = 1 APPLY BATCH;",
+			wantType:  types.StatementBatch,
+			wantValid: true,
+		},
+		{
+			name:      "describe",
+			input:     "DESCRIBE TABLE app.users;",
+			wantType:  types.StatementDescribe,
+			wantValid: true,
+		},
 		{
 			name:      "invalid - typo in FROM",
 			input:     "SELECT * FORM users;",
@@ -129,6 +142,65 @@ func TestMultipleWithStrings(t *testing.T) {
 	}
 }
 
+func TestSplitScript(t *testing.T) {
+	input := "/* setup; */ USE ks;\n" +
+		"// drop; first\n" +
+		"SELECT * FROM t WHERE k = 'a\\'; b'; -- done;\n" +
+		"  CREATE FUNCTION f(x int) RETURNS NULL ON NULL INPUT RETURNS int LANGUAGE lua AS $$ return x; $$;\n" +
+		"\tINSERT INTO t (k) VALUES ('é;') # last\n"
+
+	stmts := SplitScript(input)
+	want := []ScriptStatement{
+		{Text: "/* setup; */ USE ks;", Offset: 0, Line: 1, Column: 0, Terminated: true},
+		{Text: "// drop; first\nSELECT * FROM t WHERE k = 'a\\'; b'; -- done;", Offset: 21, Line: 2, Column: 0, Terminated: true},
+		{Text: "CREATE FUNCTION f(x int) RETURNS NULL ON NULL INPUT RETURNS int LANGUAGE lua AS $$ return x; $$;", Offset: 83, Line: 4, Column: 2, Terminated: true},
+		{Text: "INSERT INTO t (k) VALUES ('é;') # last", Offset: 181, Line: 5, Column: 1},
+	}
+	if len(stmts) != len(want) {
+		t.Fatalf("got %d statements, want %d: %+v", len(stmts), len(want), stmts)
+	}
+	for i, s := range stmts {
+		if s != want[i] {
+			t.Errorf("statement %d = %+v, want %+v", i, s, want[i])
+		}
+		if input[s.Offset:s.Offset+len(s.Text)] != s.Text {
+			t.Errorf("statement %d: offset %d does not locate its text", i, s.Offset)
+		}
+	}
+
+	// $ inside an identifier does not open a code block
+	if got := len(SplitScript("SELECT a$$b FROM t; SELECT c FROM t;")); got != 2 {
+		t.Errorf("got %d statements, want 2", got)
+	}
+}
+
+func TestMultipleErrorPositions(t *testing.T) {
+	input := "SELECT * FROM t;\n\n  SELECT * FORM t;\nSELECT *\nFROM t WHERE;"
+
+	results := Multiple(input)
+	if len(results) != 3 {
+		t.Fatalf("got %d results, want 3", len(results))
+	}
+
+	second := results[1]
+	if second.Offset != 20 || second.Line != 3 || second.Column != 2 {
+		t.Errorf("location = %d %d:%d, want 20 3:2", second.Offset, second.Line, second.Column)
+	}
+	if err := second.Errors.First(); err == nil || err.Line != 3 || err.Column != 11 {
+		t.Errorf("error = %v, want one at 3:11", err)
+	}
+
+	third := results[2]
+	if err := third.Errors.First(); err == nil || err.Line != 5 || err.Column != 12 {
+		t.Errorf("error = %v, want one at 5:12", err)
+	}
+
+	// A single statement is positioned at the start of its input
+	if r := Parse("SELECT * FROM t;"); r.Line != 1 || r.Column != 0 {
+		t.Errorf("Parse location = %d:%d, want 1:0", r.Line, r.Column)
+	}
+}
+
 func TestIsValid(t *testing.T) {
 	if !IsValid("SELECT * FROM users;") {
 		t.Error("valid query should return true")
@@ -139,6 +211,25 @@ func TestIsValid(t *testing.T) {
 	}
 }
 
+func TestAST(t *testing.T) {
+	result := Parse("  SELECT name FROM users WHERE id = 1;")
+	sel, ok := result.AST().(*ast.SelectStmt)
+	if !ok {
+		t.Fatalf("expected *ast.SelectStmt, got %T", result.AST())
+	}
+	if sel.From.Name != "users" || len(sel.Where) != 1 {
+		t.Errorf("unexpected select: %+v", sel)
+	}
+	// Spans index the trimmed Input
+	if s := sel.Where[0].Span; result.Input[s.Start:s.End] != "id = 1" {
+		t.Errorf("unexpected span %+v", s)
+	}
+
+	if (&Result{}).AST() != nil {
+		t.Error("expected nil AST without a statement")
+	}
+}
+
 func TestErrorPositions(t *testing.T) {
 	result := Parse("SELECT * FORM users;")
 
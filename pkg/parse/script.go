@@ -0,0 +1,235 @@
+package parse
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// ScriptStatement is a statement of a CQL script with its location in the script
+type ScriptStatement struct {
+	// Text is the statement with its comments, trimmed of surrounding whitespace
+	Text string
+
+	// Offset is the byte offset of Text in the script
+	Offset int
+
+	// Line (1-based) and Column (0-based, in characters) of the start of Text,
+	// counted like types.Error positions
+	Line   int
+	Column int
+
+	// Terminated is true if the statement ends with a semicolon, possibly
+	// followed by comments
+	Terminated bool
+}
+
+// SplitScript splits a CQL script into statements.
+// Semicolons inside strings, quoted identifiers, $$ code blocks and comments
+// (--, //, # and /* */) do not split. Comments stay with the statement that
+// follows them, except comments on the same line after a semicolon and
+// comments at the end of the script, which stay with the statement before
+// them.
+func SplitScript(input string) []ScriptStatement {
+	var statements []ScriptStatement
+	pos := scriptPosition{line: 1}
+	add := func(start, end int, terminated bool) {
+		raw := input[start:end]
+		text := strings.TrimSpace(raw)
+		if text == "" {
+			return
+		}
+		offset := start + len(raw) - len(strings.TrimLeftFunc(raw, unicode.IsSpace))
+		pos = pos.advance(input, offset)
+		statements = append(statements, ScriptStatement{
+			Text:       text,
+			Offset:     offset,
+			Line:       pos.line,
+			Column:     pos.column,
+			Terminated: terminated,
+		})
+	}
+
+	start := 0
+	for i := 0; i < len(input); i++ {
+		switch {
+		case input[i] == '\'' || input[i] == '"':
+			i = skipQuoted(input, i)
+		case isCodeBlockStart(input, i):
+			i = skipCodeBlock(input, i)
+		case strings.HasPrefix(input[i:], "/*"):
+			i = skipBlockComment(input, i)
+		case isLineCommentStart(input, i):
+			i = skipLine(input, i)
+		case input[i] == ';':
+			end := trailingComments(input, i+1)
+			add(start, end, true)
+			start = end
+			i = end - 1
+		}
+	}
+
+	rest := input[start:]
+	if len(statements) > 0 && onlyComments(rest) {
+		// Comments at the end of the script belong to the last statement
+		last := &statements[len(statements)-1]
+		last.Text = strings.TrimSpace(input[last.Offset:])
+	} else {
+		add(start, len(input), false)
+	}
+
+	return statements
+}
+
+// Multiple parses multiple CQL statements separated by semicolons.
+// Each result records where its statement starts in the input, and its
+// errors are positioned in the input rather than in the statement.
+func Multiple(input string) []*Result {
+	var results []*Result
+
+	for _, stmt := range SplitScript(input) {
+		text := stmt.Text
+
+		// Ensure statement ends with semicolon for parsing
+		if !stmt.Terminated {
+			text += ";"
+		}
+
+		result := Parse(text)
+		result.Offset = stmt.Offset
+		result.Line = stmt.Line
+		result.Column = stmt.Column
+		for _, err := range result.Errors {
+			err.Line, err.Column = result.ScriptPosition(err.Line, err.Column)
+		}
+		results = append(results, result)
+	}
+
+	return results
+}
+
+// scriptPosition tracks the line and column of a byte offset in a script
+type scriptPosition struct {
+	offset int
+	line   int
+	column int
+}
+
+// advance moves the position forward to offset.
+func (p scriptPosition) advance(input string, offset int) scriptPosition {
+	for p.offset < offset {
+		r, size := utf8.DecodeRuneInString(input[p.offset:])
+		if r == '\n' {
+			p.line++
+			p.column = 0
+		} else {
+			p.column++
+		}
+		p.offset += size
+	}
+	return p
+}
+
+// skipQuoted returns the index of the closing quote of the string or quoted
+// identifier starting at i. Doubled quotes are escapes, as are backslashes in
+// strings.
+func skipQuoted(input string, i int) int {
+	quote := input[i]
+	for j := i + 1; j < len(input); j++ {
+		switch {
+		case input[j] == '\\' && quote == '\'':
+			j++
+		case input[j] == quote:
+			if j+1 < len(input) && input[j+1] == quote {
+				j++
+				continue
+			}
+			return j
+		}
+	}
+	return len(input) - 1
+}
+
+// isCodeBlockStart reports whether a $$ code block starts at i. A $ inside an
+// identifier does not start one.
+func isCodeBlockStart(input string, i int) bool {
+	if !strings.HasPrefix(input[i:], "$$") {
+		return false
+	}
+	if i > 0 {
+		prev := input[i-1]
+		if prev == '_' || prev == '$' || ('0' <= prev && prev <= '9') ||
+			('a' <= prev && prev <= 'z') || ('A' <= prev && prev <= 'Z') {
+			return false
+		}
+	}
+	return true
+}
+
+// skipCodeBlock returns the index of the final '$' of the code block starting at i
+func skipCodeBlock(input string, i int) int {
+	if end := strings.Index(input[i+2:], "$$"); end >= 0 {
+		return i + 2 + end + 1
+	}
+	return len(input) - 1
+}
+
+// skipBlockComment returns the index of the final '/' of the comment starting at i
+func skipBlockComment(input string, i int) int {
+	if end := strings.Index(input[i+2:], "*/"); end >= 0 {
+		return i + 2 + end + 1
+	}
+	return len(input) - 1
+}
+
+// skipLine returns the index of the newline ending the line containing i
+func skipLine(input string, i int) int {
+	if end := strings.IndexByte(input[i:], '\n'); end >= 0 {
+		return i + end
+	}
+	return len(input) - 1
+}
+
+func isLineCommentStart(input string, i int) bool {
+	return strings.HasPrefix(input[i:], "--") || strings.HasPrefix(input[i:], "//") || input[i] == '#'
+}
+
+// trailingComments returns the end of the comments following a semicolon at
+// i on the same line, including the line break.
+func trailingComments(input string, i int) int {
+	end := i
+	for j := i; j < len(input); j++ {
+		switch {
+		case input[j] == ' ' || input[j] == '\t' || input[j] == '\r':
+		case input[j] == '\n':
+			if end > i {
+				return j + 1
+			}
+			return end
+		case strings.HasPrefix(input[j:], "/*"):
+			j = skipBlockComment(input, j)
+			end = j + 1
+		case isLineCommentStart(input, j):
+			return skipLine(input, j) + 1
+		default:
+			return end
+		}
+	}
+	return end
+}
+
+// onlyComments reports whether s contains nothing but whitespace and comments
+func onlyComments(s string) bool {
+	for i := 0; i < len(s); i++ {
+		switch {
+		case s[i] == ' ' || s[i] == '\t' || s[i] == '\r' || s[i] == '\n':
+		case strings.HasPrefix(s[i:], "/*"):
+			i = skipBlockComment(s, i)
+		case isLineCommentStart(s, i):
+			i = skipLine(s, i)
+		default:
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,239 @@
+package report
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/tentacle-scylla/scql/pkg/analyze"
+)
+
+// JSON
+
+func writeJSON(w io.Writer, diags []Diagnostic) error {
+	if diags == nil {
+		diags = []Diagnostic{}
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(diags)
+}
+
+// SARIF 2.1.0, as accepted by GitHub code scanning
+
+type sarifLog struct {
+	Version string     `json:"version"`
+	Schema  string     `json:"$schema"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	InformationURI string      `json:"informationUri"`
+	Rules          []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID               string       `json:"id"`
+	ShortDescription sarifMessage `json:"shortDescription"`
+}
+
+type sarifResult struct {
+	RuleID     string            `json:"ruleId"`
+	Level      string            `json:"level"`
+	Message    sarifMessage      `json:"message"`
+	Locations  []sarifLocation   `json:"locations"`
+	Properties map[string]string `json:"properties,omitempty"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	Region           sarifRegion           `json:"region"`
+}
+
+type sarifArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+type sarifRegion struct {
+	StartLine   int `json:"startLine"`
+	StartColumn int `json:"startColumn"` // 1-based
+}
+
+func writeSARIF(w io.Writer, diags []Diagnostic) error {
+	run := sarifRun{
+		Tool: sarifTool{Driver: sarifDriver{
+			Name:           "scql",
+			InformationURI: "https://github.com/tentacle-scylla/scql",
+			Rules:          []sarifRule{},
+		}},
+		Results: []sarifResult{},
+	}
+	for _, id := range rules(diags) {
+		run.Tool.Driver.Rules = append(run.Tool.Driver.Rules, sarifRule{
+			ID:               id,
+			ShortDescription: sarifMessage{Text: strings.ReplaceAll(id, "_", " ")},
+		})
+	}
+	for _, d := range diags {
+		uri := d.File
+		if uri == "" {
+			uri = "stdin"
+		}
+		result := sarifResult{
+			RuleID:  d.Rule,
+			Level:   sarifLevel(d.Severity),
+			Message: sarifMessage{Text: withSuggestion(d)},
+			Locations: []sarifLocation{{PhysicalLocation: sarifPhysicalLocation{
+				ArtifactLocation: sarifArtifactLocation{URI: uri},
+				Region:           sarifRegion{StartLine: d.Line, StartColumn: d.Column + 1},
+			}}},
+		}
+		if d.Suggestion != "" {
+			result.Properties = map[string]string{"suggestion": d.Suggestion}
+		}
+		run.Results = append(run.Results, result)
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(&sarifLog{
+		Version: "2.1.0",
+		Schema:  "https://json.schemastore.org/sarif-2.1.0.json",
+		Runs:    []sarifRun{run},
+	})
+}
+
+func sarifLevel(s analyze.Severity) string {
+	switch s {
+	case analyze.SeverityError:
+		return "error"
+	case analyze.SeverityWarning:
+		return "warning"
+	default:
+		return "note"
+	}
+}
+
+// Checkstyle XML, as read by most CI report plugins
+
+type checkstyleReport struct {
+	XMLName xml.Name         `xml:"checkstyle"`
+	Version string           `xml:"version,attr"`
+	Files   []checkstyleFile `xml:"file"`
+}
+
+type checkstyleFile struct {
+	Name   string            `xml:"name,attr"`
+	Errors []checkstyleError `xml:"error"`
+}
+
+type checkstyleError struct {
+	Line     int    `xml:"line,attr"`
+	Column   int    `xml:"column,attr"` // 1-based
+	Severity string `xml:"severity,attr"`
+	Message  string `xml:"message,attr"`
+	Source   string `xml:"source,attr"`
+}
+
+func writeCheckstyle(w io.Writer, diags []Diagnostic) error {
+	report := checkstyleReport{Version: "4.3"}
+	index := make(map[string]int)
+	for _, d := range diags {
+		name := d.File
+		if name == "" {
+			name = "stdin"
+		}
+		i, ok := index[name]
+		if !ok {
+			i = len(report.Files)
+			index[name] = i
+			report.Files = append(report.Files, checkstyleFile{Name: name})
+		}
+		report.Files[i].Errors = append(report.Files[i].Errors, checkstyleError{
+			Line:     d.Line,
+			Column:   d.Column + 1,
+			Severity: string(d.Severity),
+			Message:  withSuggestion(d),
+			Source:   "scql." + d.Rule,
+		})
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(&report); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+// GitHub Actions workflow commands, which annotate pull requests
+
+func writeGitHub(w io.Writer, diags []Diagnostic) error {
+	for _, d := range diags {
+		var props []string
+		if d.File != "" {
+			props = append(props, "file="+escapeProperty(d.File))
+		}
+		props = append(props,
+			fmt.Sprintf("line=%d", d.Line),
+			fmt.Sprintf("col=%d", d.Column+1),
+			"title="+escapeProperty("scql "+d.Rule),
+		)
+		if _, err := fmt.Fprintf(w, "::%s %s::%s\n", githubLevel(d.Severity), strings.Join(props, ","), escapeData(withSuggestion(d))); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func githubLevel(s analyze.Severity) string {
+	switch s {
+	case analyze.SeverityError:
+		return "error"
+	case analyze.SeverityWarning:
+		return "warning"
+	default:
+		return "notice"
+	}
+}
+
+func escapeData(s string) string {
+	return strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A").Replace(s)
+}
+
+func escapeProperty(s string) string {
+	return strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A", ":", "%3A", ",", "%2C").Replace(s)
+}
+
+// withSuggestion returns the message followed by the suggestion, for formats
+// with a single message field.
+func withSuggestion(d Diagnostic) string {
+	if d.Suggestion == "" {
+		return d.Message
+	}
+	return fmt.Sprintf("%s (suggestion: %s)", d.Message, d.Suggestion)
+}
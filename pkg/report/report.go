@@ -0,0 +1,197 @@
+// Package report writes lint and analysis findings in human and
+// machine-readable formats (JSON, SARIF, Checkstyle, GitHub annotations).
+package report
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/tentacle-scylla/scql/pkg/analyze"
+	"github.com/tentacle-scylla/scql/pkg/types"
+)
+
+// Diagnostic is a single finding in a CQL file.
+type Diagnostic struct {
+	File       string           `json:"file,omitempty"`
+	Line       int              `json:"line"`   // 1-based line number
+	Column     int              `json:"column"` // 0-based column number, in characters
+	Rule       string           `json:"rule"`
+	Severity   analyze.Severity `json:"severity"`
+	Message    string           `json:"message"`
+	Suggestion string           `json:"suggestion,omitempty"`
+}
+
+// RuleSyntaxError is the rule of syntax errors.
+const RuleSyntaxError = "syntax_error"
+
+// FromError converts a syntax error.
+func FromError(file string, e *types.Error) Diagnostic {
+	return Diagnostic{
+		File:       file,
+		Line:       e.Line,
+		Column:     e.Column,
+		Rule:       RuleSyntaxError,
+		Severity:   analyze.SeverityError,
+		Message:    e.DisplayMessage(),
+		Suggestion: e.Suggestion,
+	}
+}
+
+// FromErrors converts syntax errors.
+func FromErrors(file string, errs types.Errors) []Diagnostic {
+	var diags []Diagnostic
+	for _, e := range errs {
+		diags = append(diags, FromError(file, e))
+	}
+	return diags
+}
+
+// FromAnalyze converts the syntax errors, schema errors and warnings of an
+// analysis. Their positions are relative to the analyzed statement, which
+// starts at line and column of the file; findings without a position are
+// reported at the start of the statement.
+func FromAnalyze(file string, line, column int, result *analyze.Result) []Diagnostic {
+	at := func(l, c int) (int, int) {
+		if l == 1 {
+			c += column
+		}
+		return l + line - 1, c
+	}
+	atPosition := func(p *analyze.Position) (int, int) {
+		if p == nil {
+			return line, column
+		}
+		return at(p.Line, p.Column)
+	}
+
+	var diags []Diagnostic
+	for _, e := range result.SyntaxErrors {
+		d := FromError(file, e)
+		d.Line, d.Column = at(e.Line, e.Column)
+		diags = append(diags, d)
+	}
+	for _, e := range result.SchemaErrors {
+		d := Diagnostic{
+			File:       file,
+			Rule:       string(e.Type),
+			Severity:   analyze.SeverityError,
+			Message:    e.Message,
+			Suggestion: e.Suggestion,
+		}
+		d.Line, d.Column = atPosition(e.Position)
+		diags = append(diags, d)
+	}
+	for _, w := range result.Warnings {
+		d := Diagnostic{
+			File:       file,
+			Rule:       string(w.Type),
+			Severity:   w.Severity,
+			Message:    w.Message,
+			Suggestion: w.Suggestion,
+		}
+		d.Line, d.Column = atPosition(w.Position)
+		diags = append(diags, d)
+	}
+	return diags
+}
+
+// Format is an output format.
+type Format string
+
+const (
+	Text       Format = "text"
+	JSON       Format = "json"
+	SARIF      Format = "sarif"
+	Checkstyle Format = "checkstyle"
+	GitHub     Format = "github"
+)
+
+// Formats lists the supported formats.
+var Formats = []Format{Text, JSON, SARIF, Checkstyle, GitHub}
+
+// ParseFormat returns the format with the given name.
+func ParseFormat(name string) (Format, error) {
+	for _, f := range Formats {
+		if strings.EqualFold(name, string(f)) {
+			return f, nil
+		}
+	}
+	names := make([]string, len(Formats))
+	for i, f := range Formats {
+		names[i] = string(f)
+	}
+	return "", fmt.Errorf("unknown output format %q (want %s)", name, strings.Join(names, ", "))
+}
+
+// Write writes diagnostics in the given format.
+func Write(w io.Writer, format Format, diags []Diagnostic) error {
+	switch format {
+	case Text, "":
+		return writeText(w, diags)
+	case JSON:
+		return writeJSON(w, diags)
+	case SARIF:
+		return writeSARIF(w, diags)
+	case Checkstyle:
+		return writeCheckstyle(w, diags)
+	case GitHub:
+		return writeGitHub(w, diags)
+	}
+	return fmt.Errorf("unknown output format %q", format)
+}
+
+// severityRank orders severities from least to most serious.
+var severityRank = map[analyze.Severity]int{
+	analyze.SeverityInfo:    1,
+	analyze.SeverityWarning: 2,
+	analyze.SeverityError:   3,
+}
+
+// AnyAtLeast reports whether any diagnostic is at least as serious as threshold.
+func AnyAtLeast(diags []Diagnostic, threshold analyze.Severity) bool {
+	for _, d := range diags {
+		if severityRank[d.Severity] >= severityRank[threshold] {
+			return true
+		}
+	}
+	return false
+}
+
+// Position returns the position of the diagnostic, prefixed with its file.
+func (d Diagnostic) Position() string {
+	if d.File == "" {
+		return fmt.Sprintf("line %d:%d", d.Line, d.Column)
+	}
+	return fmt.Sprintf("%s:%d:%d", d.File, d.Line, d.Column)
+}
+
+// writeText writes one line per diagnostic, followed by its suggestion.
+func writeText(w io.Writer, diags []Diagnostic) error {
+	for _, d := range diags {
+		if _, err := fmt.Fprintf(w, "%s: %s: %s\n", d.Position(), d.Severity, d.Message); err != nil {
+			return err
+		}
+		if d.Suggestion != "" {
+			if _, err := fmt.Fprintf(w, "  suggestion: %s\n", d.Suggestion); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// rules returns the distinct rules of diags, sorted.
+func rules(diags []Diagnostic) []string {
+	seen := make(map[string]bool)
+	var ids []string
+	for _, d := range diags {
+		if !seen[d.Rule] {
+			seen[d.Rule] = true
+			ids = append(ids, d.Rule)
+		}
+	}
+	sort.Strings(ids)
+	return ids
+}
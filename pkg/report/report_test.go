@@ -0,0 +1,179 @@
+package report
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"strings"
+	"testing"
+
+	"github.com/tentacle-scylla/scql/pkg/analyze"
+	"github.com/tentacle-scylla/scql/pkg/lint"
+	"github.com/tentacle-scylla/scql/pkg/types"
+)
+
+func sample() []Diagnostic {
+	return []Diagnostic{
+		{File: "q.cql", Line: 4, Column: 2, Rule: RuleSyntaxError, Severity: analyze.SeverityError,
+			Message: "Unknown keyword 'FORM'", Suggestion: "Did you mean 'FROM'?"},
+		{File: "q.cql", Line: 7, Column: 0, Rule: "no_limit", Severity: analyze.SeverityInfo,
+			Message: "Query has no LIMIT clause, 100%"},
+	}
+}
+
+func write(t *testing.T, format Format, diags []Diagnostic) string {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := Write(&buf, format, diags); err != nil {
+		t.Fatalf("Write(%s): %v", format, err)
+	}
+	return buf.String()
+}
+
+func TestFromErrors(t *testing.T) {
+	var diags []Diagnostic
+	for _, r := range lint.AnalyzeMultiple("SELECT * FROM t;\n\nSELECT *\n  FORM t;") {
+		diags = append(diags, FromErrors("q.cql", r.Errors)...)
+	}
+	if len(diags) != 1 {
+		t.Fatalf("got %d diagnostics, want 1", len(diags))
+	}
+	d := diags[0]
+	if d.Line != 4 || d.Column != 2 || d.Rule != RuleSyntaxError || d.Severity != analyze.SeverityError ||
+		d.Message != "Unknown keyword 'FORM'" || d.Suggestion == "" {
+		t.Errorf("unexpected diagnostic: %+v", d)
+	}
+}
+
+func TestFromAnalyze(t *testing.T) {
+	opts := analyze.DefaultOptions()
+	opts.WarnOnNoLimit = true
+	result := analyze.Analyze("SELECT count(a, b)\nFROM t WHERE k = 1", opts)
+	diags := FromAnalyze("q.cql", 3, 4, result)
+
+	rulesSeen := map[string]Diagnostic{}
+	for _, d := range diags {
+		rulesSeen[d.Rule] = d
+	}
+	arity, ok := rulesSeen[string(analyze.ErrFunctionArgCount)]
+	if !ok || arity.Line != 3 || arity.Column != 11 || arity.Severity != analyze.SeverityError {
+		t.Errorf("arity diagnostic = %+v", arity)
+	}
+	// Warnings without a position are reported at the statement
+	noLimit, ok := rulesSeen[string(analyze.WarnNoLimit)]
+	if !ok || noLimit.Line != 3 || noLimit.Column != 4 || noLimit.Severity != analyze.SeverityInfo {
+		t.Errorf("no_limit diagnostic = %+v", noLimit)
+	}
+
+	syntax := FromAnalyze("", 2, 5, analyze.Analyze("SELECT * FORM t", nil))
+	if len(syntax) == 0 || syntax[0].Line != 2 || syntax[0].Column != 14 {
+		t.Errorf("syntax diagnostics = %+v", syntax)
+	}
+}
+
+func TestAnyAtLeast(t *testing.T) {
+	diags := sample()
+	if !AnyAtLeast(diags, analyze.SeverityError) || !AnyAtLeast(diags[1:], analyze.SeverityInfo) {
+		t.Error("expected a diagnostic at the threshold")
+	}
+	if AnyAtLeast(diags[1:], analyze.SeverityWarning) || AnyAtLeast(nil, analyze.SeverityInfo) {
+		t.Error("expected no diagnostic above the threshold")
+	}
+}
+
+func TestParseFormat(t *testing.T) {
+	for _, f := range Formats {
+		got, err := ParseFormat(strings.ToUpper(string(f)))
+		if err != nil || got != f {
+			t.Errorf("ParseFormat(%s) = %v, %v", f, got, err)
+		}
+	}
+	if _, err := ParseFormat("yaml"); err == nil || !strings.Contains(err.Error(), "sarif") {
+		t.Errorf("expected an error listing formats, got %v", err)
+	}
+}
+
+func TestText(t *testing.T) {
+	want := "q.cql:4:2: error: Unknown keyword 'FORM'\n" +
+		"  suggestion: Did you mean 'FROM'?\n" +
+		"q.cql:7:0: info: Query has no LIMIT clause, 100%\n"
+	if got := write(t, Text, sample()); got != want {
+		t.Errorf("text =\n%s\nwant\n%s", got, want)
+	}
+
+	d := FromError("", &types.Error{Line: 1, Column: 9, Message: "raw"})
+	if got := write(t, Text, []Diagnostic{d}); got != "line 1:9: error: raw\n" {
+		t.Errorf("text without file = %q", got)
+	}
+}
+
+func TestJSON(t *testing.T) {
+	var got []Diagnostic
+	if err := json.Unmarshal([]byte(write(t, JSON, sample())), &got); err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 2 || got[0] != sample()[0] || got[1] != sample()[1] {
+		t.Errorf("round trip = %+v", got)
+	}
+	if out := write(t, JSON, nil); strings.TrimSpace(out) != "[]" {
+		t.Errorf("empty output = %q, want []", out)
+	}
+}
+
+func TestSARIF(t *testing.T) {
+	var log sarifLog
+	if err := json.Unmarshal([]byte(write(t, SARIF, sample())), &log); err != nil {
+		t.Fatal(err)
+	}
+	if log.Version != "2.1.0" || len(log.Runs) != 1 {
+		t.Fatalf("unexpected log: %+v", log)
+	}
+	run := log.Runs[0]
+	if len(run.Tool.Driver.Rules) != 2 || run.Tool.Driver.Rules[0].ID != "no_limit" {
+		t.Errorf("rules = %+v", run.Tool.Driver.Rules)
+	}
+	if len(run.Results) != 2 {
+		t.Fatalf("results = %+v", run.Results)
+	}
+	r := run.Results[0]
+	loc := r.Locations[0].PhysicalLocation
+	if r.RuleID != RuleSyntaxError || r.Level != "error" || loc.ArtifactLocation.URI != "q.cql" ||
+		loc.Region.StartLine != 4 || loc.Region.StartColumn != 3 || r.Properties["suggestion"] != "Did you mean 'FROM'?" {
+		t.Errorf("result = %+v", r)
+	}
+	if run.Results[1].Level != "note" {
+		t.Errorf("info level = %s, want note", run.Results[1].Level)
+	}
+}
+
+func TestCheckstyle(t *testing.T) {
+	out := write(t, Checkstyle, sample())
+	if !strings.HasPrefix(out, "<?xml") {
+		t.Errorf("missing XML header: %s", out)
+	}
+	var report checkstyleReport
+	if err := xml.Unmarshal([]byte(out), &report); err != nil {
+		t.Fatal(err)
+	}
+	if len(report.Files) != 1 || report.Files[0].Name != "q.cql" || len(report.Files[0].Errors) != 2 {
+		t.Fatalf("unexpected report: %+v", report)
+	}
+	e := report.Files[0].Errors[0]
+	if e.Line != 4 || e.Column != 3 || e.Severity != "error" || e.Source != "scql.syntax_error" ||
+		e.Message != "Unknown keyword 'FORM' (suggestion: Did you mean 'FROM'?)" {
+		t.Errorf("error = %+v", e)
+	}
+}
+
+func TestGitHub(t *testing.T) {
+	want := "::error file=q.cql,line=4,col=3,title=scql syntax_error::Unknown keyword 'FORM' (suggestion: Did you mean 'FROM'?)\n" +
+		"::notice file=q.cql,line=7,col=1,title=scql no_limit::Query has no LIMIT clause, 100%25\n"
+	if got := write(t, GitHub, sample()); got != want {
+		t.Errorf("github =\n%s\nwant\n%s", got, want)
+	}
+
+	d := Diagnostic{File: "a,b:c.cql", Line: 1, Rule: "x", Severity: analyze.SeverityWarning, Message: "two\nlines"}
+	if got := write(t, GitHub, []Diagnostic{d}); got != "::warning file=a%2Cb%3Ac.cql,line=1,col=1,title=scql x::two%0Alines\n" {
+		t.Errorf("escaped = %q", got)
+	}
+}
@@ -0,0 +1,992 @@
+package schema
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/antlr4-go/antlr/v4"
+
+	parser "github.com/tentacle-scylla/scql/gen/parser"
+	"github.com/tentacle-scylla/scql/pkg/parse"
+	"github.com/tentacle-scylla/scql/pkg/types"
+)
+
+// FromCQL builds a schema from a CQL DDL script.
+// Statements are applied in order; non-DDL statements are ignored.
+// The returned errors include syntax errors and statements that could not be applied.
+func FromCQL(input string) (*Schema, types.Errors) {
+	l := &cqlLoader{schema: NewSchema()}
+	for _, r := range parse.Multiple(input) {
+		l.apply(r)
+	}
+	return l.schema, l.errors
+}
+
+// LoadFromCQL loads a schema from a CQL DDL file.
+// If some statements could not be applied, the partial schema is returned along with the errors.
+func LoadFromCQL(path string) (*Schema, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	s, errs := FromCQL(string(data))
+	if errs.HasErrors() {
+		return s, errs
+	}
+	return s, nil
+}
+
+// cqlLoader applies DDL statements to a schema.
+type cqlLoader struct {
+	schema   *Schema
+	keyspace string // Current keyspace set by USE
+	result   *parse.Result
+	errors   types.Errors
+}
+
+func (l *cqlLoader) apply(r *parse.Result) {
+	l.result = r
+	if r.HasErrors() {
+		l.errors = append(l.errors, r.Errors...)
+		return
+	}
+	if r.Cql == nil {
+		return
+	}
+
+	ctx := r.Cql
+	switch {
+	case ctx.Use_() != nil:
+		l.keyspace = identifier(ctx.Use_().Keyspace())
+	case ctx.CreateKeyspace() != nil:
+		l.createKeyspace(ctx.CreateKeyspace())
+	case ctx.AlterKeyspace() != nil:
+		l.alterKeyspace(ctx.AlterKeyspace())
+	case ctx.DropKeyspace() != nil:
+		l.dropKeyspace(ctx.DropKeyspace())
+	case ctx.CreateTable() != nil:
+		l.createTable(ctx.CreateTable())
+	case ctx.AlterTable() != nil:
+		l.alterTable(ctx.AlterTable())
+	case ctx.DropTable() != nil:
+		l.dropTable(ctx.DropTable())
+	case ctx.CreateType() != nil:
+		l.createType(ctx.CreateType())
+	case ctx.AlterType() != nil:
+		l.alterType(ctx.AlterType())
+	case ctx.DropType() != nil:
+		l.dropType(ctx.DropType())
+	case ctx.CreateIndex() != nil:
+		l.createIndex(ctx.CreateIndex())
+	case ctx.DropIndex() != nil:
+		l.dropIndex(ctx.DropIndex())
+	case ctx.CreateMaterializedView() != nil:
+		l.createMaterializedView(ctx.CreateMaterializedView())
+	case ctx.AlterMaterializedView() != nil:
+		l.alterMaterializedView(ctx.AlterMaterializedView())
+	case ctx.DropMaterializedView() != nil:
+		l.dropMaterializedView(ctx.DropMaterializedView())
+	case ctx.CreateFunction() != nil:
+		l.createFunction(ctx.CreateFunction())
+	case ctx.DropFunction() != nil:
+		l.dropFunction(ctx.DropFunction())
+	case ctx.CreateAggregate() != nil:
+		l.createAggregate(ctx.CreateAggregate())
+	case ctx.DropAggregate() != nil:
+		l.dropAggregate(ctx.DropAggregate())
+	}
+}
+
+// errorf records an error positioned at the start of ctx in the script.
+func (l *cqlLoader) errorf(ctx antlr.ParserRuleContext, format string, args ...any) {
+	msg := fmt.Sprintf(format, args...)
+	err := &types.Error{
+		Line:            1,
+		Message:         msg,
+		FriendlyMessage: msg,
+		Query:           l.result.Input,
+	}
+	if ctx != nil && ctx.GetStart() != nil {
+		err.Line = ctx.GetStart().GetLine()
+		err.Column = ctx.GetStart().GetColumn()
+	}
+	err.Line, err.Column = l.result.ScriptPosition(err.Line, err.Column)
+	l.errors = append(l.errors, err)
+}
+
+// resolveKeyspace returns the keyspace named by ksCtx, or the current keyspace.
+func (l *cqlLoader) resolveKeyspace(ctx antlr.ParserRuleContext, ksCtx parser.IKeyspaceContext) *Keyspace {
+	name := l.keyspace
+	if ksCtx != nil {
+		name = identifier(ksCtx)
+	}
+	if name == "" {
+		l.errorf(ctx, "No keyspace specified and no USE statement in effect")
+		return nil
+	}
+	ks := l.schema.GetKeyspace(name)
+	if ks == nil {
+		l.errorf(ctx, "Keyspace '%s' does not exist", name)
+	}
+	return ks
+}
+
+// Keyspaces
+
+func (l *cqlLoader) createKeyspace(ctx parser.ICreateKeyspaceContext) {
+	name := identifier(ctx.Keyspace())
+	if l.schema.GetKeyspace(name) != nil {
+		if ctx.IfNotExist() == nil {
+			l.errorf(ctx, "Keyspace '%s' already exists", name)
+		}
+		return
+	}
+	ks := l.schema.AddKeyspace(name)
+	applyReplication(ks, ctx.ReplicationList())
+	if dw := ctx.DurableWrites(); dw != nil {
+		ks.WithDurableWrites(strings.EqualFold(dw.BooleanLiteral().GetText(), "true"))
+	}
+}
+
+func (l *cqlLoader) alterKeyspace(ctx parser.IAlterKeyspaceContext) {
+	name := identifier(ctx.Keyspace())
+	ks := l.schema.GetKeyspace(name)
+	if ks == nil {
+		l.errorf(ctx, "Keyspace '%s' does not exist", name)
+		return
+	}
+	applyReplication(ks, ctx.ReplicationList())
+	if dw := ctx.DurableWrites(); dw != nil {
+		ks.WithDurableWrites(strings.EqualFold(dw.BooleanLiteral().GetText(), "true"))
+	}
+}
+
+func (l *cqlLoader) dropKeyspace(ctx parser.IDropKeyspaceContext) {
+	name := identifier(ctx.Keyspace())
+	if l.schema.GetKeyspace(name) == nil {
+		if ctx.IfExist() == nil {
+			l.errorf(ctx, "Keyspace '%s' does not exist", name)
+		}
+		return
+	}
+	delete(l.schema.Keyspaces, name)
+	if l.keyspace == name {
+		l.keyspace = ""
+	}
+}
+
+// applyReplication sets the replication class and factors from a replication map.
+func applyReplication(ks *Keyspace, list parser.IReplicationListContext) {
+	if list == nil {
+		return
+	}
+	class := ""
+	factors := make(map[string]int)
+	for _, item := range list.AllReplicationListItem() {
+		key := unquoteString(item.STRING_LITERAL(0).GetText())
+		var value string
+		if lit := item.STRING_LITERAL(1); lit != nil {
+			value = unquoteString(lit.GetText())
+		} else if lit := item.DECIMAL_LITERAL(); lit != nil {
+			value = lit.GetText()
+		}
+		if key == "class" {
+			class = strings.TrimPrefix(value, "org.apache.cassandra.locator.")
+			continue
+		}
+		if n, err := strconv.Atoi(value); err == nil {
+			factors[key] = n
+		}
+	}
+	ks.WithReplication(class, factors)
+}
+
+// Tables
+
+func (l *cqlLoader) createTable(ctx parser.ICreateTableContext) {
+	ks := l.resolveKeyspace(ctx, ctx.Keyspace())
+	if ks == nil {
+		return
+	}
+	name := identifier(ctx.Table())
+	if ks.GetTable(name) != nil || ks.GetMaterializedView(name) != nil {
+		if ctx.IfNotExist() == nil {
+			l.errorf(ctx, "Table '%s' already exists in keyspace '%s'", name, ks.Name)
+		}
+		return
+	}
+
+	t := ks.AddTable(name)
+	var partitionKey, clusteringKey []string
+	defs := ctx.ColumnDefinitionList()
+	for _, def := range defs.AllColumnDefinition() {
+		col := identifier(def.Column())
+		if t.GetColumn(col) != nil {
+			l.errorf(def, "Multiple definition of identifier %s in table '%s'", col, name)
+			continue
+		}
+		if def.StaticColumn() != nil {
+			t.AddStaticColumn(col, dataTypeString(def.DataType()))
+		} else {
+			t.AddColumn(col, dataTypeString(def.DataType()))
+		}
+		if def.PrimaryKeyColumn() != nil {
+			if partitionKey != nil {
+				l.errorf(def, "Multiple PRIMARY KEY specifications in table '%s'", name)
+				continue
+			}
+			partitionKey = []string{col}
+		}
+	}
+	if pk := defs.PrimaryKeyElement(); pk != nil {
+		if partitionKey != nil {
+			l.errorf(pk, "Multiple PRIMARY KEY specifications in table '%s'", name)
+		} else {
+			partitionKey, clusteringKey = primaryKey(pk)
+		}
+	}
+	for _, col := range append(append([]string{}, partitionKey...), clusteringKey...) {
+		if t.GetColumn(col) == nil {
+			l.errorf(defs.PrimaryKeyElement(), "Unknown column '%s' referenced in PRIMARY KEY for table '%s'", col, name)
+			delete(ks.Tables, name)
+			return
+		}
+	}
+	if partitionKey == nil {
+		l.errorf(ctx, "No PRIMARY KEY specified for table '%s'", name)
+	} else {
+		t.SetPartitionKey(partitionKey...)
+		if len(clusteringKey) > 0 {
+			t.SetClusteringKey(clusteringKey...)
+		}
+	}
+
+	if with := ctx.WithElement(); with != nil {
+		l.applyTableOptions(t, with.TableOptions())
+	}
+}
+
+func (l *cqlLoader) alterTable(ctx parser.IAlterTableContext) {
+	ks := l.resolveKeyspace(ctx, ctx.Keyspace())
+	if ks == nil {
+		return
+	}
+	name := identifier(ctx.Table())
+	t := ks.GetTable(name)
+	if t == nil {
+		l.errorf(ctx, "Table '%s' does not exist in keyspace '%s'", name, ks.Name)
+		return
+	}
+
+	op := ctx.AlterTableOperation()
+	switch {
+	case op.AlterTableAdd() != nil:
+		add := op.AlterTableAdd()
+		if add.Column() != nil {
+			l.addColumn(t, add.Column(), add.DataType(), add.StaticColumn() != nil)
+		}
+		for _, def := range add.AllColumnDefinition() {
+			l.addColumn(t, def.Column(), def.DataType(), def.StaticColumn() != nil)
+		}
+	case op.KwAlter() != nil:
+		col := identifier(op.Column())
+		if existing := t.GetColumn(col); existing == nil {
+			l.errorf(op, "Column '%s' does not exist in table '%s'", col, t.Name)
+		} else {
+			existing.Type = dataTypeString(op.DataType())
+		}
+	case op.AlterTableDropColumns() != nil:
+		for _, c := range op.AlterTableDropColumns().AlterTableDropColumnList().AllColumn() {
+			col := identifier(c)
+			existing := t.GetColumn(col)
+			switch {
+			case existing == nil:
+				l.errorf(c, "Column '%s' does not exist in table '%s'", col, t.Name)
+			case existing.IsPartitionKey || existing.IsClusteringKey:
+				l.errorf(c, "Cannot drop primary key column '%s'", col)
+			default:
+				t.removeColumn(col)
+			}
+		}
+	case op.AlterTableRename() != nil:
+		rename := op.AlterTableRename()
+		from, to := identifier(rename.Column(0)), identifier(rename.Column(1))
+		switch {
+		case t.GetColumn(from) == nil:
+			l.errorf(rename, "Column '%s' does not exist in table '%s'", from, t.Name)
+		case t.GetColumn(to) != nil:
+			l.errorf(rename, "Column '%s' already exists in table '%s'", to, t.Name)
+		default:
+			t.renameColumn(from, to)
+		}
+	case op.AlterTableWith() != nil:
+		l.applyTableOptions(t, op.AlterTableWith().TableOptions())
+	}
+}
+
+func (l *cqlLoader) addColumn(t *Table, colCtx parser.IColumnContext, typeCtx parser.IDataTypeContext, static bool) {
+	col := identifier(colCtx)
+	if t.GetColumn(col) != nil {
+		l.errorf(colCtx, "Column '%s' already exists in table '%s'", col, t.Name)
+		return
+	}
+	if static {
+		t.AddStaticColumn(col, dataTypeString(typeCtx))
+	} else {
+		t.AddColumn(col, dataTypeString(typeCtx))
+	}
+}
+
+func (l *cqlLoader) dropTable(ctx parser.IDropTableContext) {
+	ks := l.resolveKeyspace(ctx, ctx.Keyspace())
+	if ks == nil {
+		return
+	}
+	name := identifier(ctx.Table())
+	t := ks.GetTable(name)
+	if t == nil {
+		if ctx.IfExist() == nil {
+			l.errorf(ctx, "Table '%s' does not exist in keyspace '%s'", name, ks.Name)
+		}
+		return
+	}
+	if len(t.MaterializedViews) > 0 {
+		l.errorf(ctx, "Cannot drop table '%s' when materialized views still depend on it (%s.{%s})",
+			name, ks.Name, strings.Join(sortedKeys(t.MaterializedViews), ", "))
+		return
+	}
+	delete(ks.Tables, name)
+}
+
+// primaryKey returns the partition and clustering key columns of a PRIMARY KEY element.
+func primaryKey(ctx parser.IPrimaryKeyElementContext) (partitionKey, clusteringKey []string) {
+	def := ctx.PrimaryKeyDefinition()
+	switch {
+	case def.SinglePrimaryKey() != nil:
+		partitionKey = []string{identifier(def.SinglePrimaryKey().Column())}
+	case def.CompoundKey() != nil:
+		ck := def.CompoundKey()
+		partitionKey = []string{identifier(ck.PartitionKey().Column())}
+		clusteringKey = clusteringKeyNames(ck.ClusteringKeyList())
+	case def.CompositeKey() != nil:
+		ck := def.CompositeKey()
+		for _, p := range ck.PartitionKeyList().AllPartitionKey() {
+			partitionKey = append(partitionKey, identifier(p.Column()))
+		}
+		clusteringKey = clusteringKeyNames(ck.ClusteringKeyList())
+	}
+	return partitionKey, clusteringKey
+}
+
+func clusteringKeyNames(list parser.IClusteringKeyListContext) []string {
+	if list == nil {
+		return nil
+	}
+	var names []string
+	for _, c := range list.AllClusteringKey() {
+		names = append(names, identifier(c.Column()))
+	}
+	return names
+}
+
+// applyTableOptions applies WITH options to a table.
+// Options without a corresponding Table field are ignored.
+func (l *cqlLoader) applyTableOptions(t *Table, opts parser.ITableOptionsContext) {
+	orders, items := flattenTableOptions(opts)
+	for _, order := range orders {
+		for col, dir := range clusteringOrder(order) {
+			if c := t.GetColumn(col); c == nil || !c.IsClusteringKey {
+				l.errorf(order, "Column '%s' is not a clustering column of table '%s'", col, t.Name)
+				continue
+			}
+			t.SetClusteringOrder(col, dir)
+		}
+	}
+	for _, item := range items {
+		name := strings.ToLower(item.TableOptionName().GetText())
+		value := ""
+		if v := item.TableOptionValue(); v != nil {
+			value = optionValue(v.GetText())
+		}
+		switch name {
+		case "comment":
+			t.WithComment(value)
+		case "gc_grace_seconds":
+			n, err := strconv.Atoi(value)
+			if err != nil {
+				l.errorf(item, "Invalid value for gc_grace_seconds: %s", value)
+				continue
+			}
+			t.WithGCGraceSeconds(n)
+		case "bloom_filter_fp_chance":
+			f, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				l.errorf(item, "Invalid value for bloom_filter_fp_chance: %s", value)
+				continue
+			}
+			t.BloomFilterFPChance = f
+		case "compaction":
+			t.Compaction = optionHash(item.OptionHash())
+		case "compression":
+			t.Compression = optionHash(item.OptionHash())
+		case "caching":
+			t.Caching = optionHash(item.OptionHash())
+		}
+	}
+}
+
+// flattenTableOptions collects the CLUSTERING ORDER clauses and option items of a WITH clause.
+func flattenTableOptions(opts parser.ITableOptionsContext) ([]parser.IClusteringOrderContext, []parser.ITableOptionItemContext) {
+	var orders []parser.IClusteringOrderContext
+	var items []parser.ITableOptionItemContext
+	for opts != nil {
+		if opts.ClusteringOrder() != nil {
+			orders = append(orders, opts.ClusteringOrder())
+		}
+		items = append(items, opts.AllTableOptionItem()...)
+		opts = opts.TableOptions()
+	}
+	return orders, items
+}
+
+// clusteringOrder returns the column directions of a CLUSTERING ORDER BY clause.
+func clusteringOrder(ctx parser.IClusteringOrderContext) map[string]Order {
+	orders := make(map[string]Order)
+	// Directions are optional, so each one applies to the column preceding it
+	last := ""
+	for _, child := range ctx.GetChildren() {
+		switch c := child.(type) {
+		case parser.IColumnContext:
+			last = identifier(c)
+			orders[last] = OrderAsc
+		case parser.IOrderDirectionContext:
+			if c.KwDesc() != nil && last != "" {
+				orders[last] = OrderDesc
+			}
+		}
+	}
+	return orders
+}
+
+// optionHash converts a {'key': value} map literal to a string map.
+func optionHash(ctx parser.IOptionHashContext) map[string]string {
+	m := make(map[string]string)
+	if ctx == nil {
+		return m
+	}
+	for _, item := range ctx.AllOptionHashItem() {
+		m[optionValue(item.OptionHashKey().GetText())] = optionValue(item.OptionHashValue().GetText())
+	}
+	return m
+}
+
+// optionValue unquotes string literals and lowercases booleans.
+func optionValue(text string) string {
+	if strings.HasPrefix(text, "'") {
+		return unquoteString(text)
+	}
+	if strings.EqualFold(text, "true") || strings.EqualFold(text, "false") {
+		return strings.ToLower(text)
+	}
+	return text
+}
+
+// removeColumn removes a column from the table.
+func (t *Table) removeColumn(name string) {
+	delete(t.Columns, name)
+	t.ColumnOrder = removeString(t.ColumnOrder, name)
+}
+
+// renameColumn renames a column, updating key and order references.
+func (t *Table) renameColumn(from, to string) {
+	col := t.Columns[from]
+	delete(t.Columns, from)
+	col.Name = to
+	t.Columns[to] = col
+	replaceString(t.ColumnOrder, from, to)
+	replaceString(t.PartitionKey, from, to)
+	replaceString(t.ClusteringKey, from, to)
+	if order, ok := t.ClusteringOrder[from]; ok {
+		delete(t.ClusteringOrder, from)
+		t.ClusteringOrder[to] = order
+	}
+	for _, idx := range t.Indexes {
+		if idx.TargetColumn == from {
+			idx.TargetColumn = to
+		}
+	}
+}
+
+// Types
+
+func (l *cqlLoader) createType(ctx parser.ICreateTypeContext) {
+	ks := l.resolveKeyspace(ctx, ctx.Keyspace())
+	if ks == nil {
+		return
+	}
+	name := identifier(ctx.Type_())
+	if ks.GetType(name) != nil {
+		if ctx.IfNotExist() == nil {
+			l.errorf(ctx, "Type '%s' already exists in keyspace '%s'", name, ks.Name)
+		}
+		return
+	}
+	udt := ks.AddType(name)
+	members := ctx.TypeMemberColumnList()
+	for i, c := range members.AllColumn() {
+		udt.AddField(identifier(c), dataTypeString(members.DataType(i)))
+	}
+}
+
+func (l *cqlLoader) alterType(ctx parser.IAlterTypeContext) {
+	ks := l.resolveKeyspace(ctx, ctx.Keyspace())
+	if ks == nil {
+		return
+	}
+	name := identifier(ctx.Type_())
+	udt := ks.GetType(name)
+	if udt == nil {
+		l.errorf(ctx, "Type '%s' does not exist in keyspace '%s'", name, ks.Name)
+		return
+	}
+
+	op := ctx.AlterTypeOperation()
+	switch {
+	case op.AlterTypeAdd() != nil:
+		add := op.AlterTypeAdd()
+		for i, c := range add.AllColumn() {
+			field := identifier(c)
+			if _, exists := udt.Fields[field]; exists {
+				l.errorf(c, "Field '%s' already exists in type '%s'", field, udt.Name)
+				continue
+			}
+			udt.AddField(field, dataTypeString(add.DataType(i)))
+		}
+	case op.AlterTypeRename() != nil:
+		for _, item := range op.AlterTypeRename().AlterTypeRenameList().AllAlterTypeRenameItem() {
+			from, to := identifier(item.Column(0)), identifier(item.Column(1))
+			if _, exists := udt.Fields[from]; !exists {
+				l.errorf(item, "Field '%s' does not exist in type '%s'", from, udt.Name)
+				continue
+			}
+			udt.Fields[to] = udt.Fields[from]
+			delete(udt.Fields, from)
+			replaceString(udt.FieldOrder, from, to)
+		}
+	case op.AlterTypeAlterType() != nil:
+		alter := op.AlterTypeAlterType()
+		field := identifier(alter.Column())
+		if _, exists := udt.Fields[field]; !exists {
+			l.errorf(alter, "Field '%s' does not exist in type '%s'", field, udt.Name)
+			return
+		}
+		udt.Fields[field] = dataTypeString(alter.DataType())
+	}
+}
+
+func (l *cqlLoader) dropType(ctx parser.IDropTypeContext) {
+	ks := l.resolveKeyspace(ctx, ctx.Keyspace())
+	if ks == nil {
+		return
+	}
+	name := identifier(ctx.Type_())
+	if ks.GetType(name) == nil {
+		if ctx.IfExist() == nil {
+			l.errorf(ctx, "Type '%s' does not exist in keyspace '%s'", name, ks.Name)
+		}
+		return
+	}
+	delete(ks.Types, name)
+}
+
+// Indexes
+
+func (l *cqlLoader) createIndex(ctx parser.ICreateIndexContext) {
+	ks := l.resolveKeyspace(ctx, ctx.Keyspace())
+	if ks == nil {
+		return
+	}
+	tableName := identifier(ctx.Table())
+	t := ks.GetTable(tableName)
+	if t == nil {
+		l.errorf(ctx, "Table '%s' does not exist in keyspace '%s'", tableName, ks.Name)
+		return
+	}
+
+	column, target := indexTarget(ctx.IndexColumnSpec())
+	if t.GetColumn(column) == nil {
+		l.errorf(ctx.IndexColumnSpec(), "Column '%s' does not exist in table '%s'", column, t.Name)
+		return
+	}
+
+	name := tableName + "_" + column + "_idx"
+	if n := ctx.OBJECT_NAME(); n != nil {
+		name = normalizeIdentifier(n.GetText())
+	}
+	if findIndex(ks, name) != nil {
+		if ctx.IfNotExist() == nil {
+			l.errorf(ctx, "Index '%s' already exists in keyspace '%s'", name, ks.Name)
+		}
+		return
+	}
+
+	idx := t.AddIndex(name, column).WithKind("COMPOSITES")
+	idx.Options["target"] = target
+	if using := ctx.IndexUsing(); using != nil {
+		idx.WithKind("CUSTOM").WithClassName(unquoteString(using.StringLiteral().GetText()))
+		if opts := using.IndexOptions(); opts != nil {
+			for k, v := range optionHash(opts.OptionHash()) {
+				idx.Options[k] = v
+			}
+		}
+	} else if ctx.KwCustom() != nil {
+		idx.WithKind("CUSTOM")
+	}
+}
+
+// indexTarget returns the indexed column and the index target expression.
+func indexTarget(ctx parser.IIndexColumnSpecContext) (column, target string) {
+	switch {
+	case ctx.IndexKeysSpec() != nil:
+		column = normalizeIdentifier(ctx.IndexKeysSpec().OBJECT_NAME().GetText())
+		return column, "keys(" + column + ")"
+	case ctx.IndexEntriesSSpec() != nil:
+		column = normalizeIdentifier(ctx.IndexEntriesSSpec().OBJECT_NAME().GetText())
+		return column, "entries(" + column + ")"
+	case ctx.IndexFullSpec() != nil:
+		column = normalizeIdentifier(ctx.IndexFullSpec().OBJECT_NAME().GetText())
+		return column, "full(" + column + ")"
+	}
+	column = identifier(ctx.Column())
+	return column, column
+}
+
+func (l *cqlLoader) dropIndex(ctx parser.IDropIndexContext) {
+	ks := l.resolveKeyspace(ctx, ctx.Keyspace())
+	if ks == nil {
+		return
+	}
+	name := normalizeIdentifier(ctx.IndexName().GetText())
+	if strings.HasPrefix(name, "'") {
+		name = unquoteString(name)
+	}
+	idx := findIndex(ks, name)
+	if idx == nil {
+		if ctx.IfExist() == nil {
+			l.errorf(ctx, "Index '%s' does not exist in keyspace '%s'", name, ks.Name)
+		}
+		return
+	}
+	delete(ks.GetTable(idx.Table).Indexes, name)
+}
+
+// findIndex returns an index by name from any table in the keyspace.
+func findIndex(ks *Keyspace, name string) *Index {
+	for _, t := range ks.Tables {
+		if idx := t.GetIndex(name); idx != nil {
+			return idx
+		}
+	}
+	return nil
+}
+
+// Materialized views
+
+func (l *cqlLoader) createMaterializedView(ctx parser.ICreateMaterializedViewContext) {
+	ks := l.resolveKeyspace(ctx, ctx.Keyspace())
+	if ks == nil {
+		return
+	}
+	name := normalizeIdentifier(ctx.MaterializedView().GetText())
+	if ks.GetMaterializedView(name) != nil || ks.GetTable(name) != nil {
+		if ctx.IfNotExist() == nil {
+			l.errorf(ctx, "Materialized view '%s' already exists in keyspace '%s'", name, ks.Name)
+		}
+		return
+	}
+
+	from := ctx.FromSpec().FromSpecElement()
+	baseName := from.GetText()
+	if from.DOT() != nil {
+		prefix := from.OBJECT_NAME(0).GetText()
+		baseName = baseName[len(prefix)+1:]
+		if normalizeIdentifier(prefix) != ks.Name {
+			l.errorf(from, "Materialized view '%s' must be in the same keyspace as its base table", name)
+			return
+		}
+	}
+	baseName = normalizeIdentifier(baseName)
+	base := ks.GetTable(baseName)
+	if base == nil {
+		l.errorf(from, "Table '%s' does not exist in keyspace '%s'", baseName, ks.Name)
+		return
+	}
+
+	var columns []string
+	selectElements := ctx.SelectElements()
+	if selectElements.STAR() != nil {
+		columns = append(columns, base.ColumnOrder...)
+	}
+	for _, el := range selectElements.AllSelectElement() {
+		if el.ColumnRef() == nil {
+			l.errorf(el, "Only columns can be selected in a materialized view")
+			return
+		}
+		columns = append(columns, normalizeIdentifier(el.ColumnRef().GetText()))
+	}
+	partitionKey, clusteringKey := primaryKey(ctx.PrimaryKeyElement())
+	for _, col := range append(append([]string{}, partitionKey...), clusteringKey...) {
+		if !containsString(columns, col) {
+			columns = append(columns, col)
+		}
+	}
+	for _, col := range columns {
+		if base.GetColumn(col) == nil {
+			l.errorf(selectElements, "Column '%s' does not exist in table '%s'", col, base.Name)
+			return
+		}
+	}
+
+	mv := base.AddMaterializedView(name)
+	for _, col := range columns {
+		mv.AddColumn(col, base.GetColumn(col).Type)
+	}
+	mv.SetPartitionKey(partitionKey...)
+	if len(clusteringKey) > 0 {
+		mv.SetClusteringKey(clusteringKey...)
+	}
+	if where := ctx.MvWhereSpec(); where != nil {
+		clauses := where.AllMvWhereClause()
+		mv.WithWhereClause(sourceText(clauses[0], clauses[len(clauses)-1]))
+	}
+	if opts := ctx.MaterializedViewOptions(); opts != nil {
+		orders, _ := flattenTableOptions(opts.TableOptions())
+		if opts.ClusteringOrder() != nil {
+			orders = append(orders, opts.ClusteringOrder())
+		}
+		for _, order := range orders {
+			for col, dir := range clusteringOrder(order) {
+				if !containsString(mv.ClusteringKey, col) {
+					l.errorf(order, "Column '%s' is not a clustering column of materialized view '%s'", col, mv.Name)
+					continue
+				}
+				mv.ClusteringOrder[col] = dir
+			}
+		}
+	}
+}
+
+func (l *cqlLoader) alterMaterializedView(ctx parser.IAlterMaterializedViewContext) {
+	ks := l.resolveKeyspace(ctx, ctx.Keyspace())
+	if ks == nil {
+		return
+	}
+	name := normalizeIdentifier(ctx.MaterializedView().GetText())
+	if ks.GetMaterializedView(name) == nil {
+		l.errorf(ctx, "Materialized view '%s' does not exist in keyspace '%s'", name, ks.Name)
+	}
+}
+
+func (l *cqlLoader) dropMaterializedView(ctx parser.IDropMaterializedViewContext) {
+	ks := l.resolveKeyspace(ctx, ctx.Keyspace())
+	if ks == nil {
+		return
+	}
+	name := normalizeIdentifier(ctx.MaterializedView().GetText())
+	mv := ks.GetMaterializedView(name)
+	if mv == nil {
+		if ctx.IfExist() == nil {
+			l.errorf(ctx, "Materialized view '%s' does not exist in keyspace '%s'", name, ks.Name)
+		}
+		return
+	}
+	delete(ks.GetTable(mv.BaseTable).MaterializedViews, name)
+}
+
+// Functions and aggregates
+
+func (l *cqlLoader) createFunction(ctx parser.ICreateFunctionContext) {
+	ks := l.resolveKeyspace(ctx, ctx.Keyspace())
+	if ks == nil {
+		return
+	}
+	name := normalizeIdentifier(ctx.Function_().GetText())
+	if ks.GetFunction(name) != nil && ctx.OrReplace() == nil {
+		if ctx.IfNotExist() == nil {
+			l.errorf(ctx, "Function '%s' already exists in keyspace '%s'", name, ks.Name)
+		}
+		return
+	}
+
+	fn := ks.AddFunction(name)
+	if params := ctx.ParamList(); params != nil {
+		for _, p := range params.AllParam() {
+			fn.AddParameter(normalizeIdentifier(p.ParamName().GetText()), dataTypeString(p.DataType()))
+		}
+	}
+	fn.WithReturnType(dataTypeString(ctx.DataType())).
+		WithLanguage(strings.ToLower(ctx.Language().GetText())).
+		WithBody(codeBlockBody(ctx.CodeBlock().GetText()))
+	if ctx.ReturnMode().KwCalled() != nil {
+		fn.CalledOnNullInput()
+	} else {
+		fn.ReturnsNullOnNullInput()
+	}
+}
+
+func (l *cqlLoader) dropFunction(ctx parser.IDropFunctionContext) {
+	ks := l.resolveKeyspace(ctx, ctx.Keyspace())
+	if ks == nil {
+		return
+	}
+	name := normalizeIdentifier(ctx.Function_().GetText())
+	if ks.GetFunction(name) == nil {
+		if ctx.IfExist() == nil {
+			l.errorf(ctx, "Function '%s' does not exist in keyspace '%s'", name, ks.Name)
+		}
+		return
+	}
+	delete(ks.Functions, name)
+}
+
+func (l *cqlLoader) createAggregate(ctx parser.ICreateAggregateContext) {
+	ks := l.resolveKeyspace(ctx, ctx.Keyspace())
+	if ks == nil {
+		return
+	}
+	name := normalizeIdentifier(ctx.Aggregate().GetText())
+	if ks.GetAggregate(name) != nil && ctx.OrReplace() == nil {
+		if ctx.IfNotExist() == nil {
+			l.errorf(ctx, "Aggregate '%s' already exists in keyspace '%s'", name, ks.Name)
+		}
+		return
+	}
+
+	funcs := ctx.AllFunction_()
+	agg := &Aggregate{
+		Name:       name,
+		Keyspace:   ks.Name,
+		StateFunc:  normalizeIdentifier(funcs[0].GetText()),
+		StateType:  dataTypeString(ctx.DataType(1)),
+		Parameters: []string{dataTypeString(ctx.DataType(0))},
+	}
+	if ctx.KwFinalfunc() != nil {
+		agg.FinalFunc = normalizeIdentifier(funcs[len(funcs)-1].GetText())
+	}
+	if init := ctx.InitCondDefinition(); init != nil {
+		agg.InitCond = sourceText(init, init)
+	}
+	agg.ReturnType = agg.StateType
+	if fn := ks.GetFunction(agg.FinalFunc); fn != nil {
+		agg.ReturnType = fn.ReturnType
+	}
+	if ks.Aggregates == nil {
+		ks.Aggregates = make(map[string]*Aggregate)
+	}
+	ks.Aggregates[name] = agg
+}
+
+func (l *cqlLoader) dropAggregate(ctx parser.IDropAggregateContext) {
+	ks := l.resolveKeyspace(ctx, ctx.Keyspace())
+	if ks == nil {
+		return
+	}
+	name := normalizeIdentifier(ctx.Aggregate().GetText())
+	if ks.GetAggregate(name) == nil {
+		if ctx.IfExist() == nil {
+			l.errorf(ctx, "Aggregate '%s' does not exist in keyspace '%s'", name, ks.Name)
+		}
+		return
+	}
+	delete(ks.Aggregates, name)
+}
+
+// Helpers
+
+// identifier returns the normalized name of an identifier rule.
+func identifier(ctx antlr.ParseTree) string {
+	if ctx == nil {
+		return ""
+	}
+	return normalizeIdentifier(ctx.GetText())
+}
+
+// normalizeIdentifier lowercases unquoted identifiers and unquotes quoted ones.
+func normalizeIdentifier(name string) string {
+	if len(name) >= 2 && strings.HasPrefix(name, `"`) && strings.HasSuffix(name, `"`) {
+		return strings.ReplaceAll(name[1:len(name)-1], `""`, `"`)
+	}
+	return strings.ToLower(name)
+}
+
+// dataTypeString renders a data type in canonical form (e.g., "map<text, frozen<address>>").
+func dataTypeString(ctx parser.IDataTypeContext) string {
+	if ctx == nil {
+		return ""
+	}
+	name := normalizeIdentifier(ctx.DataTypeName().GetText())
+	def := ctx.DataTypeDefinition()
+	if def == nil {
+		return name
+	}
+	args := make([]string, 0, len(def.AllDataTypeArg()))
+	for _, arg := range def.AllDataTypeArg() {
+		if arg.DataType() != nil {
+			args = append(args, dataTypeString(arg.DataType()))
+		} else {
+			args = append(args, arg.GetText())
+		}
+	}
+	return name + "<" + strings.Join(args, ", ") + ">"
+}
+
+// unquoteString strips the quotes of a string literal and unescapes doubled quotes.
+func unquoteString(s string) string {
+	if len(s) >= 2 && strings.HasPrefix(s, "'") && strings.HasSuffix(s, "'") {
+		return strings.ReplaceAll(s[1:len(s)-1], "''", "'")
+	}
+	return s
+}
+
+// codeBlockBody strips the $$ or quote delimiters of a function body.
+func codeBlockBody(s string) string {
+	if len(s) >= 4 && strings.HasPrefix(s, "$$") && strings.HasSuffix(s, "$$") {
+		return s[2 : len(s)-2]
+	}
+	return unquoteString(s)
+}
+
+// sourceText returns the original source text spanning from start to stop, including whitespace.
+func sourceText(start, stop antlr.ParserRuleContext) string {
+	input := start.GetStart().GetInputStream()
+	return input.GetText(start.GetStart().GetStart(), stop.GetStop().GetStop())
+}
+
+func containsString(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
+
+func removeString(list []string, s string) []string {
+	out := list[:0]
+	for _, item := range list {
+		if item != s {
+			out = append(out, item)
+		}
+	}
+	return out
+}
+
+func replaceString(list []string, from, to string) {
+	for i, item := range list {
+		if item == from {
+			list[i] = to
+		}
+	}
+}
@@ -0,0 +1,472 @@
+package schema
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/tentacle-scylla/scql/pkg/format"
+	"github.com/tentacle-scylla/scql/pkg/parse"
+)
+
+// ToCQL renders the schema as DESCRIBE-style CQL DDL.
+// Keyspaces, tables and other objects are sorted by name; within a keyspace,
+// user-defined types come first (dependencies before dependents), then functions,
+// aggregates, and each table followed by its indexes and materialized views.
+func (s *Schema) ToCQL() string {
+	if s == nil {
+		return ""
+	}
+	var stmts []string
+	for _, ksName := range sortedKeys(s.Keyspaces) {
+		ks := s.Keyspaces[ksName]
+		stmts = append(stmts, ks.DDL())
+		for _, udt := range ks.typesInDependencyOrder() {
+			stmts = append(stmts, udt.DDL())
+		}
+		for _, name := range sortedKeys(ks.Functions) {
+			stmts = append(stmts, ks.Functions[name].DDL())
+		}
+		for _, name := range sortedKeys(ks.Aggregates) {
+			stmts = append(stmts, ks.Aggregates[name].DDL())
+		}
+		for _, name := range sortedKeys(ks.Tables) {
+			t := ks.Tables[name]
+			stmts = append(stmts, t.DDL())
+			for _, idxName := range sortedKeys(t.Indexes) {
+				stmts = append(stmts, t.indexDDL(t.Indexes[idxName]))
+			}
+			for _, mvName := range sortedKeys(t.MaterializedViews) {
+				stmts = append(stmts, t.MaterializedViews[mvName].DDL())
+			}
+		}
+	}
+	if len(stmts) == 0 {
+		return ""
+	}
+	return strings.Join(stmts, "\n\n") + "\n"
+}
+
+// DDL returns the CREATE KEYSPACE statement for the keyspace.
+func (ks *Keyspace) DDL() string {
+	return formatDDL("CREATE KEYSPACE " + format.QuoteIdentifier(ks.Name) + " WITH " + replicationDDL(ks) + ";")
+}
+
+// replicationDDL renders the replication and durable_writes options of a keyspace.
+func replicationDDL(ks *Keyspace) string {
+	class := ks.ReplicationClass
+	if class == "" {
+		class = "SimpleStrategy"
+	}
+	replication := []string{format.QuoteString("class") + ": " + format.QuoteString(class)}
+	for _, key := range sortedKeys(ks.ReplicationFactor) {
+		replication = append(replication, format.QuoteString(key)+": "+format.QuoteString(strconv.Itoa(ks.ReplicationFactor[key])))
+	}
+	return "replication = {" + strings.Join(replication, ", ") + "} AND durable_writes = " + strconv.FormatBool(ks.DurableWrites)
+}
+
+// DDL returns the CREATE TABLE statement for the table.
+// Indexes and materialized views are not included; see Schema.ToCQL.
+func (t *Table) DDL() string {
+	var defs []string
+	for _, col := range orderedColumns(t.Columns, t.ColumnOrder) {
+		def := format.QuoteIdentifier(col.Name) + " " + cqlType(col.Type)
+		if col.IsStatic {
+			def += " STATIC"
+		}
+		defs = append(defs, def)
+	}
+	defs = append(defs, primaryKeyDDL(t.PartitionKey, t.ClusteringKey))
+
+	var options []string
+	if order := clusteringOrderDDL(t.ClusteringKey, t.ClusteringOrder); order != "" {
+		options = append(options, order)
+	}
+	values := tableOptions(t)
+	for _, name := range sortedKeys(values) {
+		options = append(options, name+" = "+values[name])
+	}
+
+	var sb strings.Builder
+	sb.WriteString("CREATE TABLE ")
+	sb.WriteString(qualifiedName(t.Keyspace, t.Name))
+	sb.WriteString(" (")
+	sb.WriteString(strings.Join(defs, ", "))
+	sb.WriteString(")")
+	if len(options) > 0 {
+		sb.WriteString(" WITH ")
+		sb.WriteString(strings.Join(options, " AND "))
+	}
+	sb.WriteString(";")
+	return formatDDL(sb.String())
+}
+
+// tableOptions returns the rendered values of the table options that are set, keyed by option name.
+func tableOptions(t *Table) map[string]string {
+	options := make(map[string]string)
+	if t.BloomFilterFPChance != 0 {
+		options["bloom_filter_fp_chance"] = strconv.FormatFloat(t.BloomFilterFPChance, 'f', -1, 64)
+	}
+	if len(t.Caching) > 0 {
+		options["caching"] = optionMapDDL(t.Caching)
+	}
+	if t.Comment != "" {
+		options["comment"] = format.QuoteString(t.Comment)
+	}
+	if len(t.Compaction) > 0 {
+		options["compaction"] = optionMapDDL(t.Compaction)
+	}
+	if len(t.Compression) > 0 {
+		options["compression"] = optionMapDDL(t.Compression)
+	}
+	if t.GCGraceSeconds != 0 {
+		options["gc_grace_seconds"] = strconv.Itoa(t.GCGraceSeconds)
+	}
+	return options
+}
+
+// indexDDL returns the CREATE INDEX statement for an index on the table.
+func (t *Table) indexDDL(idx *Index) string {
+	target := format.QuoteIdentifier(idx.TargetColumn)
+	if expr := idx.Options["target"]; expr != "" {
+		if open := strings.Index(expr, "("); open > 0 && strings.HasSuffix(expr, ")") {
+			target = strings.ToUpper(expr[:open]) + "(" + format.QuoteIdentifier(expr[open+1:len(expr)-1]) + ")"
+		}
+	}
+
+	var sb strings.Builder
+	sb.WriteString("CREATE ")
+	if idx.Kind == "CUSTOM" {
+		sb.WriteString("CUSTOM ")
+	}
+	sb.WriteString("INDEX ")
+	sb.WriteString(format.QuoteIdentifier(idx.Name))
+	sb.WriteString(" ON ")
+	sb.WriteString(qualifiedName(t.Keyspace, t.Name))
+	sb.WriteString(" (")
+	sb.WriteString(target)
+	sb.WriteString(")")
+	if idx.ClassName != "" {
+		sb.WriteString(" USING ")
+		sb.WriteString(format.QuoteString(idx.ClassName))
+		options := make(map[string]string)
+		for k, v := range idx.Options {
+			if k != "target" && k != "class_name" {
+				options[k] = v
+			}
+		}
+		if len(options) > 0 {
+			sb.WriteString(" WITH OPTIONS = ")
+			sb.WriteString(optionMapDDL(options))
+		}
+	}
+	sb.WriteString(";")
+	return formatDDL(sb.String())
+}
+
+// DDL returns the CREATE TYPE statement for the user-defined type.
+func (udt *UserType) DDL() string {
+	var fields []string
+	for _, name := range orderedNames(udt.Fields, udt.FieldOrder) {
+		fields = append(fields, format.QuoteIdentifier(name)+" "+cqlType(udt.Fields[name]))
+	}
+
+	var sb strings.Builder
+	sb.WriteString("CREATE TYPE ")
+	sb.WriteString(qualifiedName(udt.Keyspace, udt.Name))
+	sb.WriteString(" (")
+	sb.WriteString(strings.Join(fields, ", "))
+	sb.WriteString(");")
+	return formatDDL(sb.String())
+}
+
+// DDL returns the CREATE MATERIALIZED VIEW statement for the view.
+// If the view has no stored WHERE clause, IS NOT NULL restrictions are generated for its primary key.
+func (mv *MaterializedView) DDL() string {
+	selectList := "*"
+	if len(mv.ColumnOrder) > 0 {
+		var cols []string
+		for _, col := range orderedColumns(mv.Columns, mv.ColumnOrder) {
+			cols = append(cols, format.QuoteIdentifier(col.Name))
+		}
+		selectList = strings.Join(cols, ", ")
+	}
+
+	where := mv.WhereClause
+	if where == "" {
+		var restrictions []string
+		for _, col := range append(append([]string{}, mv.PartitionKey...), mv.ClusteringKey...) {
+			restrictions = append(restrictions, format.QuoteIdentifier(col)+" IS NOT NULL")
+		}
+		where = strings.Join(restrictions, " AND ")
+	}
+
+	var sb strings.Builder
+	sb.WriteString("CREATE MATERIALIZED VIEW ")
+	sb.WriteString(qualifiedName(mv.Keyspace, mv.Name))
+	sb.WriteString(" AS SELECT ")
+	sb.WriteString(selectList)
+	sb.WriteString(" FROM ")
+	sb.WriteString(qualifiedName(mv.Keyspace, mv.BaseTable))
+	if where != "" {
+		sb.WriteString(" WHERE ")
+		sb.WriteString(where)
+	}
+	sb.WriteString(" ")
+	sb.WriteString(primaryKeyDDL(mv.PartitionKey, mv.ClusteringKey))
+	if order := clusteringOrderDDL(mv.ClusteringKey, mv.ClusteringOrder); order != "" {
+		sb.WriteString(" WITH ")
+		sb.WriteString(order)
+	}
+	sb.WriteString(";")
+	return formatDDL(sb.String())
+}
+
+// DDL returns the CREATE FUNCTION statement for the function.
+func (fn *Function) DDL() string {
+	var params []string
+	for _, p := range fn.Parameters {
+		params = append(params, format.QuoteIdentifier(p.Name)+" "+cqlType(p.Type))
+	}
+	onNull := "RETURNS NULL ON NULL INPUT"
+	if fn.CalledOnNull {
+		onNull = "CALLED ON NULL INPUT"
+	}
+	body := "$$" + fn.Body + "$$"
+	if strings.Contains(fn.Body, "$$") {
+		body = format.QuoteString(fn.Body)
+	}
+
+	var sb strings.Builder
+	sb.WriteString("CREATE FUNCTION ")
+	sb.WriteString(qualifiedName(fn.Keyspace, fn.Name))
+	sb.WriteString("(")
+	sb.WriteString(strings.Join(params, ", "))
+	sb.WriteString(") ")
+	sb.WriteString(onNull)
+	sb.WriteString(" RETURNS ")
+	sb.WriteString(cqlType(fn.ReturnType))
+	sb.WriteString(" LANGUAGE ")
+	sb.WriteString(fn.Language)
+	sb.WriteString(" AS ")
+	sb.WriteString(body)
+	sb.WriteString(";")
+	return formatDDL(sb.String())
+}
+
+// DDL returns the CREATE AGGREGATE statement for the aggregate.
+func (agg *Aggregate) DDL() string {
+	var params []string
+	for _, p := range agg.Parameters {
+		params = append(params, cqlType(p))
+	}
+
+	var sb strings.Builder
+	sb.WriteString("CREATE AGGREGATE ")
+	sb.WriteString(qualifiedName(agg.Keyspace, agg.Name))
+	sb.WriteString("(")
+	sb.WriteString(strings.Join(params, ", "))
+	sb.WriteString(") SFUNC ")
+	sb.WriteString(format.QuoteIdentifier(agg.StateFunc))
+	sb.WriteString(" STYPE ")
+	sb.WriteString(cqlType(agg.StateType))
+	if agg.FinalFunc != "" {
+		sb.WriteString(" FINALFUNC ")
+		sb.WriteString(format.QuoteIdentifier(agg.FinalFunc))
+	}
+	if agg.InitCond != "" {
+		sb.WriteString(" INITCOND ")
+		sb.WriteString(agg.InitCond)
+	}
+	sb.WriteString(";")
+	return formatDDL(sb.String())
+}
+
+// typesInDependencyOrder returns the keyspace's UDTs sorted by name,
+// with every type placed after the types it references.
+func (ks *Keyspace) typesInDependencyOrder() []*UserType {
+	return ks.typesReferencedBy(sortedKeys(ks.Types))
+}
+
+// ReferencedTypes returns the keyspace's UDTs that a type string like
+// "frozen<address>" references, directly or through the fields of other
+// UDTs, with every type placed after the types it references.
+func (ks *Keyspace) ReferencedTypes(cqlType string) []*UserType {
+	if ks == nil {
+		return nil
+	}
+	return ks.typesReferencedBy(typeIdentifiers(cqlType))
+}
+
+// typesReferencedBy returns the UDTs of names and the UDTs they reference,
+// with every type placed after the types it references.
+func (ks *Keyspace) typesReferencedBy(names []string) []*UserType {
+	var ordered []*UserType
+	visited := make(map[string]bool)
+	var visit func(name string)
+	visit = func(name string) {
+		udt := ks.Types[name]
+		if udt == nil || visited[name] {
+			return
+		}
+		visited[name] = true
+		for _, field := range orderedNames(udt.Fields, udt.FieldOrder) {
+			for _, ref := range typeIdentifiers(udt.Fields[field]) {
+				visit(ref)
+			}
+		}
+		ordered = append(ordered, udt)
+	}
+	for _, name := range names {
+		visit(name)
+	}
+	return ordered
+}
+
+// builtinTypeNames are the type names the grammar accepts as data type keywords.
+var builtinTypeNames = map[string]bool{
+	"ascii": true, "bigint": true, "blob": true, "boolean": true, "counter": true,
+	"date": true, "decimal": true, "double": true, "duration": true, "float": true,
+	"frozen": true, "inet": true, "int": true, "list": true, "map": true,
+	"set": true, "smallint": true, "text": true, "time": true, "timestamp": true,
+	"timeuuid": true, "tinyint": true, "tuple": true, "uuid": true, "varchar": true,
+	"varint": true, "vector": true,
+}
+
+// cqlType renders a type string, quoting user-defined type names where needed.
+func cqlType(typ string) string {
+	var sb strings.Builder
+	word := strings.Builder{}
+	flush := func() {
+		if word.Len() == 0 {
+			return
+		}
+		w := word.String()
+		word.Reset()
+		if builtinTypeNames[strings.ToLower(w)] || (w[0] >= '0' && w[0] <= '9') {
+			sb.WriteString(strings.ToLower(w))
+		} else {
+			sb.WriteString(format.QuoteIdentifier(w))
+		}
+	}
+	for _, ch := range typ {
+		switch ch {
+		case '<', '>', ',', ' ':
+			flush()
+			sb.WriteRune(ch)
+		default:
+			word.WriteRune(ch)
+		}
+	}
+	flush()
+	return sb.String()
+}
+
+// typeIdentifiers returns the names referenced by a type string that are not builtin types.
+func typeIdentifiers(typ string) []string {
+	var names []string
+	for _, w := range strings.FieldsFunc(typ, func(r rune) bool {
+		return r == '<' || r == '>' || r == ',' || r == ' '
+	}) {
+		if !builtinTypeNames[strings.ToLower(w)] && !(w[0] >= '0' && w[0] <= '9') {
+			names = append(names, w)
+		}
+	}
+	return names
+}
+
+// primaryKeyDDL renders a PRIMARY KEY clause.
+func primaryKeyDDL(partitionKey, clusteringKey []string) string {
+	var parts []string
+	pk := quoteAll(partitionKey)
+	if len(pk) == 1 {
+		parts = append(parts, pk[0])
+	} else {
+		parts = append(parts, "("+strings.Join(pk, ", ")+")")
+	}
+	parts = append(parts, quoteAll(clusteringKey)...)
+	return "PRIMARY KEY (" + strings.Join(parts, ", ") + ")"
+}
+
+// clusteringOrderDDL renders a CLUSTERING ORDER BY clause, or "" without clustering columns.
+func clusteringOrderDDL(clusteringKey []string, orders map[string]Order) string {
+	if len(clusteringKey) == 0 {
+		return ""
+	}
+	var parts []string
+	for _, col := range clusteringKey {
+		order := orders[col]
+		if order == "" {
+			order = OrderAsc
+		}
+		parts = append(parts, format.QuoteIdentifier(col)+" "+string(order))
+	}
+	return "CLUSTERING ORDER BY (" + strings.Join(parts, ", ") + ")"
+}
+
+// optionMapDDL renders a map option such as compaction = {...}.
+func optionMapDDL(m map[string]string) string {
+	var items []string
+	for _, key := range sortedKeys(m) {
+		items = append(items, format.QuoteString(key)+": "+format.QuoteString(m[key]))
+	}
+	return "{" + strings.Join(items, ", ") + "}"
+}
+
+// formatDDL pretty-prints a generated statement.
+func formatDDL(stmt string) string {
+	return format.Format(parse.Parse(stmt), format.DefaultOptions())
+}
+
+func qualifiedName(keyspace, name string) string {
+	if keyspace == "" {
+		return format.QuoteIdentifier(name)
+	}
+	return format.QuoteIdentifier(keyspace) + "." + format.QuoteIdentifier(name)
+}
+
+func quoteAll(names []string) []string {
+	quoted := make([]string, len(names))
+	for i, name := range names {
+		quoted[i] = format.QuoteIdentifier(name)
+	}
+	return quoted
+}
+
+// orderedColumns returns columns in definition order, followed by any
+// columns missing from the order sorted by name.
+func orderedColumns(columns map[string]*Column, order []string) []*Column {
+	var cols []*Column
+	for _, name := range orderedNames(columns, order) {
+		cols = append(cols, columns[name])
+	}
+	return cols
+}
+
+// orderedNames returns the keys of m in the given order, followed by any
+// keys missing from the order sorted by name.
+func orderedNames[V any](m map[string]V, order []string) []string {
+	seen := make(map[string]bool, len(m))
+	names := make([]string, 0, len(m))
+	for _, name := range order {
+		if _, ok := m[name]; ok && !seen[name] {
+			seen[name] = true
+			names = append(names, name)
+		}
+	}
+	for _, name := range sortedKeys(m) {
+		if !seen[name] {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,572 @@
+package schema
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/tentacle-scylla/scql/pkg/format"
+)
+
+// ChangeType identifies how a schema object differs between two schemas.
+type ChangeType string
+
+const (
+	ChangeAdded   ChangeType = "added"
+	ChangeDropped ChangeType = "dropped"
+	ChangeAltered ChangeType = "altered"
+)
+
+// ObjectType identifies the kind of schema object a change applies to.
+type ObjectType string
+
+const (
+	ObjectKeyspace         ObjectType = "keyspace"
+	ObjectTable            ObjectType = "table"
+	ObjectColumn           ObjectType = "column"
+	ObjectUserType         ObjectType = "type"
+	ObjectIndex            ObjectType = "index"
+	ObjectMaterializedView ObjectType = "materialized_view"
+	ObjectFunction         ObjectType = "function"
+	ObjectAggregate        ObjectType = "aggregate"
+)
+
+// Change describes a single difference between two schemas.
+type Change struct {
+	Type        ChangeType
+	Object      ObjectType
+	Keyspace    string
+	Table       string // Owning table for columns, indexes and materialized views
+	Name        string
+	Description string
+	Recreate    bool     // true if the change needs DROP and CREATE instead of ALTER
+	Statements  []string // CQL statements that apply the change; commented out if they would lose data or fail
+}
+
+// defaultTableOptions are the values table options take when they are removed.
+var defaultTableOptions = map[string]string{
+	"bloom_filter_fp_chance": "0.01",
+	"caching":                "{'keys': 'ALL', 'rows_per_partition': 'ALL'}",
+	"comment":                "''",
+	"compaction":             "{'class': 'SizeTieredCompactionStrategy'}",
+	"compression":            "{'sstable_compression': 'org.apache.cassandra.io.compress.LZ4Compressor'}",
+	"gc_grace_seconds":       "864000",
+}
+
+// Diff compares two schemas and returns the changes that turn old into new.
+// Changes are ordered so that their statements can be executed in sequence:
+// views and indexes are dropped before their tables, types are created before
+// the tables that use them, and tables before their indexes and views.
+func Diff(old, new *Schema) []Change {
+	if old == nil {
+		old = NewSchema()
+	}
+	if new == nil {
+		new = NewSchema()
+	}
+
+	var changes []Change
+	for _, name := range unionKeys(old.Keyspaces, new.Keyspaces) {
+		oldKs, newKs := old.Keyspaces[name], new.Keyspaces[name]
+		if newKs != nil {
+			changes = append(changes, diffKeyspace(oldKs, newKs)...)
+		}
+	}
+	for _, name := range unionKeys(old.Keyspaces, new.Keyspaces) {
+		if new.Keyspaces[name] == nil {
+			changes = append(changes, Change{
+				Type:        ChangeDropped,
+				Object:      ObjectKeyspace,
+				Keyspace:    name,
+				Name:        name,
+				Description: fmt.Sprintf("Keyspace '%s' dropped", name),
+				Statements:  []string{"DROP KEYSPACE " + format.QuoteIdentifier(name) + ";"},
+			})
+		}
+	}
+	return changes
+}
+
+// MigrationCQL joins the statements of the changes into a single script.
+func MigrationCQL(changes []Change) string {
+	var stmts []string
+	for _, c := range changes {
+		stmts = append(stmts, c.Statements...)
+	}
+	if len(stmts) == 0 {
+		return ""
+	}
+	return strings.Join(stmts, "\n\n") + "\n"
+}
+
+// keyspaceDiff collects the changes of one keyspace in execution stages.
+type keyspaceDiff struct {
+	old, new *Keyspace
+
+	keyspace     []Change
+	dropViews    []Change
+	dropIndexes  []Change
+	dropTables   []Change
+	types        []Change
+	functions    []Change
+	tables       []Change
+	indexes      []Change
+	views        []Change
+	dropTypes    []Change
+	dropRoutines []Change
+}
+
+func diffKeyspace(old, new *Keyspace) []Change {
+	d := &keyspaceDiff{old: old, new: new}
+	if old == nil {
+		d.old = &Keyspace{Name: new.Name}
+		d.keyspace = append(d.keyspace, Change{
+			Type:        ChangeAdded,
+			Object:      ObjectKeyspace,
+			Keyspace:    new.Name,
+			Name:        new.Name,
+			Description: fmt.Sprintf("Keyspace '%s' added", new.Name),
+			Statements:  []string{new.DDL()},
+		})
+	} else if old.ReplicationClass != new.ReplicationClass || !reflect.DeepEqual(nonNilInts(old.ReplicationFactor), nonNilInts(new.ReplicationFactor)) || old.DurableWrites != new.DurableWrites {
+		d.keyspace = append(d.keyspace, Change{
+			Type:        ChangeAltered,
+			Object:      ObjectKeyspace,
+			Keyspace:    new.Name,
+			Name:        new.Name,
+			Description: fmt.Sprintf("Keyspace '%s' replication or durable_writes changed", new.Name),
+			Statements:  []string{formatDDL("ALTER KEYSPACE " + format.QuoteIdentifier(new.Name) + " WITH " + replicationDDL(new) + ";")},
+		})
+	}
+
+	d.diffTypes()
+	d.diffFunctions()
+	d.diffAggregates()
+	for _, name := range unionKeys(d.old.Tables, d.new.Tables) {
+		d.diffTable(d.old.Tables[name], d.new.Tables[name])
+	}
+
+	var changes []Change
+	for _, stage := range [][]Change{
+		d.keyspace, d.dropViews, d.dropIndexes, d.dropTables, d.types, d.functions,
+		d.tables, d.indexes, d.views, d.dropTypes, d.dropRoutines,
+	} {
+		changes = append(changes, stage...)
+	}
+	return changes
+}
+
+// Types
+
+func (d *keyspaceDiff) diffTypes() {
+	for _, udt := range d.new.typesInDependencyOrder() {
+		old := d.old.GetType(udt.Name)
+		switch {
+		case old == nil:
+			d.types = append(d.types, d.change(ChangeAdded, ObjectUserType, "", udt.Name,
+				fmt.Sprintf("Type '%s' added", udt.Name), udt.DDL()))
+		case !reflect.DeepEqual(nonNilStrings(old.Fields), nonNilStrings(udt.Fields)):
+			d.types = append(d.types, diffTypeFields(d.new.Name, old, udt, d.typeUsers(udt.Name)))
+		}
+	}
+	for _, name := range sortedKeys(d.old.Types) {
+		if d.new.GetType(name) == nil {
+			d.dropTypes = append(d.dropTypes, d.change(ChangeDropped, ObjectUserType, "", name,
+				fmt.Sprintf("Type '%s' dropped", name),
+				"DROP TYPE "+qualifiedName(d.new.Name, name)+";"))
+		}
+	}
+}
+
+// diffTypeFields describes a changed UDT. New fields can be added with ALTER TYPE;
+// removed or retyped fields require dropping and recreating the type, which
+// cannot be done while users (tables or other types) reference it.
+func diffTypeFields(keyspace string, old, new *UserType, users []string) Change {
+	c := Change{
+		Type:     ChangeAltered,
+		Object:   ObjectUserType,
+		Keyspace: keyspace,
+		Name:     new.Name,
+	}
+	var added, changed []string
+	for _, field := range orderedNames(new.Fields, new.FieldOrder) {
+		oldType, exists := old.Fields[field]
+		if !exists {
+			added = append(added, field)
+		} else if oldType != new.Fields[field] {
+			changed = append(changed, field)
+		}
+	}
+	var removed []string
+	for _, field := range orderedNames(old.Fields, old.FieldOrder) {
+		if _, exists := new.Fields[field]; !exists {
+			removed = append(removed, field)
+		}
+	}
+
+	if len(changed) > 0 || len(removed) > 0 {
+		c.Recreate = true
+		c.Description = fmt.Sprintf("Type '%s' fields changed (%s); the type must be recreated",
+			new.Name, strings.Join(append(changed, removed...), ", "))
+		c.Statements = []string{"DROP TYPE " + qualifiedName(keyspace, new.Name) + ";", new.DDL()}
+		if len(users) > 0 {
+			c.Description = fmt.Sprintf("Type '%s' fields changed (%s) but it is still used by %s; the type must be recreated manually",
+				new.Name, strings.Join(append(changed, removed...), ", "), strings.Join(users, ", "))
+			c.Statements = commentedOut(c.Statements...)
+		}
+		return c
+	}
+	c.Description = fmt.Sprintf("Type '%s' fields added (%s)", new.Name, strings.Join(added, ", "))
+	for _, field := range added {
+		c.Statements = append(c.Statements, "ALTER TYPE "+qualifiedName(keyspace, new.Name)+
+			" ADD "+format.QuoteIdentifier(field)+" "+cqlType(new.Fields[field])+";")
+	}
+	return c
+}
+
+// typeUsers returns the tables and types of the old keyspace that reference
+// the type and are kept by the migration, as "table name" or "type name".
+func (d *keyspaceDiff) typeUsers(name string) []string {
+	uses := func(cqlType string) bool {
+		for _, ref := range d.old.ReferencedTypes(cqlType) {
+			if ref.Name == name {
+				return true
+			}
+		}
+		return false
+	}
+
+	var users []string
+	for _, table := range sortedKeys(d.old.Tables) {
+		if d.new.GetTable(table) == nil {
+			continue
+		}
+		for _, col := range orderedColumns(d.old.Tables[table].Columns, d.old.Tables[table].ColumnOrder) {
+			if uses(col.Type) {
+				users = append(users, "table '"+table+"'")
+				break
+			}
+		}
+	}
+	for _, other := range sortedKeys(d.old.Types) {
+		if other == name || d.new.GetType(other) == nil {
+			continue
+		}
+		for _, field := range d.old.Types[other].Fields {
+			if uses(field) {
+				users = append(users, "type '"+other+"'")
+				break
+			}
+		}
+	}
+	return users
+}
+
+// Functions and aggregates
+
+func (d *keyspaceDiff) diffFunctions() {
+	for _, name := range sortedKeys(d.new.Functions) {
+		fn, old := d.new.Functions[name], d.old.GetFunction(name)
+		switch {
+		case old == nil:
+			d.functions = append(d.functions, d.change(ChangeAdded, ObjectFunction, "", name,
+				fmt.Sprintf("Function '%s' added", name), fn.DDL()))
+		case old.DDL() != fn.DDL():
+			d.functions = append(d.functions, d.change(ChangeAltered, ObjectFunction, "", name,
+				fmt.Sprintf("Function '%s' changed", name), orReplace(fn.DDL())))
+		}
+	}
+	for _, name := range sortedKeys(d.old.Functions) {
+		if d.new.GetFunction(name) == nil {
+			d.dropRoutines = append(d.dropRoutines, d.change(ChangeDropped, ObjectFunction, "", name,
+				fmt.Sprintf("Function '%s' dropped", name),
+				"DROP FUNCTION "+qualifiedName(d.new.Name, name)+";"))
+		}
+	}
+}
+
+func (d *keyspaceDiff) diffAggregates() {
+	for _, name := range sortedKeys(d.new.Aggregates) {
+		agg, old := d.new.Aggregates[name], d.old.Aggregates[name]
+		switch {
+		case old == nil:
+			d.functions = append(d.functions, d.change(ChangeAdded, ObjectAggregate, "", name,
+				fmt.Sprintf("Aggregate '%s' added", name), agg.DDL()))
+		case old.DDL() != agg.DDL():
+			d.functions = append(d.functions, d.change(ChangeAltered, ObjectAggregate, "", name,
+				fmt.Sprintf("Aggregate '%s' changed", name), orReplace(agg.DDL())))
+		}
+	}
+	for _, name := range sortedKeys(d.old.Aggregates) {
+		if d.new.Aggregates[name] == nil {
+			// Aggregates must be dropped before the functions they use
+			d.dropRoutines = append([]Change{d.change(ChangeDropped, ObjectAggregate, "", name,
+				fmt.Sprintf("Aggregate '%s' dropped", name),
+				"DROP AGGREGATE "+qualifiedName(d.new.Name, name)+";")}, d.dropRoutines...)
+		}
+	}
+}
+
+// orReplace turns a CREATE FUNCTION/AGGREGATE statement into CREATE OR REPLACE.
+func orReplace(stmt string) string {
+	return strings.Replace(stmt, "CREATE ", "CREATE OR REPLACE ", 1)
+}
+
+// Tables
+
+func (d *keyspaceDiff) diffTable(old, new *Table) {
+	switch {
+	case new == nil:
+		d.dropDependents(old)
+		d.dropTables = append(d.dropTables, d.change(ChangeDropped, ObjectTable, old.Name, old.Name,
+			fmt.Sprintf("Table '%s' dropped", old.Name), dropTableDDL(old)))
+		return
+	case old == nil:
+		d.tables = append(d.tables, d.change(ChangeAdded, ObjectTable, new.Name, new.Name,
+			fmt.Sprintf("Table '%s' added", new.Name), new.DDL()))
+		d.diffIndexes(&Table{Name: new.Name, Keyspace: new.Keyspace}, new)
+		d.diffViews(&Table{Name: new.Name, Keyspace: new.Keyspace}, new)
+		return
+	}
+
+	if reason := primaryKeyChange(old, new); reason != "" {
+		// The table and everything built on it has to be recreated
+		c := d.change(ChangeAltered, ObjectTable, new.Name, new.Name,
+			fmt.Sprintf("Table '%s' %s; the table must be recreated", new.Name, reason))
+		c.Recreate = true
+		for _, name := range sortedKeys(old.MaterializedViews) {
+			c.Statements = append(c.Statements, dropViewDDL(old.MaterializedViews[name]))
+		}
+		c.Statements = append(c.Statements, dropTableDDL(old), new.DDL())
+		for _, name := range sortedKeys(new.Indexes) {
+			c.Statements = append(c.Statements, new.indexDDL(new.Indexes[name]))
+		}
+		for _, name := range sortedKeys(new.MaterializedViews) {
+			c.Statements = append(c.Statements, new.MaterializedViews[name].DDL())
+		}
+		d.tables = append(d.tables, c)
+		return
+	}
+
+	d.diffColumns(old, new)
+	d.diffTableOptions(old, new)
+	d.diffIndexes(old, new)
+	d.diffViews(old, new)
+}
+
+// primaryKeyChange describes how the primary key of a table changed, or returns "".
+func primaryKeyChange(old, new *Table) string {
+	if !reflect.DeepEqual(nonNilSlice(old.PartitionKey), nonNilSlice(new.PartitionKey)) {
+		return "partition key changed"
+	}
+	if !reflect.DeepEqual(nonNilSlice(old.ClusteringKey), nonNilSlice(new.ClusteringKey)) {
+		return "clustering key changed"
+	}
+	for _, col := range new.ClusteringKey {
+		if orderOf(old.ClusteringOrder, col) != orderOf(new.ClusteringOrder, col) {
+			return "clustering order changed"
+		}
+	}
+	for _, col := range new.PrimaryKeyColumns() {
+		if oldCol := old.GetColumn(col.Name); oldCol == nil || oldCol.Type != col.Type {
+			return fmt.Sprintf("primary key column '%s' type changed", col.Name)
+		}
+	}
+	return ""
+}
+
+func (d *keyspaceDiff) diffColumns(old, new *Table) {
+	table := qualifiedName(new.Keyspace, new.Name)
+	for _, col := range orderedColumns(new.Columns, new.ColumnOrder) {
+		oldCol := old.GetColumn(col.Name)
+		add := "ALTER TABLE " + table + " ADD " + format.QuoteIdentifier(col.Name) + " " + cqlType(col.Type)
+		if col.IsStatic {
+			add += " STATIC"
+		}
+		add += ";"
+
+		switch {
+		case oldCol == nil:
+			d.tables = append(d.tables, d.change(ChangeAdded, ObjectColumn, new.Name, col.Name,
+				fmt.Sprintf("Column '%s' added to table '%s'", col.Name, new.Name), add))
+		case oldCol.Type != col.Type || oldCol.IsStatic != col.IsStatic:
+			// CQL cannot change a column's type in place, and dropping and
+			// re-adding it loses its data, so the statements are only suggested
+			c := d.change(ChangeAltered, ObjectColumn, new.Name, col.Name,
+				fmt.Sprintf("Column '%s' changed from %s to %s; the column must be recreated manually, losing its data",
+					col.Name, columnSignature(oldCol), columnSignature(col)),
+				commentedOut("ALTER TABLE "+table+" DROP "+format.QuoteIdentifier(col.Name)+";", add)...)
+			c.Recreate = true
+			d.tables = append(d.tables, c)
+		}
+	}
+	for _, col := range orderedColumns(old.Columns, old.ColumnOrder) {
+		if new.GetColumn(col.Name) == nil {
+			d.tables = append(d.tables, d.change(ChangeDropped, ObjectColumn, new.Name, col.Name,
+				fmt.Sprintf("Column '%s' dropped from table '%s'", col.Name, new.Name),
+				"ALTER TABLE "+table+" DROP "+format.QuoteIdentifier(col.Name)+";"))
+		}
+	}
+}
+
+func columnSignature(col *Column) string {
+	if col.IsStatic {
+		return col.Type + " STATIC"
+	}
+	return col.Type
+}
+
+func (d *keyspaceDiff) diffTableOptions(old, new *Table) {
+	oldOpts, newOpts := tableOptions(old), tableOptions(new)
+	var names, options []string
+	for _, name := range unionKeys(oldOpts, newOpts) {
+		value, set := newOpts[name]
+		if value == oldOpts[name] {
+			continue
+		}
+		if !set {
+			value = defaultTableOptions[name]
+		}
+		names = append(names, name)
+		options = append(options, name+" = "+value)
+	}
+	if len(options) == 0 {
+		return
+	}
+	d.tables = append(d.tables, d.change(ChangeAltered, ObjectTable, new.Name, new.Name,
+		fmt.Sprintf("Table '%s' options changed (%s)", new.Name, strings.Join(names, ", ")),
+		formatDDL("ALTER TABLE "+qualifiedName(new.Keyspace, new.Name)+" WITH "+strings.Join(options, " AND ")+";")))
+}
+
+// Indexes and views
+
+func (d *keyspaceDiff) diffIndexes(old, new *Table) {
+	for _, name := range sortedKeys(new.Indexes) {
+		idx := new.Indexes[name]
+		oldIdx := old.GetIndex(name)
+		switch {
+		case oldIdx == nil:
+			d.indexes = append(d.indexes, d.change(ChangeAdded, ObjectIndex, new.Name, name,
+				fmt.Sprintf("Index '%s' added on table '%s'", name, new.Name), new.indexDDL(idx)))
+		case old.indexDDL(oldIdx) != new.indexDDL(idx):
+			c := d.change(ChangeAltered, ObjectIndex, new.Name, name,
+				fmt.Sprintf("Index '%s' on table '%s' changed; the index must be recreated", name, new.Name),
+				dropIndexDDL(new.Keyspace, name), new.indexDDL(idx))
+			c.Recreate = true
+			d.indexes = append(d.indexes, c)
+		}
+	}
+	for _, name := range sortedKeys(old.Indexes) {
+		if new.GetIndex(name) == nil {
+			d.dropIndexes = append(d.dropIndexes, d.change(ChangeDropped, ObjectIndex, old.Name, name,
+				fmt.Sprintf("Index '%s' dropped from table '%s'", name, old.Name), dropIndexDDL(old.Keyspace, name)))
+		}
+	}
+}
+
+func (d *keyspaceDiff) diffViews(old, new *Table) {
+	for _, name := range sortedKeys(new.MaterializedViews) {
+		mv := new.MaterializedViews[name]
+		oldMv := old.GetMaterializedView(name)
+		switch {
+		case oldMv == nil:
+			d.views = append(d.views, d.change(ChangeAdded, ObjectMaterializedView, new.Name, name,
+				fmt.Sprintf("Materialized view '%s' added on table '%s'", name, new.Name), mv.DDL()))
+		case oldMv.DDL() != mv.DDL():
+			c := d.change(ChangeAltered, ObjectMaterializedView, new.Name, name,
+				fmt.Sprintf("Materialized view '%s' changed; the view must be recreated", name),
+				dropViewDDL(oldMv), mv.DDL())
+			c.Recreate = true
+			d.views = append(d.views, c)
+		}
+	}
+	for _, name := range sortedKeys(old.MaterializedViews) {
+		if new.GetMaterializedView(name) == nil {
+			d.dropViews = append(d.dropViews, d.change(ChangeDropped, ObjectMaterializedView, old.Name, name,
+				fmt.Sprintf("Materialized view '%s' dropped", name), dropViewDDL(old.MaterializedViews[name])))
+		}
+	}
+}
+
+// dropDependents records the drops of the indexes and views of a dropped table.
+func (d *keyspaceDiff) dropDependents(t *Table) {
+	empty := &Table{Name: t.Name, Keyspace: t.Keyspace}
+	d.diffIndexes(t, empty)
+	d.diffViews(t, empty)
+}
+
+// Helpers
+
+func (d *keyspaceDiff) change(typ ChangeType, object ObjectType, table, name, description string, statements ...string) Change {
+	return Change{
+		Type:        typ,
+		Object:      object,
+		Keyspace:    d.new.Name,
+		Table:       table,
+		Name:        name,
+		Description: description,
+		Statements:  statements,
+	}
+}
+
+// commentedOut turns statements into CQL line comments, for changes that
+// must not be applied without review.
+func commentedOut(statements ...string) []string {
+	commented := make([]string, len(statements))
+	for i, stmt := range statements {
+		commented[i] = "-- " + strings.ReplaceAll(stmt, "\n", "\n-- ")
+	}
+	return commented
+}
+
+func dropTableDDL(t *Table) string {
+	return "DROP TABLE " + qualifiedName(t.Keyspace, t.Name) + ";"
+}
+
+func dropIndexDDL(keyspace, name string) string {
+	return "DROP INDEX " + qualifiedName(keyspace, name) + ";"
+}
+
+func dropViewDDL(mv *MaterializedView) string {
+	return "DROP MATERIALIZED VIEW " + qualifiedName(mv.Keyspace, mv.Name) + ";"
+}
+
+func orderOf(orders map[string]Order, col string) Order {
+	if order := orders[col]; order != "" {
+		return order
+	}
+	return OrderAsc
+}
+
+// unionKeys returns the sorted union of the keys of two maps.
+func unionKeys[V any](a, b map[string]V) []string {
+	merged := make(map[string]bool, len(a)+len(b))
+	for k := range a {
+		merged[k] = true
+	}
+	for k := range b {
+		merged[k] = true
+	}
+	return sortedKeys(merged)
+}
+
+func nonNilSlice(s []string) []string {
+	if s == nil {
+		return []string{}
+	}
+	return s
+}
+
+func nonNilStrings(m map[string]string) map[string]string {
+	if m == nil {
+		return map[string]string{}
+	}
+	return m
+}
+
+func nonNilInts(m map[string]int) map[string]int {
+	if m == nil {
+		return map[string]int{}
+	}
+	return m
+}
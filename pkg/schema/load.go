@@ -0,0 +1,25 @@
+package schema
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Load loads a schema from a file or directory, choosing the format from the
+// path: a directory of system_schema dumps, a .json schema, or a CQL DDL
+// script otherwise.
+func Load(path string) (*Schema, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case info.IsDir():
+		return LoadFromSystemSchema(path)
+	case strings.EqualFold(filepath.Ext(path), ".json"):
+		return LoadFromJSON(path)
+	default:
+		return LoadFromCQL(path)
+	}
+}
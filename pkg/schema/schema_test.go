@@ -2,7 +2,13 @@ package schema
 
 import (
 	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
 	"testing"
+
+	"github.com/tentacle-scylla/scql/pkg/parse"
 )
 
 func TestNewSchema(t *testing.T) {
@@ -263,6 +269,36 @@ func TestAddUserType(t *testing.T) {
 	}
 }
 
+func TestUserTypeOf(t *testing.T) {
+	s := NewSchema()
+	ks := s.AddKeyspace("test_ks")
+	ks.AddType("geo").AddField("lat", "double").AddField("lon", "double")
+	ks.AddType("address").AddField("city", "text").AddField("location", "frozen<geo>")
+
+	for _, typ := range []string{"address", "frozen<address>", "FROZEN<test_ks.address>"} {
+		if udt := ks.UserTypeOf(typ); udt == nil || udt.Name != "address" {
+			t.Errorf("UserTypeOf(%q) = %v, want address", typ, udt)
+		}
+	}
+	for _, typ := range []string{"text", "list<frozen<address>>", "missing"} {
+		if udt := ks.UserTypeOf(typ); udt != nil {
+			t.Errorf("UserTypeOf(%q) = %s, want nil", typ, udt.Name)
+		}
+	}
+
+	if got := strings.Join(ks.GetType("address").FieldNames(), ", "); got != "city, location" {
+		t.Errorf("FieldNames() = %q, want city, location", got)
+	}
+
+	var names []string
+	for _, udt := range ks.ReferencedTypes("list<frozen<address>>") {
+		names = append(names, udt.Name)
+	}
+	if got := strings.Join(names, ", "); got != "geo, address" {
+		t.Errorf("ReferencedTypes() = %q, want geo, address", got)
+	}
+}
+
 func TestAddFunction(t *testing.T) {
 	s := NewSchema()
 	ks := s.AddKeyspace("test_ks")
@@ -543,3 +579,606 @@ func TestComplexSchema(t *testing.T) {
 		t.Error("Clustering order not preserved in round-trip")
 	}
 }
+
+func TestFromCQL(t *testing.T) {
+	s, errs := FromCQL(`
+		CREATE KEYSPACE myapp WITH replication = {'class': 'NetworkTopologyStrategy', 'dc1': 3, 'dc2': '2'};
+		USE myapp;
+		CREATE TYPE address (street text, city text);
+		CREATE TABLE events (
+			tenant_id uuid,
+			day date,
+			event_time timestamp,
+			location frozen<address>,
+			tags MAP<text, frozen<list<int>>>,
+			owner text STATIC,
+			PRIMARY KEY ((tenant_id, day), event_time)
+		) WITH CLUSTERING ORDER BY (event_time DESC)
+			AND comment = 'Tenant events'
+			AND gc_grace_seconds = 3600
+			AND compaction = {'class': 'LeveledCompactionStrategy'};
+		CREATE INDEX ON events (KEYS(tags));
+		CREATE MATERIALIZED VIEW events_by_day AS
+			SELECT * FROM events
+			WHERE day IS NOT NULL AND tenant_id IS NOT NULL AND event_time IS NOT NULL
+			PRIMARY KEY (day, tenant_id, event_time);
+	`)
+	if errs.HasErrors() {
+		t.Fatalf("FromCQL errors: %v", errs)
+	}
+
+	// Build the same schema with the builder
+	want := NewSchema()
+	ks := want.AddKeyspace("myapp").WithNetworkTopology(map[string]int{"dc1": 3, "dc2": 2})
+	ks.AddType("address").AddField("street", "text").AddField("city", "text")
+	events := ks.AddTable("events").
+		AddColumn("tenant_id", "uuid").
+		AddColumn("day", "date").
+		AddColumn("event_time", "timestamp").
+		AddColumn("location", "frozen<address>").
+		AddColumn("tags", "map<text, frozen<list<int>>>").
+		AddStaticColumn("owner", "text").
+		SetPartitionKey("tenant_id", "day").
+		SetClusteringKey("event_time").
+		SetClusteringOrder("event_time", OrderDesc).
+		WithComment("Tenant events").
+		WithGCGraceSeconds(3600)
+	events.Compaction = map[string]string{"class": "LeveledCompactionStrategy"}
+	idx := events.AddIndex("events_tags_idx", "tags").WithKind("COMPOSITES")
+	idx.Options["target"] = "keys(tags)"
+	mv := events.AddMaterializedView("events_by_day")
+	for _, col := range events.AllColumns() {
+		mv.AddColumn(col.Name, col.Type)
+	}
+	mv.SetPartitionKey("day").
+		SetClusteringKey("tenant_id", "event_time").
+		WithWhereClause("day IS NOT NULL AND tenant_id IS NOT NULL AND event_time IS NOT NULL")
+
+	if !reflect.DeepEqual(s, want) {
+		got, _ := s.ToJSONIndent()
+		expected, _ := want.ToJSONIndent()
+		t.Errorf("FromCQL schema mismatch\ngot:  %s\nwant: %s", got, expected)
+	}
+}
+
+func TestFromCQLCompositeKey(t *testing.T) {
+	s, errs := FromCQL(`
+		CREATE KEYSPACE ks WITH replication = {'class': 'SimpleStrategy', 'replication_factor': 1};
+		CREATE TABLE ks.hits (tenant uuid, day date, total int, PRIMARY KEY ((tenant, day)));
+	`)
+	if errs.HasErrors() {
+		t.Fatalf("FromCQL errors: %v", errs)
+	}
+	hits := s.GetKeyspace("ks").GetTable("hits")
+	if got := strings.Join(hits.PartitionKey, ","); got != "tenant,day" {
+		t.Errorf("PartitionKey = %s, want tenant,day", got)
+	}
+	if len(hits.ClusteringKey) != 0 {
+		t.Errorf("ClusteringKey = %v, want none", hits.ClusteringKey)
+	}
+}
+
+func TestFromCQLAlterAndDrop(t *testing.T) {
+	s, errs := FromCQL(`
+		CREATE KEYSPACE ks WITH replication = {'class': 'SimpleStrategy', 'replication_factor': 1} AND durable_writes = false;
+		CREATE TABLE ks.users (id uuid PRIMARY KEY, name text, age int);
+		ALTER TABLE ks.users ADD email text;
+		ALTER TABLE ks.users DROP age;
+		ALTER TABLE ks.users RENAME id TO user_id;
+		ALTER TABLE ks.users ALTER name TYPE blob;
+		ALTER TABLE ks.users WITH comment = 'Users';
+		CREATE TYPE ks.point (x int);
+		ALTER TYPE ks.point ADD y int;
+		ALTER TYPE ks.point RENAME x TO lat;
+		CREATE FUNCTION ks.plus(a int, b int) CALLED ON NULL INPUT RETURNS int LANGUAGE lua AS 'return a + b';
+		CREATE AGGREGATE ks.total(int) SFUNC plus STYPE int INITCOND 0;
+		CREATE TABLE ks.tmp (id int PRIMARY KEY);
+		DROP TABLE ks.tmp;
+		DROP TABLE IF EXISTS ks.missing;
+	`)
+	if errs.HasErrors() {
+		t.Fatalf("FromCQL errors: %v", errs)
+	}
+
+	ks := s.GetKeyspace("ks")
+	if ks.DurableWrites {
+		t.Error("DurableWrites should be false")
+	}
+	if ks.ReplicationClass != "SimpleStrategy" || ks.ReplicationFactor["replication_factor"] != 1 {
+		t.Errorf("Replication = %s %v", ks.ReplicationClass, ks.ReplicationFactor)
+	}
+
+	users := ks.GetTable("users")
+	if got := strings.Join(users.ColumnOrder, ","); got != "user_id,name,email" {
+		t.Errorf("ColumnOrder = %s, want user_id,name,email", got)
+	}
+	if len(users.PartitionKey) != 1 || users.PartitionKey[0] != "user_id" {
+		t.Errorf("PartitionKey = %v, want [user_id]", users.PartitionKey)
+	}
+	if name := users.GetColumn("name"); name.Type != "blob" {
+		t.Errorf("name type = %s, want blob", name.Type)
+	}
+	if users.Comment != "Users" {
+		t.Errorf("Comment = %q, want Users", users.Comment)
+	}
+	if ks.GetTable("tmp") != nil {
+		t.Error("Dropped table should be removed")
+	}
+
+	point := ks.GetType("point")
+	if got := strings.Join(point.FieldOrder, ","); got != "lat,y" {
+		t.Errorf("FieldOrder = %s, want lat,y", got)
+	}
+
+	fn := ks.GetFunction("plus")
+	if fn == nil || len(fn.Parameters) != 2 || fn.ReturnType != "int" || !fn.CalledOnNull || fn.Body != "return a + b" {
+		t.Errorf("Function = %+v", fn)
+	}
+	agg := ks.GetAggregate("total")
+	if agg == nil || agg.StateFunc != "plus" || agg.StateType != "int" || agg.InitCond != "0" {
+		t.Errorf("Aggregate = %+v", agg)
+	}
+}
+
+func TestFromCQLErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		wantErr string
+	}{
+		{"no keyspace", "CREATE TABLE users (id int PRIMARY KEY)", "No keyspace specified"},
+		{"unknown keyspace", "CREATE TABLE nope.users (id int PRIMARY KEY)", "Keyspace 'nope' does not exist"},
+		{"duplicate table", "CREATE KEYSPACE ks WITH replication = {'class': 'SimpleStrategy', 'replication_factor': 1}; CREATE TABLE ks.t (id int PRIMARY KEY); CREATE TABLE ks.t (id int PRIMARY KEY)", "Table 't' already exists"},
+		{"drop missing", "CREATE KEYSPACE ks WITH replication = {'class': 'SimpleStrategy', 'replication_factor': 1}; DROP TABLE ks.t", "Table 't' does not exist"},
+		{"alter missing column", "CREATE KEYSPACE ks WITH replication = {'class': 'SimpleStrategy', 'replication_factor': 1}; CREATE TABLE ks.t (id int PRIMARY KEY); ALTER TABLE ks.t DROP nope", "Column 'nope' does not exist"},
+		{"undeclared primary key column", "CREATE KEYSPACE ks WITH replication = {'class': 'SimpleStrategy', 'replication_factor': 1}; CREATE TABLE ks.t (id int, PRIMARY KEY (zz))", "Unknown column 'zz' referenced in PRIMARY KEY"},
+		{"undeclared clustering column", "CREATE KEYSPACE ks WITH replication = {'class': 'SimpleStrategy', 'replication_factor': 1}; CREATE TABLE ks.t (id int, PRIMARY KEY (id, zz))", "Unknown column 'zz' referenced in PRIMARY KEY"},
+		{"duplicate column", "CREATE KEYSPACE ks WITH replication = {'class': 'SimpleStrategy', 'replication_factor': 1}; CREATE TABLE ks.t (id int PRIMARY KEY, a int, a text)", "Multiple definition of identifier a"},
+		{"drop table with views", "CREATE KEYSPACE ks WITH replication = {'class': 'SimpleStrategy', 'replication_factor': 1}; CREATE TABLE ks.t (id int PRIMARY KEY, v int); " +
+			"CREATE MATERIALIZED VIEW ks.t_by_v AS SELECT * FROM ks.t WHERE v IS NOT NULL AND id IS NOT NULL PRIMARY KEY (v, id); DROP TABLE ks.t",
+			"Cannot drop table 't' when materialized views still depend on it (ks.{t_by_v})"},
+		{"syntax error", "CREATE TABL ks.t (id int PRIMARY KEY)", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, errs := FromCQL(tt.input)
+			if !errs.HasErrors() {
+				t.Fatal("Expected errors, got none")
+			}
+			if tt.wantErr != "" && !strings.Contains(errs.Error(), tt.wantErr) {
+				t.Errorf("Errors = %v, want %q", errs, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestToCQLRoundTrip(t *testing.T) {
+	s, errs := FromCQL(`
+		CREATE KEYSPACE app WITH replication = {'class': 'NetworkTopologyStrategy', 'dc1': 3} AND durable_writes = false;
+		USE app;
+		CREATE TYPE location (street text, geo frozen<point>);
+		CREATE TYPE point (x int, y int);
+		CREATE TABLE events (
+			tenant uuid, key text, ts timestamp, id timeuuid,
+			places map<text, frozen<location>>, owner text STATIC,
+			PRIMARY KEY ((tenant, key), ts, id)
+		) WITH CLUSTERING ORDER BY (ts DESC, id ASC)
+			AND comment = 'Events'
+			AND gc_grace_seconds = 3600
+			AND bloom_filter_fp_chance = 0.01
+			AND compaction = {'class': 'LeveledCompactionStrategy'};
+		CREATE INDEX ON events (KEYS(places));
+		CREATE CUSTOM INDEX owner_idx ON events (owner) USING 'org.example.Index' WITH OPTIONS = {'mode': 'fast'};
+		CREATE MATERIALIZED VIEW events_by_id AS
+			SELECT * FROM events
+			WHERE id IS NOT NULL AND tenant IS NOT NULL AND key IS NOT NULL AND ts IS NOT NULL
+			PRIMARY KEY (id, tenant, key, ts)
+			WITH CLUSTERING ORDER BY (tenant DESC);
+		CREATE FUNCTION twice(a int) RETURNS NULL ON NULL INPUT RETURNS int LANGUAGE lua AS 'return a * 2';
+		CREATE AGGREGATE total(int) SFUNC twice STYPE int INITCOND 0;
+		CREATE TABLE "Users" (id uuid PRIMARY KEY, "Name" text);
+		CREATE TABLE hits (tenant uuid, day date, total int, PRIMARY KEY ((tenant, day)));
+	`)
+	if errs.HasErrors() {
+		t.Fatalf("FromCQL errors: %v", errs)
+	}
+
+	ddl := s.ToCQL()
+	for _, r := range parse.Multiple(ddl) {
+		if r.HasErrors() {
+			t.Errorf("Generated statement does not parse: %s\n%v", r.Input, r.Errors)
+		}
+	}
+
+	s2, errs := FromCQL(ddl)
+	if errs.HasErrors() {
+		t.Fatalf("FromCQL(ToCQL()) errors: %v\n%s", errs, ddl)
+	}
+	if !reflect.DeepEqual(s, s2) {
+		t.Errorf("Schema changed in round-trip:\n%s", ddl)
+	}
+}
+
+func TestToCQLOrder(t *testing.T) {
+	s := NewSchema()
+	ks := s.AddKeyspace("app").WithSimpleStrategy(1)
+	ks.AddType("b_outer").AddField("inner", "frozen<a_inner>")
+	ks.AddType("a_inner").AddField("v", "int")
+	ks.AddType("c_user").AddField("o", "frozen<b_outer>")
+	tbl := ks.AddTable("items").
+		AddColumn("id", "uuid").
+		AddColumn("data", "frozen<c_user>").
+		SetPartitionKey("id")
+	tbl.AddMaterializedView("items_by_data").
+		AddColumn("data", "frozen<c_user>").
+		AddColumn("id", "uuid").
+		SetPartitionKey("data").
+		SetClusteringKey("id")
+
+	ddl := s.ToCQL()
+	order := []string{"CREATE KEYSPACE app", "CREATE TYPE app.a_inner", "CREATE TYPE app.b_outer", "CREATE TYPE app.c_user", "CREATE TABLE app.items", "CREATE MATERIALIZED VIEW app.items_by_data"}
+	last := -1
+	for _, want := range order {
+		idx := strings.Index(ddl, want)
+		if idx < 0 {
+			t.Fatalf("Missing %q in:\n%s", want, ddl)
+		}
+		if idx < last {
+			t.Errorf("%q is out of order in:\n%s", want, ddl)
+		}
+		last = idx
+	}
+
+	// Generated WHERE clause covers the view's primary key
+	if !strings.Contains(ddl, "WHERE data IS NOT NULL AND id IS NOT NULL") {
+		t.Errorf("Missing generated MV WHERE clause:\n%s", ddl)
+	}
+}
+
+func TestDiff(t *testing.T) {
+	base := `
+CREATE KEYSPACE app WITH replication = {'class': 'SimpleStrategy', 'replication_factor': 1};
+USE app;
+CREATE TYPE address (street text);
+CREATE TABLE members (id uuid PRIMARY KEY, name text, age int, email text);
+CREATE INDEX members_name_idx ON members (name);
+CREATE TABLE events (id uuid, ts timestamp, payload text, PRIMARY KEY (id, ts));
+CREATE TABLE logs (id uuid PRIMARY KEY, line text);
+CREATE INDEX logs_line_idx ON logs (line);
+CREATE MATERIALIZED VIEW members_by_email AS SELECT id, email FROM members WHERE email IS NOT NULL AND id IS NOT NULL PRIMARY KEY (email, id);
+`
+	old, errs := FromCQL(base)
+	if errs.HasErrors() {
+		t.Fatalf("FromCQL(old) errors: %v", errs)
+	}
+	new, errs := FromCQL(`
+CREATE KEYSPACE app WITH replication = {'class': 'SimpleStrategy', 'replication_factor': 3};
+CREATE KEYSPACE extra WITH replication = {'class': 'SimpleStrategy', 'replication_factor': 1};
+USE app;
+CREATE TYPE address (street text, city text);
+CREATE TABLE members (id uuid PRIMARY KEY, name text, age bigint, country text) WITH comment = 'people';
+CREATE INDEX members_country_idx ON members (country);
+CREATE TABLE events (id uuid, ts timestamp, payload text, PRIMARY KEY (id, ts)) WITH CLUSTERING ORDER BY (ts DESC);
+`)
+	if errs.HasErrors() {
+		t.Fatalf("FromCQL(new) errors: %v", errs)
+	}
+
+	var got []string
+	byName := make(map[string]Change)
+	for _, c := range Diff(old, new) {
+		key := string(c.Type) + " " + string(c.Object) + " " + c.Name
+		got = append(got, key)
+		byName[key] = c
+	}
+	want := []string{
+		"altered keyspace app",
+		"dropped materialized_view members_by_email",
+		"dropped index logs_line_idx",
+		"dropped index members_name_idx",
+		"dropped table logs",
+		"altered type address",
+		"altered table events",
+		"altered column age",
+		"added column country",
+		"dropped column email",
+		"altered table members",
+		"added index members_country_idx",
+		"added keyspace extra",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("Diff() changes:\n got %v\nwant %v", got, want)
+	}
+
+	tests := []struct {
+		key       string
+		recreate  bool
+		statement string
+	}{
+		{"altered keyspace app", false, "{'class': 'SimpleStrategy', 'replication_factor': '3'}"},
+		{"altered type address", false, "ALTER TYPE app.address ADD city text;"},
+		{"altered table events", true, "DROP TABLE app.events;"},
+		{"added column country", false, "ALTER TABLE app.members ADD country text;"},
+		{"altered column age", true, "-- ALTER TABLE app.members DROP age;\n-- ALTER TABLE app.members ADD age bigint;"},
+		{"dropped column email", false, "ALTER TABLE app.members DROP email;"},
+		{"altered table members", false, "comment = 'people'"},
+		{"added index members_country_idx", false, "ON app.members (country);"},
+		{"dropped materialized_view members_by_email", false, "DROP MATERIALIZED VIEW app.members_by_email;"},
+	}
+	for _, tt := range tests {
+		c := byName[tt.key]
+		if c.Recreate != tt.recreate {
+			t.Errorf("%s: Recreate = %v, want %v", tt.key, c.Recreate, tt.recreate)
+		}
+		if !strings.Contains(strings.Join(c.Statements, "\n"), tt.statement) {
+			t.Errorf("%s: statements %q do not contain %q", tt.key, c.Statements, tt.statement)
+		}
+	}
+
+	// Applying the migration to the old schema yields the new one, except
+	// for the column type change that is left to the user
+	migrated, errs := FromCQL(base + MigrationCQL(Diff(old, new)))
+	if errs.HasErrors() {
+		t.Fatalf("Migration errors: %v", errs)
+	}
+	changes := Diff(migrated, new)
+	if len(changes) != 1 || changes[0].Name != "age" || !changes[0].Recreate {
+		t.Errorf("Diff() after migration = %v, want only the age column change", changes)
+	}
+}
+
+func TestDiffRecreatedType(t *testing.T) {
+	old, errs := FromCQL(`
+CREATE KEYSPACE app WITH replication = {'class': 'SimpleStrategy', 'replication_factor': 1};
+USE app;
+CREATE TYPE address (street text, zip int);
+CREATE TYPE phone (number text);
+CREATE TABLE users (id uuid PRIMARY KEY, home frozen<address>);
+`)
+	if errs.HasErrors() {
+		t.Fatalf("FromCQL(old) errors: %v", errs)
+	}
+	new, errs := FromCQL(`
+CREATE KEYSPACE app WITH replication = {'class': 'SimpleStrategy', 'replication_factor': 1};
+USE app;
+CREATE TYPE address (street text, zip text);
+CREATE TYPE phone (number int);
+CREATE TABLE users (id uuid PRIMARY KEY, home frozen<address>);
+`)
+	if errs.HasErrors() {
+		t.Fatalf("FromCQL(new) errors: %v", errs)
+	}
+
+	changes := Diff(old, new)
+	if len(changes) != 2 {
+		t.Fatalf("Diff() = %v, want 2 changes", changes)
+	}
+	// A type still used by a table cannot be dropped
+	address := changes[0]
+	if address.Name != "address" || !address.Recreate || !strings.Contains(address.Description, "still used by table 'users'") {
+		t.Errorf("address change = %+v, want a recreate used by table 'users'", address)
+	}
+	for _, stmt := range address.Statements {
+		if !strings.HasPrefix(stmt, "-- ") {
+			t.Errorf("address statement %q is not commented out", stmt)
+		}
+	}
+	// An unused type is dropped and created again
+	phone := changes[1]
+	if phone.Name != "phone" || !phone.Recreate || !reflect.DeepEqual(phone.Statements[:1], []string{"DROP TYPE app.phone;"}) {
+		t.Errorf("phone change = %+v, want DROP TYPE and CREATE TYPE", phone)
+	}
+}
+
+func TestDiffDroppedKeyspace(t *testing.T) {
+	old := NewSchema()
+	old.AddKeyspace("gone").WithSimpleStrategy(1).
+		AddTable("t").AddColumn("id", "int").SetPartitionKey("id")
+
+	changes := Diff(old, NewSchema())
+	if len(changes) != 1 {
+		t.Fatalf("Diff() = %v, want a single change", changes)
+	}
+	if c := changes[0]; c.Type != ChangeDropped || c.Object != ObjectKeyspace || c.Statements[0] != "DROP KEYSPACE gone;" {
+		t.Errorf("Diff() = %+v", c)
+	}
+}
+
+func TestParseSystemSchemaJSON(t *testing.T) {
+	data := []byte(`{
+  "keyspaces": [
+    {"keyspace_name": "app", "durable_writes": true, "replication": {"class": "org.apache.cassandra.locator.NetworkTopologyStrategy", "dc1": "3"}}
+  ],
+  "types": [
+    {"keyspace_name": "app", "type_name": "address", "field_names": ["street", "city"], "field_types": ["text", "text"]}
+  ],
+  "tables": [
+    {"keyspace_name": "app", "table_name": "events", "comment": "raw events", "gc_grace_seconds": 3600, "bloom_filter_fp_chance": 0.01,
+     "compaction": {"class": "SizeTieredCompactionStrategy"}, "compression": {}, "caching": {"keys": "ALL", "rows_per_partition": "NONE"}}
+  ],
+  "columns": [
+    {"keyspace_name": "app", "table_name": "events", "column_name": "day", "kind": "partition_key", "position": 1, "type": "date", "clustering_order": "none"},
+    {"keyspace_name": "app", "table_name": "events", "column_name": "tenant", "kind": "partition_key", "position": 0, "type": "text", "clustering_order": "none"},
+    {"keyspace_name": "app", "table_name": "events", "column_name": "ts", "kind": "clustering", "position": 0, "type": "timestamp", "clustering_order": "desc"},
+    {"keyspace_name": "app", "table_name": "events", "column_name": "owner", "kind": "static", "position": -1, "type": "frozen<address>", "clustering_order": "none"},
+    {"keyspace_name": "app", "table_name": "events", "column_name": "body", "kind": "regular", "position": -1, "type": "map<text, int>", "clustering_order": "none"},
+    {"keyspace_name": "app", "table_name": "events_by_ts", "column_name": "ts", "kind": "partition_key", "position": 0, "type": "timestamp", "clustering_order": "none"},
+    {"keyspace_name": "app", "table_name": "events_by_ts", "column_name": "tenant", "kind": "clustering", "position": 0, "type": "text", "clustering_order": "asc"},
+    {"keyspace_name": "app", "table_name": "events_by_ts", "column_name": "day", "kind": "clustering", "position": 1, "type": "date", "clustering_order": "asc"}
+  ],
+  "views": [
+    {"keyspace_name": "app", "view_name": "events_by_ts", "base_table_name": "events", "where_clause": "ts IS NOT NULL AND tenant IS NOT NULL AND day IS NOT NULL"}
+  ],
+  "indexes": [
+    {"keyspace_name": "app", "table_name": "events", "index_name": "events_body_idx", "kind": "COMPOSITES", "options": {"target": "keys(body)"}}
+  ],
+  "functions": [
+    {"keyspace_name": "app", "function_name": "twice", "argument_names": ["x"], "argument_types": ["int"], "return_type": "int",
+     "language": "lua", "body": "return x * 2", "called_on_null_input": false}
+  ]
+}`)
+	s, err := ParseSystemSchemaJSON(data)
+	if err != nil {
+		t.Fatalf("ParseSystemSchemaJSON() error: %v", err)
+	}
+
+	want := NewSchema()
+	ks := want.AddKeyspace("app").WithNetworkTopology(map[string]int{"dc1": 3})
+	ks.AddType("address").AddField("street", "text").AddField("city", "text")
+	ks.AddFunction("twice").AddParameter("x", "int").WithReturnType("int").WithLanguage("lua").WithBody("return x * 2")
+	tbl := ks.AddTable("events").
+		AddColumn("tenant", "text").
+		AddColumn("day", "date").
+		AddColumn("ts", "timestamp").
+		AddColumn("body", "map<text, int>").
+		AddStaticColumn("owner", "frozen<address>").
+		SetPartitionKey("tenant", "day").
+		SetClusteringKey("ts").
+		SetClusteringOrder("ts", OrderDesc).
+		WithComment("raw events").
+		WithGCGraceSeconds(3600)
+	tbl.BloomFilterFPChance = 0.01
+	tbl.Compaction = map[string]string{"class": "SizeTieredCompactionStrategy"}
+	tbl.Caching = map[string]string{"keys": "ALL", "rows_per_partition": "NONE"}
+	tbl.AddIndex("events_body_idx", "body").WithKind("COMPOSITES").Options["target"] = "keys(body)"
+	tbl.AddMaterializedView("events_by_ts").
+		AddColumn("ts", "timestamp").
+		AddColumn("tenant", "text").
+		AddColumn("day", "date").
+		SetPartitionKey("ts").
+		SetClusteringKey("tenant", "day").
+		WithWhereClause("ts IS NOT NULL AND tenant IS NOT NULL AND day IS NOT NULL")
+
+	if !reflect.DeepEqual(s, want) {
+		t.Errorf("ParseSystemSchemaJSON() =\n%s\nwant\n%s", s.ToCQL(), want.ToCQL())
+	}
+}
+
+func TestSystemSchemaCSV(t *testing.T) {
+	rows := make(SystemSchemaRows)
+	inputs := map[string]string{
+		"system_schema.keyspaces": "keyspace_name,durable_writes,replication\n" +
+			`app,False,"{'class': 'org.apache.cassandra.locator.SimpleStrategy', 'replication_factor': '1'}"` + "\n",
+		"tables": "keyspace_name,table_name,gc_grace_seconds,compaction\n" +
+			`app,users,864000,"{'class': 'LeveledCompactionStrategy', 'sstable_size_in_mb': '160'}"` + "\n",
+		"columns": "keyspace_name,table_name,column_name,clustering_order,kind,position,type\n" +
+			"app,users,id,none,partition_key,0,uuid\n" +
+			"app,users,tags,none,regular,-1,set<text>\n" +
+			"app,users,email,none,regular,-1,text\n",
+		"types": "keyspace_name,type_name,field_names,field_types\n" +
+			`app,point,"['x', 'y']","['double', 'frozen<list<int>>']"` + "\n",
+		"aggregates": "keyspace_name,aggregate_name,argument_types,final_func,initcond,return_type,state_func,state_type\n" +
+			"app,total,['int'],,0,int,add_int,int\n",
+	}
+	for table, input := range inputs {
+		if err := rows.AddCSV(table, strings.NewReader(input)); err != nil {
+			t.Fatalf("AddCSV(%s) error: %v", table, err)
+		}
+	}
+	s, err := rows.Schema()
+	if err != nil {
+		t.Fatalf("Schema() error: %v", err)
+	}
+
+	ks := s.GetKeyspace("app")
+	if ks.ReplicationClass != "SimpleStrategy" || ks.ReplicationFactor["replication_factor"] != 1 || ks.DurableWrites {
+		t.Errorf("Keyspace = %+v", ks)
+	}
+	users := ks.GetTable("users")
+	if !reflect.DeepEqual(users.ColumnOrder, []string{"id", "email", "tags"}) {
+		t.Errorf("ColumnOrder = %v", users.ColumnOrder)
+	}
+	if !users.GetColumn("id").IsPartitionKey || users.GetColumn("tags").Type != "set<text>" {
+		t.Errorf("Columns = %+v", users.Columns)
+	}
+	if users.Compaction["sstable_size_in_mb"] != "160" || users.GCGraceSeconds != 864000 {
+		t.Errorf("Table options = %+v %d", users.Compaction, users.GCGraceSeconds)
+	}
+	if point := ks.GetType("point"); point.Fields["y"] != "frozen<list<int>>" {
+		t.Errorf("Type fields = %v", point.Fields)
+	}
+	if agg := ks.Aggregates["total"]; agg == nil || agg.StateFunc != "add_int" || agg.InitCond != "0" || !reflect.DeepEqual(agg.Parameters, []string{"int"}) {
+		t.Errorf("Aggregate = %+v", agg)
+	}
+}
+
+func TestSystemSchemaErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		rows SystemSchemaRows
+		want string
+	}{
+		{
+			"unknown keyspace",
+			SystemSchemaRows{"tables": {{"keyspace_name": "nope", "table_name": "t"}}},
+			"system_schema.tables row 1: keyspace 'nope' does not exist",
+		},
+		{
+			"no partition key",
+			SystemSchemaRows{
+				"keyspaces": {{"keyspace_name": "app"}},
+				"tables":    {{"keyspace_name": "app", "table_name": "t"}},
+			},
+			"system_schema.tables row 1: table 'app.t' has no partition key columns",
+		},
+		{
+			"bad position",
+			SystemSchemaRows{
+				"keyspaces": {{"keyspace_name": "app"}},
+				"columns":   {{"keyspace_name": "app", "table_name": "t", "column_name": "id", "position": "first"}},
+			},
+			"system_schema.columns row 1: invalid position: first",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := tt.rows.Schema()
+			if err == nil || err.Error() != tt.want {
+				t.Errorf("Schema() error = %v, want %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoad(t *testing.T) {
+	dir := t.TempDir()
+	ddl := "CREATE KEYSPACE app WITH replication = {'class': 'SimpleStrategy', 'replication_factor': 1};\n" +
+		"CREATE TABLE app.t (id int PRIMARY KEY);\n" +
+		"ALTER TABLE app.t DROP nope;\n"
+	s, errs := FromCQL(ddl)
+	data, err := s.ToJSON()
+	if err != nil {
+		t.Fatal(err)
+	}
+	files := map[string]string{"schema.cql": ddl, "schema.JSON": string(data), "dump/keyspaces.json": `[{"keyspace_name": "app"}]`}
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Errors from a script are positioned in the script
+	if err := errs.First(); err == nil || err.Line != 3 || err.Column != 23 {
+		t.Errorf("FromCQL error = %v, want one at 3:23", err)
+	}
+	s, err = Load(filepath.Join(dir, "schema.cql"))
+	if s == nil || s.GetKeyspace("app").GetTable("t") == nil || err == nil {
+		t.Errorf("Load(schema.cql) = %v, %v", s, err)
+	}
+
+	for _, name := range []string{"schema.JSON", "dump"} {
+		s, err := Load(filepath.Join(dir, name))
+		if err != nil || s.GetKeyspace("app") == nil {
+			t.Errorf("Load(%s) = %v, %v", name, s, err)
+		}
+	}
+
+	if _, err := Load(filepath.Join(dir, "missing.cql")); err == nil {
+		t.Error("expected an error for a missing file")
+	}
+}
@@ -0,0 +1,598 @@
+package schema
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// SystemSchemaTables lists the system_schema tables the loader reads.
+var SystemSchemaTables = []string{
+	"keyspaces", "tables", "columns", "indexes", "views", "types", "functions", "aggregates",
+}
+
+// SystemSchemaRows holds rows of the system_schema tables keyed by table name
+// ("keyspaces", "tables", "columns", ...). Values are either strings, as read
+// from CSV, or decoded JSON values. Collections may be given as JSON arrays and
+// objects or as CQL literals such as ['a', 'b'] and {'k': 'v'}.
+type SystemSchemaRows map[string][]map[string]any
+
+// AddCSV reads rows of a system_schema table from CSV with a header line,
+// as written by cqlsh: COPY system_schema.columns TO 'columns.csv' WITH HEADER = true.
+func (r SystemSchemaRows) AddCSV(table string, rd io.Reader) error {
+	table = systemSchemaTable(table)
+	records, err := csv.NewReader(rd).ReadAll()
+	if err != nil {
+		return fmt.Errorf("system_schema.%s: %w", table, err)
+	}
+	if len(records) == 0 {
+		return nil
+	}
+	header := records[0]
+	if !containsString(header, "keyspace_name") {
+		return fmt.Errorf("system_schema.%s: CSV header must include keyspace_name", table)
+	}
+	for _, record := range records[1:] {
+		values := make(map[string]any, len(header))
+		for i, name := range header {
+			if i < len(record) {
+				values[name] = record[i]
+			}
+		}
+		r[table] = append(r[table], values)
+	}
+	return nil
+}
+
+// AddJSON reads rows of a system_schema table from a JSON array of objects or
+// from a stream of JSON objects, such as the output of SELECT JSON * FROM system_schema.columns.
+func (r SystemSchemaRows) AddJSON(table string, data []byte) error {
+	table = systemSchemaTable(table)
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	if trimmed := bytes.TrimSpace(data); len(trimmed) > 0 && trimmed[0] == '[' {
+		var rows []map[string]any
+		if err := dec.Decode(&rows); err != nil {
+			return fmt.Errorf("system_schema.%s: %w", table, err)
+		}
+		r[table] = append(r[table], rows...)
+		return nil
+	}
+	for {
+		var values map[string]any
+		err := dec.Decode(&values)
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("system_schema.%s: %w", table, err)
+		}
+		// SELECT JSON returns each row as a [json] column holding the encoded row
+		if encoded, ok := values["[json]"].(string); ok {
+			values = nil
+			inner := json.NewDecoder(strings.NewReader(encoded))
+			inner.UseNumber()
+			if err := inner.Decode(&values); err != nil {
+				return fmt.Errorf("system_schema.%s: %w", table, err)
+			}
+		}
+		r[table] = append(r[table], values)
+	}
+}
+
+// ParseSystemSchemaJSON builds a schema from a JSON object that maps system_schema
+// table names to arrays of rows, e.g. {"keyspaces": [...], "tables": [...], "columns": [...]}.
+func ParseSystemSchemaJSON(data []byte) (*Schema, error) {
+	var tables map[string]json.RawMessage
+	if err := json.Unmarshal(data, &tables); err != nil {
+		return nil, err
+	}
+	rows := make(SystemSchemaRows)
+	for name, raw := range tables {
+		if err := rows.AddJSON(name, raw); err != nil {
+			return nil, err
+		}
+	}
+	return rows.Schema()
+}
+
+// LoadFromSystemSchema loads a schema from a directory of system_schema dumps.
+// Each table is read from <table>.json or <table>.csv, optionally prefixed with
+// "system_schema."; missing tables are skipped.
+func LoadFromSystemSchema(dir string) (*Schema, error) {
+	rows := make(SystemSchemaRows)
+	for _, table := range SystemSchemaTables {
+		for _, name := range []string{table, "system_schema." + table} {
+			if data, err := os.ReadFile(filepath.Join(dir, name+".json")); err == nil {
+				if err := rows.AddJSON(table, data); err != nil {
+					return nil, err
+				}
+			} else if !errors.Is(err, os.ErrNotExist) {
+				return nil, err
+			}
+			if data, err := os.ReadFile(filepath.Join(dir, name+".csv")); err == nil {
+				if err := rows.AddCSV(table, bytes.NewReader(data)); err != nil {
+					return nil, err
+				}
+			} else if !errors.Is(err, os.ErrNotExist) {
+				return nil, err
+			}
+		}
+	}
+	return rows.Schema()
+}
+
+// Schema builds a schema from the rows.
+func (r SystemSchemaRows) Schema() (*Schema, error) {
+	l := &systemSchemaLoader{schema: NewSchema(), columns: make(map[string][]systemColumn)}
+	steps := []struct {
+		table string
+		load  func(row) error
+	}{
+		{"keyspaces", l.keyspace},
+		{"types", l.userType},
+		{"functions", l.function},
+		{"aggregates", l.aggregate},
+		{"columns", l.column},
+		{"tables", l.table},
+		{"views", l.view},
+		{"indexes", l.index},
+	}
+	for _, step := range steps {
+		for i, values := range r[step.table] {
+			if err := step.load(row(values)); err != nil {
+				return nil, fmt.Errorf("system_schema.%s row %d: %w", step.table, i+1, err)
+			}
+		}
+	}
+	return l.schema, nil
+}
+
+// systemSchemaTable strips the system_schema. prefix and file extension from a table name.
+func systemSchemaTable(name string) string {
+	name = strings.TrimPrefix(name, "system_schema.")
+	return strings.TrimSuffix(name, filepath.Ext(name))
+}
+
+type systemSchemaLoader struct {
+	schema  *Schema
+	columns map[string][]systemColumn // "keyspace.table" -> columns of tables and views
+}
+
+// systemColumn is a row of system_schema.columns.
+type systemColumn struct {
+	name     string
+	typ      string
+	kind     string
+	position int
+	order    string
+}
+
+func (l *systemSchemaLoader) keyspaceFor(values row) (*Keyspace, error) {
+	name := values.str("keyspace_name")
+	if name == "" {
+		return nil, errors.New("missing keyspace_name")
+	}
+	ks := l.schema.GetKeyspace(name)
+	if ks == nil {
+		return nil, fmt.Errorf("keyspace '%s' does not exist", name)
+	}
+	return ks, nil
+}
+
+func (l *systemSchemaLoader) keyspace(values row) error {
+	name := values.str("keyspace_name")
+	if name == "" {
+		return errors.New("missing keyspace_name")
+	}
+	ks := l.schema.AddKeyspace(name)
+	if durable, ok := values.bool("durable_writes"); ok {
+		ks.WithDurableWrites(durable)
+	}
+	replication, err := values.stringMap("replication")
+	if err != nil {
+		return err
+	}
+	factors := make(map[string]int)
+	for key, value := range replication {
+		if n, err := strconv.Atoi(value); err == nil && key != "class" {
+			factors[key] = n
+		}
+	}
+	ks.WithReplication(strings.TrimPrefix(replication["class"], "org.apache.cassandra.locator."), factors)
+	return nil
+}
+
+func (l *systemSchemaLoader) column(values row) error {
+	if _, err := l.keyspaceFor(values); err != nil {
+		return err
+	}
+	position, err := values.int("position")
+	if err != nil {
+		return err
+	}
+	key := values.str("keyspace_name") + "." + values.str("table_name")
+	l.columns[key] = append(l.columns[key], systemColumn{
+		name:     values.str("column_name"),
+		typ:      values.str("type"),
+		kind:     strings.ToLower(values.str("kind")),
+		position: position,
+		order:    strings.ToLower(values.str("clustering_order")),
+	})
+	return nil
+}
+
+// sortedColumns returns the columns of a table or view in DESCRIBE order:
+// partition key and clustering columns by position, then the others by name.
+func (l *systemSchemaLoader) sortedColumns(keyspace, table string) (columns []systemColumn, partitionKey, clusteringKey []string) {
+	columns = append(columns, l.columns[keyspace+"."+table]...)
+	rank := map[string]int{"partition_key": 0, "clustering": 1, "static": 2, "regular": 2}
+	sort.SliceStable(columns, func(i, j int) bool {
+		a, b := columns[i], columns[j]
+		if rank[a.kind] != rank[b.kind] {
+			return rank[a.kind] < rank[b.kind]
+		}
+		if a.kind == "partition_key" || a.kind == "clustering" {
+			return a.position < b.position
+		}
+		return a.name < b.name
+	})
+	for _, col := range columns {
+		switch col.kind {
+		case "partition_key":
+			partitionKey = append(partitionKey, col.name)
+		case "clustering":
+			clusteringKey = append(clusteringKey, col.name)
+		}
+	}
+	return columns, partitionKey, clusteringKey
+}
+
+func (l *systemSchemaLoader) table(values row) error {
+	ks, err := l.keyspaceFor(values)
+	if err != nil {
+		return err
+	}
+	t := ks.AddTable(values.str("table_name"))
+	columns, partitionKey, clusteringKey := l.sortedColumns(ks.Name, t.Name)
+	if len(partitionKey) == 0 {
+		return fmt.Errorf("table '%s.%s' has no partition key columns", ks.Name, t.Name)
+	}
+	for _, col := range columns {
+		if col.kind == "static" {
+			t.AddStaticColumn(col.name, col.typ)
+		} else {
+			t.AddColumn(col.name, col.typ)
+		}
+	}
+	t.SetPartitionKey(partitionKey...)
+	if len(clusteringKey) > 0 {
+		t.SetClusteringKey(clusteringKey...)
+	}
+	for _, col := range columns {
+		if col.kind == "clustering" && col.order == "desc" {
+			t.SetClusteringOrder(col.name, OrderDesc)
+		}
+	}
+
+	t.WithComment(values.str("comment"))
+	gcGrace, err := values.int("gc_grace_seconds")
+	if err != nil {
+		return err
+	}
+	t.WithGCGraceSeconds(gcGrace)
+	if s := values.str("bloom_filter_fp_chance"); s != "" {
+		f, err := strconv.ParseFloat(s, 64)
+		if err != nil {
+			return fmt.Errorf("invalid bloom_filter_fp_chance: %s", s)
+		}
+		t.BloomFilterFPChance = f
+	}
+	if t.Compaction, err = values.stringMap("compaction"); err != nil {
+		return err
+	}
+	if t.Compression, err = values.stringMap("compression"); err != nil {
+		return err
+	}
+	t.Caching, err = values.stringMap("caching")
+	return err
+}
+
+func (l *systemSchemaLoader) view(values row) error {
+	ks, err := l.keyspaceFor(values)
+	if err != nil {
+		return err
+	}
+	name, baseName := values.str("view_name"), values.str("base_table_name")
+	base := ks.GetTable(baseName)
+	if base == nil {
+		return fmt.Errorf("base table '%s' of materialized view '%s' does not exist", baseName, name)
+	}
+	columns, partitionKey, clusteringKey := l.sortedColumns(ks.Name, name)
+	mv := base.AddMaterializedView(name)
+	for _, col := range columns {
+		mv.AddColumn(col.name, col.typ)
+	}
+	mv.SetPartitionKey(partitionKey...)
+	if len(clusteringKey) > 0 {
+		mv.SetClusteringKey(clusteringKey...)
+	}
+	for _, col := range columns {
+		if col.kind == "clustering" && col.order == "desc" {
+			mv.ClusteringOrder[col.name] = OrderDesc
+		}
+	}
+	mv.WithWhereClause(values.str("where_clause"))
+	return nil
+}
+
+func (l *systemSchemaLoader) index(values row) error {
+	ks, err := l.keyspaceFor(values)
+	if err != nil {
+		return err
+	}
+	tableName := values.str("table_name")
+	t := ks.GetTable(tableName)
+	if t == nil {
+		return fmt.Errorf("table '%s' does not exist in keyspace '%s'", tableName, ks.Name)
+	}
+	options, err := values.stringMap("options")
+	if err != nil {
+		return err
+	}
+	idx := t.AddIndex(values.str("index_name"), indexTargetColumn(options["target"])).
+		WithKind(strings.ToUpper(values.str("kind")))
+	if class, ok := options["class_name"]; ok {
+		idx.WithClassName(class)
+		delete(options, "class_name")
+	}
+	for k, v := range options {
+		idx.Options[k] = v
+	}
+	return nil
+}
+
+// indexTargetColumn returns the column of an index target such as "col", "keys(col)"
+// or the JSON target of a local index ({"pk":["p"],"ck":["col"]}).
+func indexTargetColumn(target string) string {
+	if strings.HasPrefix(target, "{") {
+		var local struct {
+			CK []string `json:"ck"`
+		}
+		if err := json.Unmarshal([]byte(target), &local); err == nil && len(local.CK) > 0 {
+			return normalizeIdentifier(local.CK[0])
+		}
+		return target
+	}
+	if open := strings.Index(target, "("); open > 0 && strings.HasSuffix(target, ")") {
+		target = target[open+1 : len(target)-1]
+	}
+	if strings.HasPrefix(target, `"`) {
+		return normalizeIdentifier(target)
+	}
+	return target
+}
+
+func (l *systemSchemaLoader) userType(values row) error {
+	ks, err := l.keyspaceFor(values)
+	if err != nil {
+		return err
+	}
+	names, err := values.stringList("field_names")
+	if err != nil {
+		return err
+	}
+	fieldTypes, err := values.stringList("field_types")
+	if err != nil {
+		return err
+	}
+	if len(names) != len(fieldTypes) {
+		return fmt.Errorf("type '%s' has %d field names and %d field types", values.str("type_name"), len(names), len(fieldTypes))
+	}
+	udt := ks.AddType(values.str("type_name"))
+	for i, name := range names {
+		udt.AddField(name, fieldTypes[i])
+	}
+	return nil
+}
+
+func (l *systemSchemaLoader) function(values row) error {
+	ks, err := l.keyspaceFor(values)
+	if err != nil {
+		return err
+	}
+	names, err := values.stringList("argument_names")
+	if err != nil {
+		return err
+	}
+	argTypes, err := values.stringList("argument_types")
+	if err != nil {
+		return err
+	}
+	if len(names) != len(argTypes) {
+		return fmt.Errorf("function '%s' has %d argument names and %d argument types", values.str("function_name"), len(names), len(argTypes))
+	}
+	fn := ks.AddFunction(values.str("function_name")).
+		WithReturnType(values.str("return_type")).
+		WithLanguage(strings.ToLower(values.str("language"))).
+		WithBody(values.str("body"))
+	for i, name := range names {
+		fn.AddParameter(name, argTypes[i])
+	}
+	if calledOnNull, _ := values.bool("called_on_null_input"); calledOnNull {
+		fn.CalledOnNullInput()
+	}
+	return nil
+}
+
+func (l *systemSchemaLoader) aggregate(values row) error {
+	ks, err := l.keyspaceFor(values)
+	if err != nil {
+		return err
+	}
+	argTypes, err := values.stringList("argument_types")
+	if err != nil {
+		return err
+	}
+	agg := &Aggregate{
+		Name:       values.str("aggregate_name"),
+		Keyspace:   ks.Name,
+		StateFunc:  values.str("state_func"),
+		StateType:  values.str("state_type"),
+		FinalFunc:  values.str("final_func"),
+		InitCond:   values.str("initcond"),
+		Parameters: argTypes,
+		ReturnType: values.str("return_type"),
+	}
+	if agg.ReturnType == "" {
+		agg.ReturnType = agg.StateType
+	}
+	if ks.Aggregates == nil {
+		ks.Aggregates = make(map[string]*Aggregate)
+	}
+	ks.Aggregates[agg.Name] = agg
+	return nil
+}
+
+// row is a system_schema row with typed accessors that accept both CSV strings and JSON values.
+type row map[string]any
+
+func (r row) str(key string) string {
+	return stringValue(r[key])
+}
+
+// stringValue renders a CSV or JSON value as a string.
+func stringValue(value any) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case json.Number:
+		return v.String()
+	case bool:
+		return strconv.FormatBool(v)
+	default:
+		data, err := json.Marshal(v)
+		if err != nil {
+			return fmt.Sprint(v)
+		}
+		return string(data)
+	}
+}
+
+func (r row) int(key string) (int, error) {
+	s := r.str(key)
+	if s == "" {
+		return 0, nil
+	}
+	n, err := strconv.Atoi(s)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s: %s", key, s)
+	}
+	return n, nil
+}
+
+func (r row) bool(key string) (value, ok bool) {
+	value, err := strconv.ParseBool(r.str(key))
+	return value, err == nil
+}
+
+func (r row) stringList(key string) ([]string, error) {
+	switch v := r[key].(type) {
+	case nil:
+		return nil, nil
+	case []any:
+		list := make([]string, len(v))
+		for i, item := range v {
+			list[i] = stringValue(item)
+		}
+		return list, nil
+	case string:
+		elements, err := collectionElements(v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s: %w", key, err)
+		}
+		return elements, nil
+	}
+	return nil, fmt.Errorf("invalid %s: expected a list", key)
+}
+
+func (r row) stringMap(key string) (map[string]string, error) {
+	m := make(map[string]string)
+	switch v := r[key].(type) {
+	case nil:
+	case map[string]any:
+		for k, item := range v {
+			m[k] = stringValue(item)
+		}
+	case string:
+		elements, err := collectionElements(v)
+		if err != nil || len(elements)%2 != 0 {
+			return nil, fmt.Errorf("invalid %s: %s", key, v)
+		}
+		for i := 0; i < len(elements); i += 2 {
+			m[elements[i]] = elements[i+1]
+		}
+	default:
+		return nil, fmt.Errorf("invalid %s: expected a map", key)
+	}
+	return m, nil
+}
+
+// collectionElements splits a CQL or JSON collection literal ("['a', 'b']",
+// "{'k': 'v'}" or "{"k": "v"}") into its unquoted elements. Map keys and values
+// are returned alternately.
+func collectionElements(s string) ([]string, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return nil, nil
+	}
+	if len(s) < 2 || !strings.ContainsRune("[{", rune(s[0])) || !strings.ContainsRune("]}", rune(s[len(s)-1])) {
+		return nil, fmt.Errorf("not a collection literal: %s", s)
+	}
+	var elements []string
+	var current strings.Builder
+	started := false
+	flush := func() {
+		if started {
+			elements = append(elements, strings.TrimSpace(current.String()))
+		}
+		current.Reset()
+		started = false
+	}
+	for i := 1; i < len(s)-1; i++ {
+		c := s[i]
+		switch {
+		case c == '\'' || c == '"':
+			// Quoted element; a doubled quote escapes itself
+			for i++; i < len(s)-1; i++ {
+				if s[i] == c {
+					if i+1 < len(s)-1 && s[i+1] == c {
+						i++
+					} else {
+						break
+					}
+				}
+				current.WriteByte(s[i])
+			}
+			started = true
+		case c == ',' || c == ':':
+			flush()
+		case c == ' ' || c == '\t' || c == '\n':
+		default:
+			current.WriteByte(c)
+			started = true
+		}
+	}
+	flush()
+	return elements, nil
+}
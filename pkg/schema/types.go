@@ -3,6 +3,8 @@
 // and used for query validation, auto-completion, and type hints.
 package schema
 
+import "strings"
+
 // Schema represents a complete CQL schema with all keyspaces.
 type Schema struct {
 	Keyspaces map[string]*Keyspace
@@ -153,6 +155,24 @@ func (ks *Keyspace) GetType(name string) *UserType {
 	return ks.Types[name]
 }
 
+// UserTypeOf returns the user-defined type of a column type like "address"
+// or "frozen<address>", or nil if it is not a UDT of the keyspace.
+func (ks *Keyspace) UserTypeOf(cqlType string) *UserType {
+	typ := strings.TrimSpace(cqlType)
+	if lower := strings.ToLower(typ); strings.HasPrefix(lower, "frozen<") && strings.HasSuffix(lower, ">") {
+		typ = strings.TrimSpace(typ[len("frozen<") : len(typ)-1])
+	}
+	if dot := strings.LastIndex(typ, "."); dot != -1 {
+		typ = typ[dot+1:]
+	}
+	return ks.GetType(strings.Trim(typ, "\""))
+}
+
+// FieldNames returns the names of the fields in definition order.
+func (udt *UserType) FieldNames() []string {
+	return orderedNames(udt.Fields, udt.FieldOrder)
+}
+
 // GetFunction returns a user-defined function by name, or nil if not found.
 func (ks *Keyspace) GetFunction(name string) *Function {
 	if ks == nil || ks.Functions == nil {
@@ -161,6 +181,14 @@ func (ks *Keyspace) GetFunction(name string) *Function {
 	return ks.Functions[name]
 }
 
+// GetAggregate returns a user-defined aggregate by name, or nil if not found.
+func (ks *Keyspace) GetAggregate(name string) *Aggregate {
+	if ks == nil || ks.Aggregates == nil {
+		return nil
+	}
+	return ks.Aggregates[name]
+}
+
 // GetIndex returns an index by name, or nil if not found.
 func (t *Table) GetIndex(name string) *Index {
 	if t == nil || t.Indexes == nil {
@@ -43,6 +43,7 @@ const (
 	StatementListPermissions
 	StatementUse
 	StatementPruneMaterializedView
+	StatementDescribe
 )
 
 // String returns the string representation of the statement type
@@ -124,6 +125,8 @@ func (s StatementType) String() string {
 		return "USE"
 	case StatementPruneMaterializedView:
 		return "PRUNE MATERIALIZED VIEW"
+	case StatementDescribe:
+		return "DESCRIBE"
 	default:
 		return "UNKNOWN"
 	}
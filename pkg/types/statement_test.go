@@ -15,6 +15,7 @@ func TestStatementTypeString(t *testing.T) {
 		{StatementCreateTable, "CREATE TABLE"},
 		{StatementDropTable, "DROP TABLE"},
 		{StatementCreateKeyspace, "CREATE KEYSPACE"},
+		{StatementDescribe, "DESCRIBE"},
 		{StatementUnknown, "UNKNOWN"},
 	}
 
@@ -39,6 +39,9 @@ type (
 	// ParseResult contains the result of parsing a CQL statement
 	ParseResult = parse.Result
 
+	// ScriptStatement is a statement of a CQL script with its location in the script
+	ScriptStatement = parse.ScriptStatement
+
 	// LintResult contains detailed lint results for a statement
 	LintResult = lint.Result
 
@@ -141,6 +144,7 @@ const (
 	StatementListPermissions        = types.StatementListPermissions
 	StatementUse                    = types.StatementUse
 	StatementPruneMaterializedView  = types.StatementPruneMaterializedView
+	StatementDescribe               = types.StatementDescribe
 )
 
 // Re-export format style constants
@@ -176,6 +180,11 @@ func ParseMultiple(input string) []*ParseResult {
 	return parse.Multiple(input)
 }
 
+// SplitScript splits a CQL script into statements with their locations
+func SplitScript(input string) []ScriptStatement {
+	return parse.SplitScript(input)
+}
+
 // IsValid returns true if the CQL input is syntactically valid
 func IsValid(input string) bool {
 	return parse.IsValid(input)
@@ -236,6 +245,11 @@ func NewSchema() *Schema {
 	return schema.NewSchema()
 }
 
+// SchemaFromCQL builds a schema from a CQL DDL script
+func SchemaFromCQL(input string) (*Schema, Errors) {
+	return schema.FromCQL(input)
+}
+
 // GetCompletions returns completion items for the given context
 func GetCompletions(ctx *CompletionContext) []CompletionItem {
 	return complete.GetCompletions(ctx)